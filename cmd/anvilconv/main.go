@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/anvil"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+func main() {
+	out := flag.String("o", "", "output directory of the converted world")
+	workers := flag.Int("j", runtime.NumCPU(), "amount of region files converted concurrently")
+	flag.Parse()
+
+	if len(flag.Args()) != 1 || *out == "" {
+		log.Fatalln("Usage: anvilconv -o <output directory> <java world directory>")
+	}
+	in := flag.Args()[0]
+
+	db, err := mcdb.Config{}.Open(*out)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	settings := db.Settings()
+	if err := anvil.ReadSettings(filepath.Join(in, "level.dat"), settings); err != nil {
+		log.Printf("Not converting world settings: %v\n", err)
+	}
+	db.SaveSettings(settings)
+
+	c := &anvil.Converter{}
+	dimensions := map[string]world.Dimension{"region": world.Overworld, "DIM-1/region": world.Nether, "DIM1/region": world.End}
+	for dir, dim := range dimensions {
+		regions, _ := filepath.Glob(filepath.Join(in, dir, "r.*.*.mca"))
+		if len(regions) == 0 {
+			continue
+		}
+		log.Printf("Converting %v region files of the %v...\n", len(regions), dim)
+		convert(c, regions, dim, db, *workers)
+	}
+	if err := db.Close(); err != nil {
+		log.Fatalln(err)
+	}
+	printUnmapped(c.Unmapped())
+}
+
+// convert converts the region files passed using a number of workers and stores the chunks in the DB.
+func convert(c *anvil.Converter, regions []string, dim world.Dimension, db *mcdb.DB, workers int) {
+	var (
+		wg     sync.WaitGroup
+		chunks atomic.Int64
+		done   atomic.Int64
+		queue  = make(chan string)
+	)
+	for i := 0; i < max(workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range queue {
+				r, err := anvil.OpenRegion(path)
+				if err != nil {
+					log.Println(err)
+					continue
+				}
+				n, err := c.ConvertRegion(r, dim, db)
+				_ = r.Close()
+				if err != nil {
+					log.Printf("%v: %v\n", filepath.Base(path), err)
+				}
+				chunks.Add(int64(n))
+				log.Printf("[%v/%v] %v: %v chunks\n", done.Add(1), len(regions), filepath.Base(path), n)
+			}
+		}()
+	}
+	for _, path := range regions {
+		queue <- path
+	}
+	close(queue)
+	wg.Wait()
+	log.Printf("Converted %v chunks of the %v.\n", chunks.Load(), dim)
+}
+
+// printUnmapped prints the block states and biomes that could not be converted, sorted by the amount of times
+// they were encountered.
+func printUnmapped(unmapped map[string]int) {
+	if len(unmapped) == 0 {
+		return
+	}
+	states := make([]string, 0, len(unmapped))
+	for state := range unmapped {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return unmapped[states[i]] > unmapped[states[j]]
+	})
+	_, _ = fmt.Fprintf(os.Stderr, "%v block states and biomes could not be converted:\n", len(states))
+	for _, state := range states {
+		_, _ = fmt.Fprintf(os.Stderr, "%8d  %v\n", unmapped[state], state)
+	}
+}
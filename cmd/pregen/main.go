@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/generator"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"log"
+	"time"
+)
+
+func main() {
+	dimension := flag.String("dim", "overworld", "dimension to generate chunks in: overworld, nether or end")
+	x := flag.Int("x", 0, "x coordinate of the centre chunk of the area")
+	z := flag.Int("z", 0, "z coordinate of the centre chunk of the area")
+	radius := flag.Int("r", 32, "radius in chunks of the area around the centre chunk")
+	seed := flag.Int64("seed", 0, "seed of the world if it does not yet exist, random if 0")
+	flag.Parse()
+
+	if len(flag.Args()) != 1 {
+		log.Fatalln("Usage: pregen [-dim dimension] [-x x] [-z z] [-r radius] [-seed seed] <world directory>")
+	}
+	db, err := mcdb.Config{Seed: *seed}.Open(flag.Args()[0])
+	if err != nil {
+		log.Fatalln(err)
+	}
+	s := db.Settings().Seed
+
+	var conf world.Config
+	switch *dimension {
+	case "overworld":
+		conf = world.Config{Dim: world.Overworld, Generator: generator.NewOverworld(s)}
+	case "nether":
+		conf = world.Config{Dim: world.Nether, Generator: generator.NewNether(s)}
+	case "end":
+		conf = world.Config{Dim: world.End, Generator: generator.NewEnd(s)}
+	default:
+		log.Fatalf("Unknown dimension %v.\n", *dimension)
+	}
+	conf.Provider = db
+	w := conf.New()
+
+	start := time.Now()
+	log.Printf("Generating chunks in a radius of %v around chunk (%v, %v) of the %v...\n", *radius, *x, *z, conf.Dim)
+	w.PregenerateArea(world.ChunkPos{int32(*x), int32(*z)}, *radius, func(done, total int) {
+		log.Printf("%v/%v chunks (%.1f%%)\n", done, total, float64(done)/float64(total)*100)
+	})
+	if err := w.Close(); err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Done in %v.\n", time.Since(start).Round(time.Second))
+}
@@ -1,6 +1,9 @@
 package block
 
-import "time"
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"time"
+)
 
 // OpenAction is a world.BlockAction to open a block at a position. It is sent for blocks such as chests.
 type OpenAction struct{ action }
@@ -26,6 +29,20 @@ type ContinueCrackAction struct {
 // StopCrackAction is a world.BlockAction to make the cracks forming in a block stop and disappear.
 type StopCrackAction struct{ action }
 
+// PistonAction is a world.BlockAction sent every tick while a piston is extending or retracting. It moves the arm of
+// the piston and the blocks that it pushes or pulls to the progress in the action.
+type PistonAction struct {
+	action
+	// Extending is true if the piston is extending, or false if it is retracting.
+	Extending bool
+	// Sticky specifies if the piston is a sticky piston.
+	Sticky bool
+	// Progress is the progress of the arm of the piston, ranging from 0 (retracted) to 1 (extended).
+	Progress float64
+	// AttachedBlocks holds the positions that the blocks moved by the piston are moving to.
+	AttachedBlocks []cube.Pos
+}
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
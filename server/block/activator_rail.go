@@ -0,0 +1,97 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ActivatorRail is a rail that activates minecarts riding over it while it is powered. Entities riding a minecart
+// are ejected from it when it passes a powered activator rail. Like powered rails, activator rails pass on redstone
+// power to up to eight activator rails connected to them.
+type ActivatorRail struct {
+	transparent
+	empty
+
+	// Shape is the shape of the rail. Activator rails cannot be curved.
+	Shape RailShape
+	// Powered is true if the rail is powered.
+	Powered bool
+}
+
+// RailShape ...
+func (a ActivatorRail) RailShape() RailShape {
+	return a.Shape
+}
+
+// Ascending returns true if the rail ascends towards one of the horizontal directions.
+func (a ActivatorRail) Ascending() bool {
+	return a.Shape.Ascending()
+}
+
+// withRailShape ...
+func (a ActivatorRail) withRailShape(shape RailShape) world.Block {
+	a.Shape = shape
+	return a
+}
+
+// railPowered ...
+func (a ActivatorRail) railPowered() bool {
+	return a.Powered
+}
+
+// withRailPowered ...
+func (a ActivatorRail) withRailPowered(powered bool) world.Block {
+	a.Powered = powered
+	return a
+}
+
+// UseOnBlock ...
+func (a ActivatorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	if !placeRail(pos, face, w, user, ctx, a) {
+		return false
+	}
+	if r, ok := w.Block(pos).(ActivatorRail); ok {
+		updateRailPower(pos, w, r)
+	}
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (a ActivatorRail) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !railSupported(pos, w) {
+		breakRail(pos, w, a)
+		return
+	}
+	updateRailPower(pos, w, a)
+}
+
+// BreakInfo ...
+func (a ActivatorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(ActivatorRail{}))
+}
+
+// HasLiquidDrops ...
+func (a ActivatorRail) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (ActivatorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:activator_rail", 0
+}
+
+// EncodeBlock ...
+func (a ActivatorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:activator_rail", map[string]any{"rail_direction": int32(a.Shape.Uint8()), "rail_data_bit": boolByte(a.Powered)}
+}
+
+// allActivatorRails ...
+func allActivatorRails() (rails []world.Block) {
+	for _, s := range StraightRailShapes() {
+		rails = append(rails, ActivatorRail{Shape: s})
+		rails = append(rails, ActivatorRail{Shape: s, Powered: true})
+	}
+	return
+}
@@ -0,0 +1,275 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math"
+)
+
+// Bed is a two blocks long block that players can sleep in to set their spawn point and to skip the night. Beds
+// explode when they are used outside the overworld.
+type Bed struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Colour is the colour of the bed.
+	Colour item.Colour
+	// Facing is the direction that the bed is facing. The head of the bed is on this side of the foot of the bed.
+	Facing cube.Direction
+	// Head is true if the block is the head part of the bed.
+	Head bool
+	// Occupied is true if a player is sleeping in the bed. It is only set for the head part of the bed.
+	Occupied bool
+}
+
+// HostileEntity represents an entity that is hostile towards players. Players are unable to sleep in a Bed while a
+// HostileEntity is close to it.
+type HostileEntity interface {
+	world.Entity
+	// Hostile returns true if the entity is currently hostile towards players.
+	Hostile() bool
+}
+
+// MaxCount always returns 1.
+func (Bed) MaxCount() int {
+	return 1
+}
+
+// Model ...
+func (Bed) Model() world.BlockModel {
+	return model.Bed{}
+}
+
+// SideClosed ...
+func (Bed) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// EntityLand ...
+func (Bed) EntityLand(_ cube.Pos, _ *world.World, e world.Entity, distance *float64) {
+	if _, ok := e.(fallDistanceEntity); ok {
+		*distance *= 0.5
+	}
+}
+
+// UseOnBlock ...
+func (b Bed) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, b)
+	if !used {
+		return
+	}
+	b.Facing, b.Head, b.Occupied = user.Rotation().Direction(), false, false
+	head := b
+	head.Head = true
+	headPos := pos.Side(b.Facing.Face())
+
+	if !replaceableWith(w, headPos, head) {
+		return false
+	}
+	if !attachedFaceSolid(pos, cube.FaceUp, w) || !attachedFaceSolid(headPos, cube.FaceUp, w) {
+		return false
+	}
+
+	ctx.IgnoreBBox = true
+	place(w, pos, b, user, ctx)
+	place(w, headPos, head, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (b Bed) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, _ *item.UseContext) bool {
+	s, ok := u.(bedSleeper)
+	if !ok {
+		return false
+	}
+	headPos, footPos := b.headPos(pos), b.footPos(pos)
+	head, ok := w.Block(headPos).(Bed)
+	if !ok {
+		return false
+	}
+	if _, ok := w.Block(footPos).(Bed); !ok {
+		return false
+	}
+	if w.Dimension() != world.Overworld {
+		// Beds cannot be used in the nether and the end: They explode instead.
+		w.SetBlock(headPos, nil, nil)
+		w.SetBlock(footPos, nil, nil)
+		ExplosionConfig{Size: 5, SpawnFire: true}.Explode(w, pos.Vec3Centre())
+		return true
+	}
+	if !bedInReach(s.Position(), headPos) && !bedInReach(s.Position(), footPos) {
+		s.Messaget("tile.bed.tooFar")
+		return true
+	}
+	if bedOccupied(headPos, w) {
+		s.Messaget("tile.bed.occupied")
+		return true
+	}
+	if w.PlayerSpawn(s.UUID()) != headPos {
+		w.SetPlayerSpawn(s.UUID(), headPos)
+		s.Messaget("tile.bed.respawnSet")
+	}
+	if !w.CanSleepAt(headPos) {
+		s.Messaget("tile.bed.noSleep")
+		return true
+	}
+	if g, ok := u.(interface{ GameMode() world.GameMode }); (!ok || !g.GameMode().CreativeInventory()) && monstersNear(headPos, w) {
+		s.Messaget("tile.bed.notSafe")
+		return true
+	}
+	if head.Occupied {
+		// The bed is still marked as occupied, but nobody is sleeping in it anymore. This may happen if the server
+		// was stopped while a player was sleeping.
+		head.Occupied = false
+		w.SetBlock(headPos, head, nil)
+	}
+	s.Sleep(headPos)
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (b Bed) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	otherPos := b.footPos(pos)
+	if !b.Head {
+		otherPos = b.headPos(pos)
+	}
+	if other, ok := w.Block(otherPos).(Bed); !ok || other.Head == b.Head {
+		w.SetBlock(pos, nil, nil)
+	}
+}
+
+// BreakInfo ...
+func (b Bed) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, nothingEffective, oneOf(Bed{Colour: b.Colour}))
+}
+
+// headPos returns the position of the head part of the bed, given the position of one of its parts.
+func (b Bed) headPos(pos cube.Pos) cube.Pos {
+	if b.Head {
+		return pos
+	}
+	return pos.Side(b.Facing.Face())
+}
+
+// footPos returns the position of the foot part of the bed, given the position of one of its parts.
+func (b Bed) footPos(pos cube.Pos) cube.Pos {
+	if !b.Head {
+		return pos
+	}
+	return pos.Side(b.Facing.Opposite().Face())
+}
+
+// StandUpPosition returns a free position next to the bed, given the position of one of its parts, that an entity
+// waking up in the bed may be placed at. The blocks on the sides of the bed are tried first, followed by the blocks
+// at the ends of the bed. If there is no free position next to the bed, false is returned.
+func (b Bed) StandUpPosition(pos cube.Pos, w *world.World) (mgl64.Vec3, bool) {
+	head, foot := b.headPos(pos), b.footPos(pos)
+	faces := []cube.Face{b.Facing.RotateLeft().Face(), b.Facing.RotateRight().Face(), b.Facing.Face(), b.Facing.Opposite().Face()}
+	for _, face := range faces {
+		for _, part := range []cube.Pos{head, foot} {
+			side := part.Side(face)
+			if side == head || side == foot {
+				continue
+			}
+			for _, y := range []int{0, 1, -1} {
+				if p := side.Add(cube.Pos{0, y}); bedStandable(p, w) {
+					return p.Vec3Middle(), true
+				}
+			}
+		}
+	}
+	return mgl64.Vec3{}, false
+}
+
+// bedStandable checks if an entity waking up in a bed can stand at the position passed. The block below must have a
+// solid top face, while the position itself and the block above it must be free of any collision.
+func bedStandable(pos cube.Pos, w *world.World) bool {
+	below := pos.Side(cube.FaceDown)
+	if !w.Block(below).Model().FaceSolid(below, cube.FaceUp, w) {
+		return false
+	}
+	for _, p := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if len(w.Block(p).Model().BBox(p, w)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// EncodeItem ...
+func (b Bed) EncodeItem() (name string, meta int16) {
+	return "minecraft:bed", int16(b.Colour.Uint8())
+}
+
+// EncodeBlock ...
+func (b Bed) EncodeBlock() (name string, properties map[string]any) {
+	return "minecraft:bed", map[string]any{"direction": int32(horizontalDirection(b.Facing)), "head_piece_bit": b.Head, "occupied_bit": b.Occupied}
+}
+
+// EncodeNBT ...
+func (b Bed) EncodeNBT() map[string]any {
+	return map[string]any{"id": "Bed", "color": b.Colour.Uint8()}
+}
+
+// DecodeNBT ...
+func (b Bed) DecodeNBT(data map[string]any) any {
+	b.Colour = item.Colours()[nbtconv.Uint8(data, "color")%16]
+	return b
+}
+
+// allBeds returns all possible beds.
+func allBeds() (beds []world.Block) {
+	for _, d := range cube.Directions() {
+		beds = append(beds, Bed{Facing: d})
+		beds = append(beds, Bed{Facing: d, Occupied: true})
+		beds = append(beds, Bed{Facing: d, Head: true})
+		beds = append(beds, Bed{Facing: d, Head: true, Occupied: true})
+	}
+	return
+}
+
+// bedSleeper represents an item.User that is able to sleep in a Bed.
+type bedSleeper interface {
+	world.Sleeper
+	// UUID returns the UUID of the bedSleeper, which is used to set its spawn point.
+	UUID() uuid.UUID
+	// Sleep makes the bedSleeper sleep in the bed at the position passed.
+	Sleep(pos cube.Pos)
+	// Messaget sends a translatable message to the bedSleeper.
+	Messaget(key string, a ...string)
+}
+
+// bedInReach checks if a bed part at the position passed is close enough to the position of a user to be used.
+func bedInReach(userPos mgl64.Vec3, pos cube.Pos) bool {
+	d := pos.Vec3Centre().Sub(userPos)
+	return math.Abs(d[0]) <= 3 && math.Abs(d[1]) <= 2 && math.Abs(d[2]) <= 3
+}
+
+// bedOccupied checks if a world.Sleeper is currently sleeping in the bed with its head at the position passed.
+func bedOccupied(headPos cube.Pos, w *world.World) bool {
+	for _, e := range w.EntitiesWithin(cube.Box(-2, -2, -2, 3, 3, 3).Translate(headPos.Vec3()), nil) {
+		if s, ok := e.(world.Sleeper); ok {
+			if pos, sleeping := s.Sleeping(); sleeping && pos == headPos {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// monstersNear checks if a HostileEntity is close enough to the bed with its head at the position passed to prevent
+// players from sleeping in it.
+func monstersNear(headPos cube.Pos, w *world.World) bool {
+	box := cube.Box(-8, -5, -8, 9, 6, 9).Translate(headPos.Vec3())
+	for _, e := range w.EntitiesWithin(box, nil) {
+		if h, ok := e.(HostileEntity); ok && h.Hostile() {
+			return true
+		}
+	}
+	return false
+}
@@ -10,6 +10,11 @@ type Bedrock struct {
 	InfiniteBurning bool
 }
 
+// PistonImmovable ...
+func (Bedrock) PistonImmovable() bool {
+	return true
+}
+
 // EncodeItem ...
 func (Bedrock) EncodeItem() (name string, meta int16) {
 	return "minecraft:bedrock", 0
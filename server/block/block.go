@@ -74,6 +74,22 @@ type EntityInsider interface {
 	EntityInside(pos cube.Pos, w *world.World, e world.Entity)
 }
 
+// ComparatorEmitter represents a block that a comparator placed behind it is able to read a signal from, such as a
+// cake or a composter. Containers do not need to implement ComparatorEmitter: Comparators read the fullness of their
+// inventory directly.
+type ComparatorEmitter interface {
+	// ComparatorSignal returns the signal, ranging from 0 to 15, that a comparator reading the block at the position
+	// passed outputs.
+	ComparatorSignal(pos cube.Pos, w *world.World) int
+}
+
+// PistonImmovable represents a block that may not be pushed or pulled by pistons. Blocks with a block entity that do
+// not implement PistonImmovable may never be moved by pistons.
+type PistonImmovable interface {
+	// PistonImmovable returns true if the block may not be moved by pistons in its current state.
+	PistonImmovable() bool
+}
+
 // Frictional represents a block that may have a custom friction value. Friction is used for entity drag when the
 // entity is on ground. If a block does not implement this interface, it should be assumed that its friction is 0.6.
 type Frictional interface {
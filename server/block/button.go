@@ -0,0 +1,138 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// Button is a non-solid block that provides redstone power for a short time after being pressed. A pressed button
+// powers the block it is attached to and all redstone components next to it.
+type Button struct {
+	transparent
+	empty
+
+	// Type is the type of the button.
+	Type ButtonType
+	// Facing is the face of the block that the button is attached to.
+	Facing cube.Face
+	// Pressed is true if the button is currently pressed.
+	Pressed bool
+}
+
+// BreakInfo ...
+func (b Button) BreakInfo() BreakInfo {
+	effective := pickaxeEffective
+	if b.Type.Wooden() {
+		effective = axeEffective
+	}
+	return newBreakInfo(0.5, alwaysHarvestable, effective, oneOf(Button{Type: b.Type}))
+}
+
+// UseOnBlock ...
+func (b Button) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, b)
+	if !used {
+		return false
+	}
+	if !attachedFaceSolid(pos, face, w) {
+		return false
+	}
+	b.Facing = face
+
+	place(w, pos, b, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (b Button) Activate(pos cube.Pos, _ cube.Face, w *world.World, _ item.User, _ *item.UseContext) bool {
+	if b.Pressed {
+		return true
+	}
+	b.Pressed = true
+	w.SetBlock(pos, b, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+	w.ScheduleBlockUpdate(pos, b.pressDuration())
+	return true
+}
+
+// ScheduledTick ...
+func (b Button) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if !b.Pressed {
+		return
+	}
+	b.Pressed = false
+	w.SetBlock(pos, b, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+}
+
+// pressDuration returns the duration that the button stays pressed for after being pressed. Wooden buttons stay
+// pressed for longer than other buttons.
+func (b Button) pressDuration() time.Duration {
+	if b.Type.Wooden() {
+		return time.Second * 3 / 2
+	}
+	return time.Second
+}
+
+// NeighbourUpdateTick ...
+func (b Button) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !attachedFaceSolid(pos, b.Facing, w) {
+		w.SetBlock(pos, nil, nil)
+		dropItem(w, item.NewStack(Button{Type: b.Type}, 1), pos.Vec3Centre())
+	}
+}
+
+// WeakPower ...
+func (b Button) WeakPower(cube.Pos, cube.Face, *world.World, bool) int {
+	if b.Pressed {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (b Button) StrongPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if b.Pressed && face == b.Facing.Opposite() {
+		return 15
+	}
+	return 0
+}
+
+// HasLiquidDrops ...
+func (b Button) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (b Button) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + buttonName(b.Type), 0
+}
+
+// EncodeBlock ...
+func (b Button) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + buttonName(b.Type), map[string]any{"button_pressed_bit": b.Pressed, "facing_direction": int32(b.Facing)}
+}
+
+// buttonName returns the name of a button of the type passed, without the namespace.
+func buttonName(t ButtonType) string {
+	if t == WoodenButton(OakWood()) {
+		return "wooden_button"
+	}
+	return t.String() + "_button"
+}
+
+// allButtons ...
+func allButtons() (buttons []world.Block) {
+	for _, t := range ButtonTypes() {
+		for _, f := range cube.Faces() {
+			buttons = append(buttons, Button{Type: t, Facing: f})
+			buttons = append(buttons, Button{Type: t, Facing: f, Pressed: true})
+		}
+	}
+	return
+}
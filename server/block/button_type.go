@@ -0,0 +1,53 @@
+package block
+
+// ButtonType represents a type of button. Buttons may be made of any type of wood, stone or polished blackstone.
+type ButtonType struct {
+	button
+}
+
+// WoodenButton returns the button type of a button made of the WoodType passed.
+func WoodenButton(w WoodType) ButtonType {
+	return ButtonType{button(w.Uint8())}
+}
+
+// StoneButton returns the button type of a stone button.
+func StoneButton() ButtonType {
+	return ButtonType{10}
+}
+
+// PolishedBlackstoneButton returns the button type of a polished blackstone button.
+func PolishedBlackstoneButton() ButtonType {
+	return ButtonType{11}
+}
+
+// ButtonTypes returns all button types.
+func ButtonTypes() []ButtonType {
+	types := make([]ButtonType, 0, len(WoodTypes())+2)
+	for _, w := range WoodTypes() {
+		types = append(types, WoodenButton(w))
+	}
+	return append(types, StoneButton(), PolishedBlackstoneButton())
+}
+
+type button uint8
+
+// Uint8 returns the button as a uint8.
+func (b button) Uint8() uint8 {
+	return uint8(b)
+}
+
+// Wooden checks if the button is made of wood.
+func (b button) Wooden() bool {
+	return b < 10
+}
+
+// String ...
+func (b button) String() string {
+	switch b {
+	case 10:
+		return "stone"
+	case 11:
+		return "polished_blackstone"
+	}
+	return wood(b).String()
+}
@@ -65,6 +65,11 @@ func (c Cake) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, _
 	return false
 }
 
+// ComparatorSignal ...
+func (c Cake) ComparatorSignal(cube.Pos, *world.World) int {
+	return (7 - c.Bites) * 2
+}
+
 // BreakInfo ...
 func (c Cake) BreakInfo() BreakInfo {
 	return newBreakInfo(0.5, neverHarvestable, nothingEffective, simpleDrops())
@@ -0,0 +1,227 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Comparator is a redstone component that compares the power it receives from behind with the power it receives from
+// its sides. Comparators are also able to measure the fullness of containers and the state of some other blocks
+// placed behind them.
+type Comparator struct {
+	transparent
+
+	// Facing is the direction in which the comparator outputs power. The main input of the comparator is received
+	// from the opposite direction.
+	Facing cube.Direction
+	// Subtract is true if the comparator is in subtract mode. In subtract mode, the comparator outputs the power from
+	// behind minus the highest power from its sides. Otherwise, the comparator outputs the power from behind if it
+	// is not lower than the power from its sides.
+	Subtract bool
+	// Powered is true if the comparator is currently outputting power.
+	Powered bool
+	// OutputSignal is the power that the comparator is currently outputting, ranging from 0 to 15.
+	OutputSignal int
+}
+
+// Model ...
+func (Comparator) Model() world.BlockModel {
+	return model.Diode{}
+}
+
+// BreakInfo ...
+func (c Comparator) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Comparator{}))
+}
+
+// UseOnBlock ...
+func (c Comparator) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, c)
+	if !used {
+		return false
+	}
+	if !attachedFaceSolid(pos, cube.FaceUp, w) {
+		return false
+	}
+	c.Facing = user.Rotation().Direction()
+	c.Subtract, c.Powered, c.OutputSignal = false, false, 0
+
+	place(w, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (c Comparator) Activate(pos cube.Pos, _ cube.Face, w *world.World, _ item.User, _ *item.UseContext) bool {
+	c.Subtract = !c.Subtract
+	w.SetBlock(pos, c, nil)
+	if c.Subtract {
+		w.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+	} else {
+		w.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+	}
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (c Comparator) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !attachedFaceSolid(pos, cube.FaceUp, w) {
+		w.SetBlock(pos, nil, nil)
+		dropItem(w, item.NewStack(Comparator{}, 1), pos.Vec3Centre())
+		return
+	}
+	if c.outputPower(pos, w) != c.OutputSignal {
+		w.ScheduleBlockUpdate(pos, time.Second/10)
+	}
+}
+
+// Tick checks if the output of the comparator changed. Changes in the contents of a container do not cause block
+// updates, so comparators check the blocks they read every tick.
+func (c Comparator) Tick(_ int64, pos cube.Pos, w *world.World) {
+	if c.outputPower(pos, w) != c.OutputSignal {
+		w.ScheduleBlockUpdate(pos, time.Second/10)
+	}
+}
+
+// ScheduledTick ...
+func (c Comparator) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if power := c.outputPower(pos, w); power != c.OutputSignal {
+		c.OutputSignal, c.Powered = power, power > 0
+		w.SetBlock(pos, c, nil)
+	}
+}
+
+// outputPower calculates the power that the comparator at the position passed should output.
+func (c Comparator) outputPower(pos cube.Pos, w *world.World) int {
+	back, side := c.inputPower(pos, w), c.sidePower(pos, w)
+	if c.Subtract {
+		return max(back-side, 0)
+	}
+	if back >= side {
+		return back
+	}
+	return 0
+}
+
+// inputPower returns the power that the comparator at the position passed receives from behind. If the block behind
+// the comparator is a Container or a ComparatorEmitter, the signal read from that block is returned instead. A
+// comparator may also read such a block if it is placed behind a solid block behind the comparator.
+func (c Comparator) inputPower(pos cube.Pos, w *world.World) int {
+	face := c.Facing.Opposite().Face()
+	back := pos.Side(face)
+	b := w.Block(back)
+	if signal, ok := comparatorSignal(back, b, w); ok {
+		return signal
+	}
+	power := w.RedstonePower(back, c.Facing.Face(), true)
+	if _, solid := b.Model().(model.Solid); solid && power < 15 {
+		if signal, ok := comparatorSignal(back.Side(face), w.Block(back.Side(face)), w); ok {
+			return signal
+		}
+	}
+	return power
+}
+
+// sidePower returns the highest power that the comparator at the position passed receives from its sides. Only
+// redstone wire, redstone blocks, repeaters and other comparators power a comparator from the side.
+func (c Comparator) sidePower(pos cube.Pos, w *world.World) int {
+	power := 0
+	for _, d := range []cube.Direction{c.Facing.RotateLeft(), c.Facing.RotateRight()} {
+		side := pos.Side(d.Face())
+		switch b := w.Block(side).(type) {
+		case RedstoneWire:
+			power = max(power, b.Power)
+		case RedstoneBlock:
+			power = 15
+		case Repeater, Comparator:
+			power = max(power, b.(world.Conductor).WeakPower(side, d.Opposite().Face(), w, true))
+		}
+	}
+	return power
+}
+
+// comparatorSignal returns the signal that a comparator reads from the block passed, if the block is a Container or
+// a ComparatorEmitter.
+func comparatorSignal(pos cube.Pos, b world.Block, w *world.World) (int, bool) {
+	switch b := b.(type) {
+	case ComparatorEmitter:
+		return b.ComparatorSignal(pos, w), true
+	case Container:
+		return containerSignal(b.Inventory()), true
+	}
+	return 0, false
+}
+
+// containerSignal returns the signal that a comparator reads from a container with the inventory passed. The signal
+// is based on how full the inventory is, taking into account the maximum count of the items in it.
+func containerSignal(inv *inventory.Inventory) int {
+	fullness, empty := 0.0, true
+	for _, it := range inv.Slots() {
+		if it.Empty() {
+			continue
+		}
+		fullness += float64(it.Count()) / float64(it.MaxCount())
+		empty = false
+	}
+	if empty {
+		return 0
+	}
+	return int(math.Floor(fullness/float64(inv.Size())*14)) + 1
+}
+
+// WeakPower ...
+func (c Comparator) WeakPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if face == c.Facing.Face() {
+		return c.OutputSignal
+	}
+	return 0
+}
+
+// StrongPower ...
+func (c Comparator) StrongPower(pos cube.Pos, face cube.Face, w *world.World, accountForDust bool) int {
+	return c.WeakPower(pos, face, w, accountForDust)
+}
+
+// DecodeNBT ...
+func (c Comparator) DecodeNBT(data map[string]any) any {
+	c.OutputSignal = int(nbtconv.Int32(data, "OutputSignal"))
+	return c
+}
+
+// EncodeNBT ...
+func (c Comparator) EncodeNBT() map[string]any {
+	return map[string]any{"id": "Comparator", "OutputSignal": int32(c.OutputSignal)}
+}
+
+// EncodeItem ...
+func (Comparator) EncodeItem() (name string, meta int16) {
+	return "minecraft:comparator", 0
+}
+
+// EncodeBlock ...
+func (c Comparator) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:unpowered_comparator"
+	if c.Powered {
+		name = "minecraft:powered_comparator"
+	}
+	return name, map[string]any{"minecraft:cardinal_direction": c.Facing.String(), "output_lit_bit": c.Powered, "output_subtract_bit": c.Subtract}
+}
+
+// allComparators ...
+func allComparators() (comparators []world.Block) {
+	for _, d := range cube.Directions() {
+		comparators = append(comparators, Comparator{Facing: d})
+		comparators = append(comparators, Comparator{Facing: d, Subtract: true})
+		comparators = append(comparators, Comparator{Facing: d, Powered: true})
+		comparators = append(comparators, Comparator{Facing: d, Subtract: true, Powered: true})
+	}
+	return
+}
@@ -91,6 +91,11 @@ func (c Composter) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
 	}
 }
 
+// ComparatorSignal ...
+func (c Composter) ComparatorSignal(cube.Pos, *world.World) int {
+	return c.Level
+}
+
 // EncodeItem ...
 func (c Composter) EncodeItem() (name string, meta int16) {
 	return "minecraft:composter", 0
@@ -28,3 +28,46 @@ type Container interface {
 	RemoveViewer(v ContainerViewer, w *world.World, pos cube.Pos)
 	Inventory() *inventory.Inventory
 }
+
+// insertContainerItem inserts one item of the stack passed into the container passed. The item is moved into the
+// container towards the face passed: smelters only accept items to smelt from above and fuel from their sides. True
+// is returned if the item was inserted.
+func insertContainerItem(c Container, face cube.Face, it item.Stack) bool {
+	it = it.Grow(1 - it.Count())
+	inv := c.Inventory()
+	switch c.(type) {
+	case Furnace, BlastFurnace, Smoker:
+		if face == cube.FaceDown {
+			return insertSlotItem(inv, 0, it)
+		}
+		if f, ok := it.Item().(item.Fuel); ok && face != cube.FaceUp && f.FuelInfo().Duration > 0 {
+			return insertSlotItem(inv, 1, it)
+		}
+		return false
+	}
+	_, err := inv.AddItem(it)
+	return err == nil
+}
+
+// insertSlotItem inserts the item passed into a specific slot of the inventory passed. True is returned if the slot
+// was empty or if the item could be stacked onto the item already in the slot.
+func insertSlotItem(inv *inventory.Inventory, slot int, it item.Stack) bool {
+	existing, _ := inv.Item(slot)
+	if existing.Empty() {
+		return inv.SetItem(slot, it) == nil
+	}
+	if !existing.Comparable(it) || existing.Count()+it.Count() > existing.MaxCount() {
+		return false
+	}
+	return inv.SetItem(slot, existing.Grow(it.Count())) == nil
+}
+
+// extractableSlot checks if items may be extracted from the slot passed of the container passed by a hopper. Only the
+// products of smelters may be extracted.
+func extractableSlot(c Container, slot int) bool {
+	switch c.(type) {
+	case Furnace, BlastFurnace, Smoker:
+		return slot == 2
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// DetectorRail is a rail that provides redstone power while a minecart is riding over it.
+type DetectorRail struct {
+	transparent
+	empty
+
+	// Shape is the shape of the rail. Detector rails cannot be curved.
+	Shape RailShape
+	// Powered is true if a minecart is on the rail.
+	Powered bool
+}
+
+// RailShape ...
+func (d DetectorRail) RailShape() RailShape {
+	return d.Shape
+}
+
+// Ascending returns true if the rail ascends towards one of the horizontal directions.
+func (d DetectorRail) Ascending() bool {
+	return d.Shape.Ascending()
+}
+
+// withRailShape ...
+func (d DetectorRail) withRailShape(shape RailShape) world.Block {
+	d.Shape = shape
+	return d
+}
+
+// UseOnBlock ...
+func (d DetectorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	return placeRail(pos, face, w, user, ctx, d)
+}
+
+// NeighbourUpdateTick ...
+func (d DetectorRail) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !railSupported(pos, w) {
+		breakRail(pos, w, d)
+	}
+}
+
+// EntityInside ...
+func (d DetectorRail) EntityInside(pos cube.Pos, w *world.World, e world.Entity) {
+	if !d.Powered && minecart(e) {
+		// Once pressed, the detector rail is updated through scheduled ticks until the minecart leaves it again.
+		d.update(pos, w)
+	}
+}
+
+// ScheduledTick ...
+func (d DetectorRail) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if d.Powered {
+		d.update(pos, w)
+	}
+}
+
+// update checks if a minecart is on the detector rail at the position passed and updates the rail if its powered
+// state changed. As long as the rail is powered, it schedules a block update to check for minecarts again.
+func (d DetectorRail) update(pos cube.Pos, w *world.World) {
+	box := cube.Box(0.2, 0, 0.2, 0.8, 0.8, 0.8).Translate(pos.Vec3())
+
+	powered := false
+	for _, e := range w.EntitiesWithin(box.Grow(2), nil) {
+		if minecart(e) && e.Type().BBox(e).Translate(e.Position()).IntersectsWith(box) {
+			powered = true
+			break
+		}
+	}
+	if powered != d.Powered {
+		d.Powered = powered
+		w.SetBlock(pos, d, nil)
+	}
+	if powered {
+		w.ScheduleBlockUpdate(pos, time.Second)
+	}
+}
+
+// WeakPower ...
+func (d DetectorRail) WeakPower(cube.Pos, cube.Face, *world.World, bool) int {
+	if d.Powered {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (d DetectorRail) StrongPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if d.Powered && face == cube.FaceDown {
+		return 15
+	}
+	return 0
+}
+
+// BreakInfo ...
+func (d DetectorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(DetectorRail{}))
+}
+
+// HasLiquidDrops ...
+func (d DetectorRail) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (DetectorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:detector_rail", 0
+}
+
+// EncodeBlock ...
+func (d DetectorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:detector_rail", map[string]any{"rail_direction": int32(d.Shape.Uint8()), "rail_data_bit": boolByte(d.Powered)}
+}
+
+// allDetectorRails ...
+func allDetectorRails() (rails []world.Block) {
+	for _, s := range StraightRailShapes() {
+		rails = append(rails, DetectorRail{Shape: s})
+		rails = append(rails, DetectorRail{Shape: s, Powered: true})
+	}
+	return
+}
+
+// minecart checks if the entity passed is a minecart of any kind.
+func minecart(e world.Entity) bool {
+	return strings.HasSuffix(e.Type().EncodeEntity(), "minecart")
+}
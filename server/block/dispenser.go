@@ -0,0 +1,254 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Dispenser is a block that dispenses a random item from its inventory when it is powered by redstone. Items that
+// implement item.Dispensable are used or shot out of the dispenser, while other items are dropped.
+type Dispenser struct {
+	solid
+
+	// Facing is the face that the dispenser is facing. Items are dispensed towards this face.
+	Facing cube.Face
+	// Triggered is true if the dispenser was triggered by redstone power and is still powered.
+	Triggered bool
+	// CustomName is the custom name of the dispenser. This name is displayed when the dispenser is opened, and may
+	// include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewDispenser creates a new initialised dispenser. The inventory is properly initialised.
+func NewDispenser() Dispenser {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Dispenser{
+		inventory: inventory.New(9, func(slot int, _, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+	}
+}
+
+// Inventory returns the inventory of the dispenser. The size of the inventory will be 9.
+func (d Dispenser) Inventory() *inventory.Inventory {
+	return d.inventory
+}
+
+// WithName returns the dispenser after applying a specific name to the block.
+func (d Dispenser) WithName(a ...any) world.Item {
+	d.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return d
+}
+
+// AddViewer adds a viewer to the dispenser, so that it is updated whenever the inventory of the dispenser is changed.
+func (d Dispenser) AddViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	d.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the dispenser, so that slot updates in the inventory are no longer sent to it.
+func (d Dispenser) RemoveViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	delete(d.viewers, v)
+}
+
+// Activate ...
+func (d Dispenser) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (d Dispenser) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, d)
+	if !used {
+		return
+	}
+	//noinspection GoAssignmentToReceiver
+	d = NewDispenser()
+	d.Facing = calculateFace(user, pos)
+
+	place(w, pos, d, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (d Dispenser) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if triggered, ok := dispenserTriggered(pos, w, d.Triggered); ok {
+		d.Triggered = triggered
+		w.SetBlock(pos, d, nil)
+	}
+}
+
+// ScheduledTick dispenses a random item from the inventory of the dispenser.
+func (d Dispenser) ScheduledTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	slot, it, ok := dispenseSlot(d.inventory, r)
+	if !ok {
+		w.PlaySound(pos.Vec3Centre(), sound.ClickFail{})
+		return
+	}
+	dispensable, ok := it.Item().(item.Dispensable)
+	if !ok {
+		dispenseItem(w, pos, d.Facing, it.Grow(1-it.Count()))
+		_ = d.inventory.SetItem(slot, it.Grow(-1))
+		w.PlaySound(pos.Vec3Centre(), sound.Click{})
+		return
+	}
+	ctx := &item.UseContext{}
+	if !dispensable.Dispense(pos, d.Facing, w, ctx) {
+		w.PlaySound(pos.Vec3Centre(), sound.ClickFail{})
+		return
+	}
+	w.PlaySound(pos.Vec3Centre(), sound.Click{})
+
+	it = it.Damage(ctx.Damage).Grow(-ctx.CountSub)
+	if it.Empty() {
+		it, ctx.NewItem = ctx.NewItem, item.Stack{}
+	}
+	_ = d.inventory.SetItem(slot, it)
+	if !ctx.NewItem.Empty() {
+		// The new item could not replace the dispensed item, so we try to add it to the inventory of the dispenser
+		// and drop it if the inventory is full.
+		if n, err := d.inventory.AddItem(ctx.NewItem); err != nil {
+			dispenseItem(w, pos, d.Facing, ctx.NewItem.Grow(-n))
+		}
+	}
+}
+
+// BreakInfo ...
+func (d Dispenser) BreakInfo() BreakInfo {
+	return newBreakInfo(3.5, pickaxeHarvestable, pickaxeEffective, oneOf(Dispenser{}))
+}
+
+// DecodeNBT ...
+func (d Dispenser) DecodeNBT(data map[string]any) any {
+	facing, triggered := d.Facing, d.Triggered
+	//noinspection GoAssignmentToReceiver
+	d = NewDispenser()
+	d.Facing, d.Triggered = facing, triggered
+	d.CustomName = nbtconv.String(data, "CustomName")
+	nbtconv.InvFromNBT(d.inventory, nbtconv.Slice(data, "Items"))
+	return d
+}
+
+// EncodeNBT ...
+func (d Dispenser) EncodeNBT() map[string]any {
+	if d.inventory == nil {
+		facing, triggered, customName := d.Facing, d.Triggered, d.CustomName
+		//noinspection GoAssignmentToReceiver
+		d = NewDispenser()
+		d.Facing, d.Triggered, d.CustomName = facing, triggered, customName
+	}
+	m := map[string]any{
+		"Items": nbtconv.InvToNBT(d.inventory),
+		"id":    "Dispenser",
+	}
+	if d.CustomName != "" {
+		m["CustomName"] = d.CustomName
+	}
+	return m
+}
+
+// EncodeItem ...
+func (Dispenser) EncodeItem() (name string, meta int16) {
+	return "minecraft:dispenser", 0
+}
+
+// EncodeBlock ...
+func (d Dispenser) EncodeBlock() (string, map[string]any) {
+	return "minecraft:dispenser", map[string]any{"facing_direction": int32(d.Facing), "triggered_bit": boolByte(d.Triggered)}
+}
+
+// allDispensers ...
+func allDispensers() (dispensers []world.Block) {
+	for _, f := range cube.Faces() {
+		dispensers = append(dispensers, Dispenser{Facing: f})
+		dispensers = append(dispensers, Dispenser{Facing: f, Triggered: true})
+	}
+	return
+}
+
+// dispenserTriggered checks if a dispenser or dropper at the position passed should change its triggered state. If
+// the block started receiving redstone power, an update is scheduled after which it dispenses an item. The new
+// triggered state is returned, together with a bool that is true if the state changed.
+func dispenserTriggered(pos cube.Pos, w *world.World, triggered bool) (bool, bool) {
+	powered := w.ReceivedRedstonePower(pos, true) > 0
+	if powered && !triggered {
+		w.ScheduleBlockUpdate(pos, time.Second/5)
+	}
+	return powered, powered != triggered
+}
+
+// dispenseSlot returns a random slot of the inventory passed that is not empty, together with the item in that slot.
+// False is returned if the inventory is empty.
+func dispenseSlot(inv *inventory.Inventory, r *rand.Rand) (int, item.Stack, bool) {
+	slot, n := -1, 0
+	for i, it := range inv.Slots() {
+		if it.Empty() {
+			continue
+		}
+		if n++; r.Intn(n) == 0 {
+			slot = i
+		}
+	}
+	if slot == -1 {
+		return 0, item.Stack{}, false
+	}
+	it, _ := inv.Item(slot)
+	return slot, it, true
+}
+
+// dispenseItem drops the item stack passed out of the dispenser or dropper at the position passed, facing the face
+// passed.
+func dispenseItem(w *world.World, pos cube.Pos, face cube.Face, it item.Stack) {
+	spawnPos := item.DispensePosition(pos, face)
+	if face.Axis() == cube.Y {
+		spawnPos[1] -= 0.15625
+	} else {
+		spawnPos[1] -= 0.125
+	}
+	vel := cube.Pos{}.Side(face).Vec3().Mul(rand.Float64()*0.1 + 0.2).Add(mgl64.Vec3{0, 0.2})
+	for i := range vel {
+		vel[i] += rand.NormFloat64() * 0.1
+	}
+	create := w.EntityRegistry().Config().Item
+	w.AddEntity(create(it, spawnPos, vel))
+}
+
+// dispensePlace places the block passed in front of the dispenser at the position passed, if the block in front of
+// the dispenser may be replaced and is inside the world border. True is returned if the block was placed.
+func dispensePlace(pos cube.Pos, face cube.Face, w *world.World, b world.Block, ctx *item.UseContext) bool {
+	pos = pos.Side(face)
+	if !w.Border().ContainsBlock(pos) || !replaceableWith(w, pos, b) {
+		return false
+	}
+	place(w, pos, b, nil, ctx)
+	ctx.SubtractFromCount(1)
+	return true
+}
@@ -0,0 +1,178 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"strings"
+	"sync"
+)
+
+// Dropper is a block that drops a random item from its inventory when it is powered by redstone. Unlike dispensers,
+// droppers always drop items as they are. If a dropper faces a container, the item is pushed into it instead.
+type Dropper struct {
+	solid
+
+	// Facing is the face that the dropper is facing. Items are dropped towards this face.
+	Facing cube.Face
+	// Triggered is true if the dropper was triggered by redstone power and is still powered.
+	Triggered bool
+	// CustomName is the custom name of the dropper. This name is displayed when the dropper is opened, and may
+	// include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewDropper creates a new initialised dropper. The inventory is properly initialised.
+func NewDropper() Dropper {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Dropper{
+		inventory: inventory.New(9, func(slot int, _, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+	}
+}
+
+// Inventory returns the inventory of the dropper. The size of the inventory will be 9.
+func (d Dropper) Inventory() *inventory.Inventory {
+	return d.inventory
+}
+
+// WithName returns the dropper after applying a specific name to the block.
+func (d Dropper) WithName(a ...any) world.Item {
+	d.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return d
+}
+
+// AddViewer adds a viewer to the dropper, so that it is updated whenever the inventory of the dropper is changed.
+func (d Dropper) AddViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	d.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the dropper, so that slot updates in the inventory are no longer sent to it.
+func (d Dropper) RemoveViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	d.viewerMu.Lock()
+	defer d.viewerMu.Unlock()
+	delete(d.viewers, v)
+}
+
+// Activate ...
+func (d Dropper) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (d Dropper) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, d)
+	if !used {
+		return
+	}
+	//noinspection GoAssignmentToReceiver
+	d = NewDropper()
+	d.Facing = calculateFace(user, pos)
+
+	place(w, pos, d, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (d Dropper) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if triggered, ok := dispenserTriggered(pos, w, d.Triggered); ok {
+		d.Triggered = triggered
+		w.SetBlock(pos, d, nil)
+	}
+}
+
+// ScheduledTick drops a random item from the inventory of the dropper, or pushes it into the container that the
+// dropper is facing.
+func (d Dropper) ScheduledTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	slot, it, ok := dispenseSlot(d.inventory, r)
+	if !ok {
+		w.PlaySound(pos.Vec3Centre(), sound.ClickFail{})
+		return
+	}
+	if dest, ok := w.Block(pos.Side(d.Facing)).(Container); ok {
+		if insertContainerItem(dest, d.Facing, it) {
+			_ = d.inventory.SetItem(slot, it.Grow(-1))
+		}
+		return
+	}
+	dispenseItem(w, pos, d.Facing, it.Grow(1-it.Count()))
+	_ = d.inventory.SetItem(slot, it.Grow(-1))
+	w.PlaySound(pos.Vec3Centre(), sound.Click{})
+}
+
+// BreakInfo ...
+func (d Dropper) BreakInfo() BreakInfo {
+	return newBreakInfo(3.5, pickaxeHarvestable, pickaxeEffective, oneOf(Dropper{}))
+}
+
+// DecodeNBT ...
+func (d Dropper) DecodeNBT(data map[string]any) any {
+	facing, triggered := d.Facing, d.Triggered
+	//noinspection GoAssignmentToReceiver
+	d = NewDropper()
+	d.Facing, d.Triggered = facing, triggered
+	d.CustomName = nbtconv.String(data, "CustomName")
+	nbtconv.InvFromNBT(d.inventory, nbtconv.Slice(data, "Items"))
+	return d
+}
+
+// EncodeNBT ...
+func (d Dropper) EncodeNBT() map[string]any {
+	if d.inventory == nil {
+		facing, triggered, customName := d.Facing, d.Triggered, d.CustomName
+		//noinspection GoAssignmentToReceiver
+		d = NewDropper()
+		d.Facing, d.Triggered, d.CustomName = facing, triggered, customName
+	}
+	m := map[string]any{
+		"Items": nbtconv.InvToNBT(d.inventory),
+		"id":    "Dropper",
+	}
+	if d.CustomName != "" {
+		m["CustomName"] = d.CustomName
+	}
+	return m
+}
+
+// EncodeItem ...
+func (Dropper) EncodeItem() (name string, meta int16) {
+	return "minecraft:dropper", 0
+}
+
+// EncodeBlock ...
+func (d Dropper) EncodeBlock() (string, map[string]any) {
+	return "minecraft:dropper", map[string]any{"facing_direction": int32(d.Facing), "triggered_bit": boolByte(d.Triggered)}
+}
+
+// allDroppers ...
+func allDroppers() (droppers []world.Block) {
+	for _, f := range cube.Faces() {
+		droppers = append(droppers, Dropper{Facing: f})
+		droppers = append(droppers, Dropper{Facing: f, Triggered: true})
+	}
+	return
+}
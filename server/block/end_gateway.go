@@ -0,0 +1,108 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// EndGateway is a block found in the end that teleports entities entering it to its exit portal. Gateways are used
+// to travel between the main island of the end and the outer islands, and back.
+type EndGateway struct {
+	transparent
+	empty
+
+	// ExitPortal is the position of the end gateway that this end gateway leads to. If ExitPortal is the zero
+	// cube.Pos, no exit has been set, and an exit with a gateway leading back is created in the outer islands of the
+	// end the first time an entity enters the gateway.
+	ExitPortal cube.Pos
+}
+
+// LightEmissionLevel ...
+func (EndGateway) LightEmissionLevel() uint8 {
+	return 15
+}
+
+// Destination returns the position that entities entering the end gateway at the cube.Pos passed are teleported to.
+// This is a position on top of the highest solid block near the exit portal of the gateway. If the gateway has no
+// exit portal yet, one is created.
+func (g EndGateway) Destination(pos cube.Pos, w *world.World) cube.Pos {
+	if g.ExitPortal == (cube.Pos{}) {
+		g.ExitPortal = createEndGatewayExit(pos, w)
+		w.SetBlock(pos, g, nil)
+	}
+	return endGatewayLanding(g.ExitPortal, w)
+}
+
+// createEndGatewayExit creates an end gateway in the outer islands of the end, 1024 blocks away from the centre of
+// the end in the direction of the gateway at the cube.Pos passed. The new gateway leads back to pos. If there is no
+// island at that position, a small platform of end stone is placed instead. The position of the new gateway is
+// returned.
+func createEndGatewayExit(pos cube.Pos, w *world.World) cube.Pos {
+	dir := mgl64.Vec2{float64(pos[0]), float64(pos[2])}
+	if dir.Len() == 0 {
+		dir = mgl64.Vec2{1, 0}
+	}
+	dir = dir.Normalize().Mul(1024)
+	target := cube.Pos{int(dir[0]), 75, int(dir[1])}
+
+	ground, found := target, false
+	for x := target[0] - 16; x <= target[0]+16; x++ {
+		for z := target[2] - 16; z <= target[2]+16; z++ {
+			y := w.HighestBlock(x, z)
+			if _, ok := w.Block(cube.Pos{x, y, z}).(EndStone); ok && (!found || y > ground[1]) {
+				ground, found = cube.Pos{x, y, z}, true
+			}
+		}
+	}
+	if !found {
+		for x := -2; x <= 2; x++ {
+			for z := -2; z <= 2; z++ {
+				w.SetBlock(target.Add(cube.Pos{x, 0, z}), EndStone{}, nil)
+			}
+		}
+	}
+	exit := ground.Add(cube.Pos{0, 10})
+	w.SetBlock(exit.Side(cube.FaceDown), Bedrock{}, nil)
+	w.SetBlock(exit, EndGateway{ExitPortal: pos}, nil)
+	w.SetBlock(exit.Side(cube.FaceUp), Bedrock{}, nil)
+	return exit
+}
+
+// endGatewayLanding returns the position above the highest solid block, other than bedrock, within 5 blocks of the
+// exit portal passed on the horizontal axes. If no such block is found, the position above the bedrock on top of the
+// exit portal is returned.
+func endGatewayLanding(exit cube.Pos, w *world.World) cube.Pos {
+	landing, found := exit.Add(cube.Pos{0, 2}), false
+	for x := exit[0] - 5; x <= exit[0]+5; x++ {
+		for z := exit[2] - 5; z <= exit[2]+5; z++ {
+			top := cube.Pos{x, w.HighestBlock(x, z), z}
+			b := w.Block(top)
+			if _, bedrock := b.(Bedrock); bedrock || !b.Model().FaceSolid(top, cube.FaceUp, w) {
+				// Bedrock is skipped so that entities are not placed on top of the gateway itself.
+				continue
+			}
+			if !found || top[1] >= landing[1] {
+				landing, found = top.Side(cube.FaceUp), true
+			}
+		}
+	}
+	return landing
+}
+
+// DecodeNBT ...
+func (g EndGateway) DecodeNBT(data map[string]any) any {
+	g.ExitPortal = nbtconv.Pos(data, "ExitPortal")
+	return g
+}
+
+// EncodeNBT ...
+func (g EndGateway) EncodeNBT() map[string]any {
+	return map[string]any{"id": "EndGateway", "Age": int32(0), "ExitPortal": nbtconv.PosToInt32Slice(g.ExitPortal)}
+}
+
+// EncodeBlock ...
+func (EndGateway) EncodeBlock() (string, map[string]any) {
+	return "minecraft:end_gateway", nil
+}
@@ -0,0 +1,46 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// EndPortal is the block found inside a completed ring of end portal frames and in the exit portal of the end.
+// Entities that enter an end portal are transported to the end, or back to the overworld if they are already in the
+// end.
+type EndPortal struct {
+	transparent
+	empty
+}
+
+// LightEmissionLevel ...
+func (EndPortal) LightEmissionLevel() uint8 {
+	return 15
+}
+
+// PistonImmovable ...
+func (EndPortal) PistonImmovable() bool {
+	return true
+}
+
+// EncodeBlock ...
+func (EndPortal) EncodeBlock() (string, map[string]any) {
+	return "minecraft:end_portal", nil
+}
+
+// EndPlatformPos is the position of the centre of the obsidian platform in the end that entities arrive on when
+// travelling through an end portal.
+var EndPlatformPos = cube.Pos{100, 48, 0}
+
+// CreateEndPlatform builds the 5x5 obsidian platform that entities arrive on in the end with its centre at the
+// cube.Pos passed, and clears the three blocks of space above it, so that entities cannot get stuck.
+func CreateEndPlatform(w *world.World, pos cube.Pos) {
+	for x := -2; x <= 2; x++ {
+		for z := -2; z <= 2; z++ {
+			w.SetBlock(pos.Add(cube.Pos{x, 0, z}), Obsidian{}, nil)
+			for y := 1; y <= 3; y++ {
+				w.SetBlock(pos.Add(cube.Pos{x, y, z}), nil, nil)
+			}
+		}
+	}
+}
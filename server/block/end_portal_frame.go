@@ -0,0 +1,122 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// EndPortalFrame is a block that forms the frame of an end portal. Once all twelve frames surrounding a 3x3 area hold
+// an eye of ender, the end portal inside the frames is activated. End portal frames cannot be broken in survival.
+type EndPortalFrame struct {
+	transparent
+
+	// Facing is the direction that the end portal frame faces. For an end portal to be activated, all frames must
+	// face towards the inside of the portal.
+	Facing cube.Direction
+	// Eye specifies if the end portal frame holds an eye of ender.
+	Eye bool
+}
+
+// Model ...
+func (f EndPortalFrame) Model() world.BlockModel {
+	return model.EndPortalFrame{Eye: f.Eye}
+}
+
+// LightEmissionLevel ...
+func (EndPortalFrame) LightEmissionLevel() uint8 {
+	return 1
+}
+
+// UseOnBlock ...
+func (f EndPortalFrame) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, f)
+	if !used {
+		return
+	}
+	f.Facing = user.Rotation().Direction().Opposite()
+	place(w, pos, f, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (f EndPortalFrame) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, ctx *item.UseContext) bool {
+	held, _ := u.HeldItems()
+	if _, ok := held.Item().(item.EnderEye); !ok || f.Eye {
+		return false
+	}
+	f.Eye = true
+	w.SetBlock(pos, f, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.EnderEyePlaced{})
+	ctx.SubtractFromCount(1)
+
+	for o := -1; o <= 1; o++ {
+		// The frame may be any of the three frames on its side of the portal, so we check all possible centres.
+		centre := pos.Side(f.Facing.Face()).Side(f.Facing.Face()).Add(directionOffset(f.Facing.RotateRight(), o))
+		if activateEndPortal(centre, w) {
+			break
+		}
+	}
+	return true
+}
+
+// activateEndPortal checks if the 3x3 area around the centre passed is surrounded by end portal frames that all face
+// inwards and hold an eye of ender. If so, the area is filled with EndPortal blocks and true is returned.
+func activateEndPortal(centre cube.Pos, w *world.World) bool {
+	for _, d := range cube.Directions() {
+		side := centre.Side(d.Opposite().Face()).Side(d.Opposite().Face())
+		for o := -1; o <= 1; o++ {
+			if f, ok := w.Block(side.Add(directionOffset(d.RotateRight(), o))).(EndPortalFrame); !ok || !f.Eye || f.Facing != d {
+				return false
+			}
+		}
+	}
+	for x := -1; x <= 1; x++ {
+		for z := -1; z <= 1; z++ {
+			w.SetBlock(centre.Add(cube.Pos{x, 0, z}), EndPortal{}, nil)
+		}
+	}
+	w.PlaySound(centre.Vec3Centre(), sound.EndPortalCreated{})
+	return true
+}
+
+// directionOffset returns a cube.Pos that is n blocks away from the origin in the cube.Direction passed.
+func directionOffset(d cube.Direction, n int) cube.Pos {
+	v := cube.Pos{}.Side(d.Face())
+	return cube.Pos{v[0] * n, 0, v[2] * n}
+}
+
+// ComparatorSignal ...
+func (f EndPortalFrame) ComparatorSignal(cube.Pos, *world.World) int {
+	if f.Eye {
+		return 15
+	}
+	return 0
+}
+
+// PistonImmovable ...
+func (EndPortalFrame) PistonImmovable() bool {
+	return true
+}
+
+// EncodeItem ...
+func (EndPortalFrame) EncodeItem() (name string, meta int16) {
+	return "minecraft:end_portal_frame", 0
+}
+
+// EncodeBlock ...
+func (f EndPortalFrame) EncodeBlock() (string, map[string]any) {
+	return "minecraft:end_portal_frame", map[string]any{"minecraft:cardinal_direction": f.Facing.String(), "end_portal_eye_bit": f.Eye}
+}
+
+// allEndPortalFrames ...
+func allEndPortalFrames() (frames []world.Block) {
+	for _, d := range cube.Directions() {
+		frames = append(frames, EndPortalFrame{Facing: d})
+		frames = append(frames, EndPortalFrame{Facing: d, Eye: true})
+	}
+	return
+}
@@ -71,6 +71,10 @@ func (f Farmland) hydrated(pos cube.Pos, w *world.World) bool {
 // EntityLand ...
 func (f Farmland) EntityLand(pos cube.Pos, w *world.World, e world.Entity, distance *float64) {
 	if living, ok := e.(livingEntity); ok {
+		if _, player := e.(interface{ GameMode() world.GameMode }); !player && !w.BoolGameRule(world.GameRuleMobGriefing) {
+			// Mobs only trample farmland if mob griefing is enabled.
+			return
+		}
 		if fall, ok := living.(fallDistanceEntity); ok && rand.Float64() < fall.FallDistance()-0.5 {
 			w.SetBlock(pos, Dirt{}, nil)
 		}
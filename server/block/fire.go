@@ -90,7 +90,7 @@ func rainingAround(pos cube.Pos, w *world.World) bool {
 
 // tick ...
 func (f Fire) tick(pos cube.Pos, w *world.World, r *rand.Rand) {
-	if f.Type == SoulFire() {
+	if f.Type == SoulFire() || !w.BoolGameRule(world.GameRuleDoFireTick) {
 		return
 	}
 	infinitelyBurns := infinitelyBurning(pos, w)
@@ -220,6 +220,10 @@ func (f Fire) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
 
 // NeighbourUpdateTick ...
 func (f Fire) NeighbourUpdateTick(pos, neighbour cube.Pos, w *world.World) {
+	if neighbour == pos && lightNetherPortal(pos, w) {
+		// The fire was placed inside an obsidian frame, so it lights a nether portal instead.
+		return
+	}
 	below := w.Block(pos.Side(cube.FaceDown))
 	if diffuser, ok := below.(LightDiffuser); (ok && diffuser.LightDiffusionLevel() != 15) && (!neighboursFlammable(pos, w) || f.Type == SoulFire()) {
 		w.SetBlock(pos, nil, nil)
@@ -227,8 +231,10 @@ func (f Fire) NeighbourUpdateTick(pos, neighbour cube.Pos, w *world.World) {
 	}
 	switch below.(type) {
 	case SoulSand, SoulSoil:
-		f.Type = SoulFire()
-		w.SetBlock(pos, f, nil)
+		if f.Type != SoulFire() {
+			f.Type = SoulFire()
+			w.SetBlock(pos, f, nil)
+		}
 	case Water:
 		if neighbour == pos {
 			w.SetBlock(pos, nil, nil)
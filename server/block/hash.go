@@ -3,7 +3,8 @@
 package block
 
 const (
-	hashAir = iota
+	hashActivatorRail = iota
+	hashAir
 	hashAmethyst
 	hashAncientDebris
 	hashAndesite
@@ -13,6 +14,7 @@ const (
 	hashBarrier
 	hashBasalt
 	hashBeacon
+	hashBed
 	hashBedrock
 	hashBeetrootSeeds
 	hashBlackstone
@@ -21,6 +23,7 @@ const (
 	hashBone
 	hashBookshelf
 	hashBricks
+	hashButton
 	hashCactus
 	hashCake
 	hashCalcite
@@ -34,6 +37,7 @@ const (
 	hashCoalOre
 	hashCobblestone
 	hashCocoaBean
+	hashComparator
 	hashComposter
 	hashConcrete
 	hashConcretePowder
@@ -46,11 +50,13 @@ const (
 	hashDeepslate
 	hashDeepslateBricks
 	hashDeepslateTiles
+	hashDetectorRail
 	hashDiamond
 	hashDiamondOre
 	hashDiorite
 	hashDirt
 	hashDirtPath
+	hashDispenser
 	hashDoubleFlower
 	hashDoubleTallGrass
 	hashDragonEgg
@@ -61,6 +67,9 @@ const (
 	hashEmeraldOre
 	hashEnchantingTable
 	hashEndBricks
+	hashEndGateway
+	hashEndPortal
+	hashEndPortalFrame
 	hashEndStone
 	hashEnderChest
 	hashFarmland
@@ -81,6 +90,7 @@ const (
 	hashGrindstone
 	hashHayBale
 	hashHoneycomb
+	hashHopper
 	hashInvisibleBedrock
 	hashIron
 	hashIronBars
@@ -95,6 +105,7 @@ const (
 	hashLava
 	hashLeaves
 	hashLectern
+	hashLever
 	hashLight
 	hashLitPumpkin
 	hashLog
@@ -102,12 +113,14 @@ const (
 	hashMelon
 	hashMelonSeeds
 	hashMossCarpet
+	hashMovingBlock
 	hashMud
 	hashMudBricks
 	hashMuddyMangroveRoots
 	hashNetherBrickFence
 	hashNetherBricks
 	hashNetherGoldOre
+	hashNetherPortal
 	hashNetherQuartzOre
 	hashNetherSprouts
 	hashNetherWart
@@ -115,13 +128,18 @@ const (
 	hashNetherite
 	hashNetherrack
 	hashNote
+	hashObserver
 	hashObsidian
 	hashPackedIce
 	hashPackedMud
+	hashPiston
+	hashPistonArmCollision
 	hashPlanks
 	hashPodzol
 	hashPolishedBlackstoneBrick
 	hashPotato
+	hashPoweredRail
+	hashPressurePlate
 	hashPrismarine
 	hashPumpkin
 	hashPumpkinSeeds
@@ -130,10 +148,15 @@ const (
 	hashQuartz
 	hashQuartzBricks
 	hashQuartzPillar
+	hashRail
 	hashRawCopper
 	hashRawGold
 	hashRawIron
+	hashRedstoneBlock
+	hashRedstoneTorch
+	hashRedstoneWire
 	hashReinforcedDeepslate
+	hashRepeater
 	hashSand
 	hashSandstone
 	hashSeaLantern
@@ -184,6 +207,11 @@ func NextHash() uint64 {
 	return customBlockBase
 }
 
+// Hash ...
+func (a ActivatorRail) Hash() uint64 {
+	return hashActivatorRail | uint64(a.Shape.Uint8())<<8 | uint64(boolByte(a.Powered))<<12
+}
+
 // Hash ...
 func (Air) Hash() uint64 {
 	return hashAir
@@ -234,6 +262,11 @@ func (Beacon) Hash() uint64 {
 	return hashBeacon
 }
 
+// Hash ...
+func (b Bed) Hash() uint64 {
+	return hashBed | uint64(b.Facing)<<8 | uint64(boolByte(b.Head))<<10 | uint64(boolByte(b.Occupied))<<11
+}
+
 // Hash ...
 func (b Bedrock) Hash() uint64 {
 	return hashBedrock | uint64(boolByte(b.InfiniteBurning))<<8
@@ -274,6 +307,11 @@ func (Bricks) Hash() uint64 {
 	return hashBricks
 }
 
+// Hash ...
+func (b Button) Hash() uint64 {
+	return hashButton | uint64(b.Type.Uint8())<<8 | uint64(b.Facing)<<12 | uint64(boolByte(b.Pressed))<<15
+}
+
 // Hash ...
 func (c Cactus) Hash() uint64 {
 	return hashCactus | uint64(c.Age)<<8
@@ -339,6 +377,11 @@ func (c CocoaBean) Hash() uint64 {
 	return hashCocoaBean | uint64(c.Facing)<<8 | uint64(c.Age)<<10
 }
 
+// Hash ...
+func (c Comparator) Hash() uint64 {
+	return hashComparator | uint64(c.Facing)<<8 | uint64(boolByte(c.Subtract))<<10 | uint64(boolByte(c.Powered))<<11
+}
+
 // Hash ...
 func (c Composter) Hash() uint64 {
 	return hashComposter | uint64(c.Level)<<8
@@ -399,6 +442,11 @@ func (d DeepslateTiles) Hash() uint64 {
 	return hashDeepslateTiles | uint64(boolByte(d.Cracked))<<8
 }
 
+// Hash ...
+func (d DetectorRail) Hash() uint64 {
+	return hashDetectorRail | uint64(d.Shape.Uint8())<<8 | uint64(boolByte(d.Powered))<<12
+}
+
 // Hash ...
 func (Diamond) Hash() uint64 {
 	return hashDiamond
@@ -424,6 +472,11 @@ func (DirtPath) Hash() uint64 {
 	return hashDirtPath
 }
 
+// Hash ...
+func (d Dispenser) Hash() uint64 {
+	return hashDispenser | uint64(d.Facing)<<8 | uint64(boolByte(d.Triggered))<<11
+}
+
 // Hash ...
 func (d DoubleFlower) Hash() uint64 {
 	return hashDoubleFlower | uint64(boolByte(d.UpperPart))<<8 | uint64(d.Type.Uint8())<<9
@@ -449,6 +502,11 @@ func (Dripstone) Hash() uint64 {
 	return hashDripstone
 }
 
+// Hash ...
+func (d Dropper) Hash() uint64 {
+	return hashDropper | uint64(d.Facing)<<8 | uint64(boolByte(d.Triggered))<<11
+}
+
 // Hash ...
 func (Emerald) Hash() uint64 {
 	return hashEmerald
@@ -469,6 +527,21 @@ func (EndBricks) Hash() uint64 {
 	return hashEndBricks
 }
 
+// Hash ...
+func (EndGateway) Hash() uint64 {
+	return hashEndGateway
+}
+
+// Hash ...
+func (EndPortal) Hash() uint64 {
+	return hashEndPortal
+}
+
+// Hash ...
+func (f EndPortalFrame) Hash() uint64 {
+	return hashEndPortalFrame | uint64(f.Facing)<<8 | uint64(boolByte(f.Eye))<<10
+}
+
 // Hash ...
 func (EndStone) Hash() uint64 {
 	return hashEndStone
@@ -569,6 +642,11 @@ func (Honeycomb) Hash() uint64 {
 	return hashHoneycomb
 }
 
+// Hash ...
+func (h Hopper) Hash() uint64 {
+	return hashHopper | uint64(h.Facing)<<8 | uint64(boolByte(h.Powered))<<11
+}
+
 // Hash ...
 func (InvisibleBedrock) Hash() uint64 {
 	return hashInvisibleBedrock
@@ -639,6 +717,11 @@ func (l Lectern) Hash() uint64 {
 	return hashLectern | uint64(l.Facing)<<8
 }
 
+// Hash ...
+func (l Lever) Hash() uint64 {
+	return hashLever | uint64(l.Facing)<<8 | uint64(l.Axis)<<11 | uint64(boolByte(l.Powered))<<13
+}
+
 // Hash ...
 func (l Light) Hash() uint64 {
 	return hashLight | uint64(l.Level)<<8
@@ -674,6 +757,11 @@ func (MossCarpet) Hash() uint64 {
 	return hashMossCarpet
 }
 
+// Hash ...
+func (MovingBlock) Hash() uint64 {
+	return hashMovingBlock
+}
+
 // Hash ...
 func (Mud) Hash() uint64 {
 	return hashMud
@@ -704,6 +792,11 @@ func (NetherGoldOre) Hash() uint64 {
 	return hashNetherGoldOre
 }
 
+// Hash ...
+func (p NetherPortal) Hash() uint64 {
+	return hashNetherPortal | uint64(p.Axis)<<8
+}
+
 // Hash ...
 func (NetherQuartzOre) Hash() uint64 {
 	return hashNetherQuartzOre
@@ -739,6 +832,11 @@ func (Note) Hash() uint64 {
 	return hashNote
 }
 
+// Hash ...
+func (o Observer) Hash() uint64 {
+	return hashObserver | uint64(o.Facing)<<8 | uint64(boolByte(o.Powered))<<11
+}
+
 // Hash ...
 func (o Obsidian) Hash() uint64 {
 	return hashObsidian | uint64(boolByte(o.Crying))<<8
@@ -754,6 +852,16 @@ func (PackedMud) Hash() uint64 {
 	return hashPackedMud
 }
 
+// Hash ...
+func (p Piston) Hash() uint64 {
+	return hashPiston | uint64(p.Facing)<<8 | uint64(boolByte(p.Sticky))<<11
+}
+
+// Hash ...
+func (a PistonArmCollision) Hash() uint64 {
+	return hashPistonArmCollision | uint64(a.Facing)<<8 | uint64(boolByte(a.Sticky))<<11
+}
+
 // Hash ...
 func (p Planks) Hash() uint64 {
 	return hashPlanks | uint64(p.Wood.Uint8())<<8
@@ -774,6 +882,16 @@ func (p Potato) Hash() uint64 {
 	return hashPotato | uint64(p.Growth)<<8
 }
 
+// Hash ...
+func (p PoweredRail) Hash() uint64 {
+	return hashPoweredRail | uint64(p.Shape.Uint8())<<8 | uint64(boolByte(p.Powered))<<12
+}
+
+// Hash ...
+func (p PressurePlate) Hash() uint64 {
+	return hashPressurePlate | uint64(p.Type.Uint8())<<8 | uint64(p.Power)<<12
+}
+
 // Hash ...
 func (p Prismarine) Hash() uint64 {
 	return hashPrismarine | uint64(p.Type.Uint8())<<8
@@ -814,6 +932,11 @@ func (q QuartzPillar) Hash() uint64 {
 	return hashQuartzPillar | uint64(q.Axis)<<8
 }
 
+// Hash ...
+func (r Rail) Hash() uint64 {
+	return hashRail | uint64(r.Shape.Uint8())<<8
+}
+
 // Hash ...
 func (RawCopper) Hash() uint64 {
 	return hashRawCopper
@@ -829,11 +952,31 @@ func (RawIron) Hash() uint64 {
 	return hashRawIron
 }
 
+// Hash ...
+func (RedstoneBlock) Hash() uint64 {
+	return hashRedstoneBlock
+}
+
+// Hash ...
+func (t RedstoneTorch) Hash() uint64 {
+	return hashRedstoneTorch | uint64(t.Facing)<<8 | uint64(boolByte(t.Lit))<<11
+}
+
+// Hash ...
+func (r RedstoneWire) Hash() uint64 {
+	return hashRedstoneWire | uint64(r.Power)<<8
+}
+
 // Hash ...
 func (ReinforcedDeepslate) Hash() uint64 {
 	return hashReinforcedDeepslate
 }
 
+// Hash ...
+func (r Repeater) Hash() uint64 {
+	return hashRepeater | uint64(r.Facing)<<8 | uint64(r.Delay)<<10 | uint64(boolByte(r.Powered))<<18
+}
+
 // Hash ...
 func (s Sand) Hash() uint64 {
 	return hashSand | uint64(boolByte(s.Red))<<8
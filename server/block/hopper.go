@@ -0,0 +1,240 @@
+package block
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+	"sync"
+)
+
+// Hopper is a block used to transport items. Hoppers pull items from the container above them and item entities that
+// land on them, and push items into the container they are facing.
+type Hopper struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Facing is the face that the hopper is facing. Items are pushed into the container on this side of the hopper.
+	// Hoppers may face down or in any horizontal direction.
+	Facing cube.Face
+	// Powered is true if the hopper receives redstone power. Powered hoppers do not move any items.
+	Powered bool
+	// CustomName is the custom name of the hopper. This name is displayed when the hopper is opened, and may include
+	// colour codes.
+	CustomName string
+
+	// LastTick is the tick at which the hopper last moved an item.
+	LastTick int64
+	// TransferCooldown is the amount of ticks that the hopper waits after LastTick before moving another item.
+	TransferCooldown int
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+}
+
+// NewHopper creates a new initialised hopper. The inventory is properly initialised.
+func NewHopper() Hopper {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Hopper{
+		inventory: inventory.New(5, func(slot int, _, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+	}
+}
+
+// Model ...
+func (Hopper) Model() world.BlockModel {
+	return model.Hopper{}
+}
+
+// Inventory returns the inventory of the hopper. The size of the inventory will be 5.
+func (h Hopper) Inventory() *inventory.Inventory {
+	return h.inventory
+}
+
+// WithName returns the hopper after applying a specific name to the block.
+func (h Hopper) WithName(a ...any) world.Item {
+	h.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return h
+}
+
+// AddViewer adds a viewer to the hopper, so that it is updated whenever the inventory of the hopper is changed.
+func (h Hopper) AddViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	h.viewerMu.Lock()
+	defer h.viewerMu.Unlock()
+	h.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the hopper, so that slot updates in the inventory are no longer sent to it.
+func (h Hopper) RemoveViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	h.viewerMu.Lock()
+	defer h.viewerMu.Unlock()
+	delete(h.viewers, v)
+}
+
+// Activate ...
+func (h Hopper) Activate(pos cube.Pos, _ cube.Face, _ *world.World, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (h Hopper) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(w, pos, face, h)
+	if !used {
+		return
+	}
+	//noinspection GoAssignmentToReceiver
+	h = NewHopper()
+	h.Facing = face.Opposite()
+	if h.Facing == cube.FaceUp {
+		h.Facing = cube.FaceDown
+	}
+	h.Powered = w.ReceivedRedstonePower(pos, true) > 0
+
+	place(w, pos, h, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (h Hopper) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if powered := w.ReceivedRedstonePower(pos, true) > 0; powered != h.Powered {
+		h.Powered = powered
+		w.SetBlock(pos, h, nil)
+	}
+}
+
+// Tick pushes an item into the container that the hopper is facing and pulls an item from the container above it.
+// After moving an item, the hopper waits 8 ticks before moving another one.
+func (h Hopper) Tick(currentTick int64, pos cube.Pos, w *world.World) {
+	if h.Powered || currentTick-h.LastTick < int64(h.TransferCooldown) {
+		return
+	}
+	pushed := h.push(pos, w)
+	pulled := h.pull(pos, w)
+	if pushed || pulled {
+		h.LastTick, h.TransferCooldown = currentTick, 8
+		w.SetBlock(pos, h, nil)
+	}
+}
+
+// push pushes a single item from the hopper into the container that the hopper is facing. True is returned if an
+// item was pushed.
+func (h Hopper) push(pos cube.Pos, w *world.World) bool {
+	dest, ok := w.Block(pos.Side(h.Facing)).(Container)
+	if !ok {
+		return false
+	}
+	for slot, it := range h.inventory.Slots() {
+		if !it.Empty() && insertContainerItem(dest, h.Facing, it) {
+			_ = h.inventory.SetItem(slot, it.Grow(-1))
+			return true
+		}
+	}
+	return false
+}
+
+// pull pulls a single item from the container above the hopper into the hopper. True is returned if an item was
+// pulled.
+func (h Hopper) pull(pos cube.Pos, w *world.World) bool {
+	src, ok := w.Block(pos.Side(cube.FaceUp)).(Container)
+	if !ok {
+		return false
+	}
+	inv := src.Inventory()
+	for slot, it := range inv.Slots() {
+		if it.Empty() || !extractableSlot(src, slot) {
+			continue
+		}
+		if _, err := h.inventory.AddItem(it.Grow(1 - it.Count())); err == nil {
+			_ = inv.SetItem(slot, it.Grow(-1))
+			return true
+		}
+	}
+	return false
+}
+
+// CollectItem collects as many items of the stack passed into the inventory of the hopper as possible. It is called
+// for item entities that land on the hopper. The amount of items collected is returned. Powered hoppers do not
+// collect any items.
+func (h Hopper) CollectItem(it item.Stack) int {
+	if h.Powered {
+		return 0
+	}
+	n, _ := h.inventory.AddItem(it)
+	return n
+}
+
+// BreakInfo ...
+func (h Hopper) BreakInfo() BreakInfo {
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(Hopper{})).withBlastResistance(24)
+}
+
+// DecodeNBT ...
+func (h Hopper) DecodeNBT(data map[string]any) any {
+	facing, powered := h.Facing, h.Powered
+	//noinspection GoAssignmentToReceiver
+	h = NewHopper()
+	h.Facing, h.Powered = facing, powered
+	h.CustomName = nbtconv.String(data, "CustomName")
+	h.TransferCooldown = int(nbtconv.Int32(data, "TransferCooldown"))
+	nbtconv.InvFromNBT(h.inventory, nbtconv.Slice(data, "Items"))
+	return h
+}
+
+// EncodeNBT ...
+func (h Hopper) EncodeNBT() map[string]any {
+	if h.inventory == nil {
+		facing, powered, customName := h.Facing, h.Powered, h.CustomName
+		//noinspection GoAssignmentToReceiver
+		h = NewHopper()
+		h.Facing, h.Powered, h.CustomName = facing, powered, customName
+	}
+	m := map[string]any{
+		"Items":            nbtconv.InvToNBT(h.inventory),
+		"TransferCooldown": int32(h.TransferCooldown),
+		"id":               "Hopper",
+	}
+	if h.CustomName != "" {
+		m["CustomName"] = h.CustomName
+	}
+	return m
+}
+
+// EncodeItem ...
+func (Hopper) EncodeItem() (name string, meta int16) {
+	return "minecraft:hopper", 0
+}
+
+// EncodeBlock ...
+func (h Hopper) EncodeBlock() (string, map[string]any) {
+	return "minecraft:hopper", map[string]any{"facing_direction": int32(h.Facing), "toggle_bit": boolByte(h.Powered)}
+}
+
+// allHoppers ...
+func allHoppers() (hoppers []world.Block) {
+	for _, f := range cube.Faces() {
+		if f == cube.FaceUp {
+			continue
+		}
+		hoppers = append(hoppers, Hopper{Facing: f})
+		hoppers = append(hoppers, Hopper{Facing: f, Powered: true})
+	}
+	return
+}
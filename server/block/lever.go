@@ -0,0 +1,128 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Lever is a non-solid block that can be switched on and off to provide redstone power. A lever that is switched on
+// powers the block it is attached to and all redstone components next to it.
+type Lever struct {
+	transparent
+	empty
+
+	// Facing is the face of the block that the lever is attached to.
+	Facing cube.Face
+	// Axis is the horizontal axis that a lever attached to the top or bottom of a block is aligned with. It is
+	// cube.Y for levers attached to the side of a block.
+	Axis cube.Axis
+	// Powered is true if the lever is switched on.
+	Powered bool
+}
+
+// BreakInfo ...
+func (l Lever) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, nothingEffective, oneOf(Lever{}))
+}
+
+// UseOnBlock ...
+func (l Lever) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, l)
+	if !used {
+		return false
+	}
+	if !attachedFaceSolid(pos, face, w) {
+		return false
+	}
+	l.Facing, l.Axis = face, cube.Y
+	if face == cube.FaceUp || face == cube.FaceDown {
+		l.Axis = user.Rotation().Direction().Face().Axis()
+	}
+
+	place(w, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (l Lever) Activate(pos cube.Pos, _ cube.Face, w *world.World, _ item.User, _ *item.UseContext) bool {
+	l.Powered = !l.Powered
+	w.SetBlock(pos, l, nil)
+	if l.Powered {
+		w.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+	} else {
+		w.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+	}
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (l Lever) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !attachedFaceSolid(pos, l.Facing, w) {
+		w.SetBlock(pos, nil, nil)
+		dropItem(w, item.NewStack(Lever{}, 1), pos.Vec3Centre())
+	}
+}
+
+// WeakPower ...
+func (l Lever) WeakPower(cube.Pos, cube.Face, *world.World, bool) int {
+	if l.Powered {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (l Lever) StrongPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if l.Powered && face == l.Facing.Opposite() {
+		return 15
+	}
+	return 0
+}
+
+// HasLiquidDrops ...
+func (l Lever) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (l Lever) EncodeItem() (name string, meta int16) {
+	return "minecraft:lever", 0
+}
+
+// EncodeBlock ...
+func (l Lever) EncodeBlock() (string, map[string]any) {
+	direction := l.Facing.String()
+	if l.Facing == cube.FaceUp || l.Facing == cube.FaceDown {
+		if l.Axis == cube.Z {
+			direction += "_north_south"
+		} else {
+			direction += "_east_west"
+		}
+	}
+	return "minecraft:lever", map[string]any{"lever_direction": direction, "open_bit": l.Powered}
+}
+
+// attachedFaceSolid checks if the block that a block at the position passed, attached to the face passed, is
+// attached to is solid on that face.
+func attachedFaceSolid(pos cube.Pos, face cube.Face, w *world.World) bool {
+	attached := pos.Side(face.Opposite())
+	return w.Block(attached).Model().FaceSolid(attached, face, w)
+}
+
+// allLevers ...
+func allLevers() (levers []world.Block) {
+	for _, f := range cube.Faces() {
+		axes := []cube.Axis{cube.Y}
+		if f == cube.FaceUp || f == cube.FaceDown {
+			axes = []cube.Axis{cube.X, cube.Z}
+		}
+		for _, a := range axes {
+			levers = append(levers, Lever{Facing: f, Axis: a})
+			levers = append(levers, Lever{Facing: f, Axis: a, Powered: true})
+		}
+	}
+	return
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Diode is a model used by redstone diodes, such as repeaters and comparators.
+type Diode struct{}
+
+// BBox returns a flat BBox with a height of 0.125.
+func (Diode) BBox(cube.Pos, *world.World) []cube.BBox {
+	return []cube.BBox{cube.Box(0, 0, 0, 1, 0.125, 1)}
+}
+
+// FaceSolid returns true if the face passed is cube.FaceDown.
+func (Diode) FaceSolid(_ cube.Pos, face cube.Face, _ *world.World) bool {
+	return face == cube.FaceDown
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// EndPortalFrame is a model used by end portal frames.
+type EndPortalFrame struct {
+	// Eye specifies if the end portal frame holds an eye of ender.
+	Eye bool
+}
+
+// BBox ...
+func (f EndPortalFrame) BBox(cube.Pos, *world.World) []cube.BBox {
+	if f.Eye {
+		return []cube.BBox{cube.Box(0, 0, 0, 1, 0.8125, 1), cube.Box(0.3125, 0.8125, 0.3125, 0.6875, 1, 0.6875)}
+	}
+	return []cube.BBox{cube.Box(0, 0, 0, 1, 0.8125, 1)}
+}
+
+// FaceSolid ...
+func (EndPortalFrame) FaceSolid(_ cube.Pos, face cube.Face, _ *world.World) bool {
+	return face == cube.FaceDown
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Piston is a model used by the base of pistons and sticky pistons.
+type Piston struct {
+	// Facing is the face that the piston is facing, which is the face that the arm of the piston extends from.
+	Facing cube.Face
+	// Extended specifies if the arm of the piston is extended. If true, the base of the piston only fills three
+	// quarters of the block.
+	Extended bool
+}
+
+// BBox ...
+func (p Piston) BBox(cube.Pos, *world.World) []cube.BBox {
+	if !p.Extended {
+		return []cube.BBox{full}
+	}
+	return []cube.BBox{boxTowards(p.Facing.Opposite(), 0, 0.75, 0)}
+}
+
+// FaceSolid ...
+func (p Piston) FaceSolid(_ cube.Pos, face cube.Face, _ *world.World) bool {
+	return !p.Extended || face != p.Facing
+}
+
+// PistonArm is a model used by the arm of an extended piston.
+type PistonArm struct {
+	// Facing is the face that the piston the arm belongs to is facing.
+	Facing cube.Face
+}
+
+// BBox ...
+func (a PistonArm) BBox(cube.Pos, *world.World) []cube.BBox {
+	return []cube.BBox{boxTowards(a.Facing, 0, 0.25, 0), boxTowards(a.Facing, 0.25, 1, 0.375)}
+}
+
+// FaceSolid ...
+func (a PistonArm) FaceSolid(_ cube.Pos, face cube.Face, _ *world.World) bool {
+	return face == a.Facing
+}
+
+// boxTowards returns a BBox that starts at a depth of from and ends at a depth of to into the block, measured from
+// the face passed. On the other axes, the BBox is inset by the value passed from both sides.
+func boxTowards(face cube.Face, from, to, inset float64) cube.BBox {
+	if face == cube.FaceUp || face == cube.FaceSouth || face == cube.FaceEast {
+		from, to = 1-to, 1-from
+	}
+	switch face.Axis() {
+	case cube.X:
+		return cube.Box(from, inset, inset, to, 1-inset, 1-inset)
+	case cube.Y:
+		return cube.Box(inset, from, inset, 1-inset, to, 1-inset)
+	}
+	return cube.Box(inset, inset, from, 1-inset, 1-inset, to)
+}
@@ -0,0 +1,71 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"slices"
+)
+
+// MovingBlock is a block that is currently being moved by a piston. It holds the block moved, including the data of
+// its block entity, until the piston finishes moving, after which the block moved is placed at its position.
+type MovingBlock struct {
+	transparent
+	empty
+
+	// Moving is the block that is being moved.
+	Moving world.Block
+	// PistonPos is the position of the piston that is moving the block.
+	PistonPos cube.Pos
+}
+
+// PistonImmovable ...
+func (MovingBlock) PistonImmovable() bool {
+	return true
+}
+
+// Tick places the block moved if the piston that moved it no longer exists or is no longer moving it, for example
+// because it was broken while moving.
+func (m MovingBlock) Tick(_ int64, pos cube.Pos, w *world.World) {
+	if p, ok := w.Block(m.PistonPos).(Piston); ok && slices.Contains(p.AttachedBlocks, pos) {
+		return
+	}
+	w.SetBlock(pos, m.Moving, nil)
+}
+
+// DecodeNBT ...
+func (m MovingBlock) DecodeNBT(data map[string]any) any {
+	m.Moving = nbtconv.Block(data, "movingBlock")
+	if nbter, ok := m.Moving.(world.NBTer); ok {
+		if entity, ok := data["movingEntity"].(map[string]any); ok {
+			m.Moving = nbter.DecodeNBT(entity).(world.Block)
+		}
+	}
+	m.PistonPos = cube.Pos{int(nbtconv.Int32(data, "pistonPosX")), int(nbtconv.Int32(data, "pistonPosY")), int(nbtconv.Int32(data, "pistonPosZ"))}
+	return m
+}
+
+// EncodeNBT ...
+func (m MovingBlock) EncodeNBT() map[string]any {
+	var moving world.Block = Air{}
+	if m.Moving != nil {
+		moving = m.Moving
+	}
+	data := map[string]any{
+		"id":               "MovingBlock",
+		"movingBlock":      nbtconv.WriteBlock(moving),
+		"movingBlockExtra": nbtconv.WriteBlock(Air{}),
+		"pistonPosX":       int32(m.PistonPos[0]),
+		"pistonPosY":       int32(m.PistonPos[1]),
+		"pistonPosZ":       int32(m.PistonPos[2]),
+	}
+	if nbter, ok := moving.(world.NBTer); ok {
+		data["movingEntity"] = nbter.EncodeNBT()
+	}
+	return data
+}
+
+// EncodeBlock ...
+func (MovingBlock) EncodeBlock() (string, map[string]any) {
+	return "minecraft:moving_block", nil
+}
@@ -0,0 +1,283 @@
+package block
+
+import (
+	"cmp"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"slices"
+)
+
+// NetherPortal is the block that fills the inside of an obsidian nether portal frame once it is lit. Entities that
+// stand inside a nether portal for long enough are transported to the nether, or back to the overworld if they are
+// already in the nether.
+type NetherPortal struct {
+	transparent
+	empty
+
+	// Axis is the horizontal axis that the portal is aligned with. It is either cube.X or cube.Z.
+	Axis cube.Axis
+}
+
+// NeighbourUpdateTick ...
+func (p NetherPortal) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	for _, face := range portalFaces(p.Axis) {
+		switch b := w.Block(pos.Side(face)).(type) {
+		case NetherPortal:
+			if b.Axis == p.Axis {
+				continue
+			}
+		case Obsidian:
+			if !b.Crying {
+				continue
+			}
+		}
+		// The frame of the portal was broken, so the portal is broken too. The portal blocks next to this one are
+		// updated as a result, so that the whole portal is removed.
+		w.SetBlock(pos, nil, nil)
+		return
+	}
+}
+
+// LightEmissionLevel ...
+func (p NetherPortal) LightEmissionLevel() uint8 {
+	return 11
+}
+
+// PistonImmovable ...
+func (NetherPortal) PistonImmovable() bool {
+	return true
+}
+
+// EncodeBlock ...
+func (p NetherPortal) EncodeBlock() (string, map[string]any) {
+	return "minecraft:portal", map[string]any{"portal_axis": p.Axis.String()}
+}
+
+// allNetherPortals ...
+func allNetherPortals() []world.Block {
+	return []world.Block{NetherPortal{Axis: cube.X}, NetherPortal{Axis: cube.Z}}
+}
+
+const (
+	// maxPortalWidth and maxPortalHeight are the maximum width and height of the inside of a nether portal frame.
+	maxPortalWidth, maxPortalHeight = 21, 21
+	// minPortalWidth and minPortalHeight are the minimum width and height of the inside of a nether portal frame.
+	minPortalWidth, minPortalHeight = 2, 3
+)
+
+// lightNetherPortal attempts to light a nether portal in an obsidian frame that has the cube.Pos passed inside it.
+// The frame may be aligned with either the X or the Z axis. If a valid frame was found, the inside of the frame is
+// filled with NetherPortal blocks and true is returned.
+func lightNetherPortal(pos cube.Pos, w *world.World) bool {
+	for _, axis := range []cube.Axis{cube.X, cube.Z} {
+		inside, ok := netherPortalFrame(pos, axis, w)
+		if !ok {
+			continue
+		}
+		for _, p := range inside {
+			w.SetBlock(p, NetherPortal{Axis: axis}, &world.SetOpts{DisableBlockUpdates: true})
+		}
+		w.PlaySound(pos.Vec3Centre(), sound.Ignite{})
+		return true
+	}
+	return false
+}
+
+// netherPortalFrame finds the inside of an obsidian nether portal frame aligned with the axis passed that has the
+// cube.Pos passed inside it. The inside of the frame must be a rectangle of air or fire blocks, surrounded by
+// obsidian on all sides. The corners of the frame do not need to be obsidian. If no valid frame was found, false is
+// returned.
+func netherPortalFrame(pos cube.Pos, axis cube.Axis, w *world.World) ([]cube.Pos, bool) {
+	if !portalReplaceable(w.Block(pos)) {
+		return nil, false
+	}
+	var (
+		faces   = portalFaces(axis)
+		inside  = []cube.Pos{pos}
+		visited = map[cube.Pos]struct{}{pos: {}}
+		lo, hi  = pos, pos
+	)
+	// Flood fill the inside of the frame. The fill is stopped early if it becomes too large to be a valid frame.
+	for i := 0; i < len(inside); i++ {
+		for _, face := range faces {
+			n := inside[i].Side(face)
+			if _, ok := visited[n]; ok {
+				continue
+			}
+			b := w.Block(n)
+			if o, ok := b.(Obsidian); ok && !o.Crying {
+				continue
+			}
+			if !portalReplaceable(b) {
+				return nil, false
+			}
+			visited[n] = struct{}{}
+			inside = append(inside, n)
+
+			lo = cube.Pos{min(lo[0], n[0]), min(lo[1], n[1]), min(lo[2], n[2])}
+			hi = cube.Pos{max(hi[0], n[0]), max(hi[1], n[1]), max(hi[2], n[2])}
+			if width, height := hi[0]-lo[0]+hi[2]-lo[2]+1, hi[1]-lo[1]+1; width > maxPortalWidth || height > maxPortalHeight {
+				return nil, false
+			}
+		}
+	}
+	width, height := hi[0]-lo[0]+hi[2]-lo[2]+1, hi[1]-lo[1]+1
+	if width < minPortalWidth || height < minPortalHeight || len(inside) != width*height {
+		// The inside of the frame was not a rectangle.
+		return nil, false
+	}
+	return inside, true
+}
+
+// portalReplaceable checks if a block may be replaced by a NetherPortal when a portal is lit.
+func portalReplaceable(b world.Block) bool {
+	switch b.(type) {
+	case Air, Fire:
+		return true
+	}
+	return false
+}
+
+// portalFaces returns the faces of a block that lie in the plane of a nether portal aligned with the axis passed.
+func portalFaces(axis cube.Axis) []cube.Face {
+	if axis == cube.X {
+		return []cube.Face{cube.FaceUp, cube.FaceDown, cube.FaceEast, cube.FaceWest}
+	}
+	return []cube.Face{cube.FaceUp, cube.FaceDown, cube.FaceNorth, cube.FaceSouth}
+}
+
+// FindNetherPortal searches for the NetherPortal block closest to the cube.Pos passed, at most radius blocks away
+// on the horizontal axes. If one is found, the position of the lowest NetherPortal block of that column of the portal
+// is returned, so that an entity may be placed inside the portal at that position.
+// Chunks within 16 blocks of pos are loaded, or generated, to be searched. Chunks further away are only searched if
+// they are already loaded, so that a large radius does not result in hundreds of chunks being loaded at once.
+func FindNetherPortal(w *world.World, pos cube.Pos, radius int) (cube.Pos, bool) {
+	const loadRadius = 16
+	isPortal := func(b world.Block) bool {
+		_, ok := b.(NetherPortal)
+		return ok
+	}
+	r := w.Range()
+	near := min(radius, loadRadius)
+	portals := w.FindBlocks(cube.Pos{pos[0] - near, r[0], pos[2] - near}, cube.Pos{pos[0] + near, r[1], pos[2] + near}, isPortal)
+	if radius > loadRadius {
+		portals = append(portals, w.FindLoadedBlocks(cube.Pos{pos[0] - radius, r[0], pos[2] - radius}, cube.Pos{pos[0] + radius, r[1], pos[2] + radius}, isPortal)...)
+	}
+	if len(portals) == 0 {
+		return cube.Pos{}, false
+	}
+	portal := slices.MinFunc(portals, func(a, b cube.Pos) int {
+		return cmp.Compare(a.Sub(pos).Vec3().LenSqr(), b.Sub(pos).Vec3().LenSqr())
+	})
+	for {
+		if _, ok := w.Block(portal.Side(cube.FaceDown)).(NetherPortal); !ok {
+			return portal, true
+		}
+		portal = portal.Side(cube.FaceDown)
+	}
+}
+
+// CreateNetherPortal builds a lit nether portal near the cube.Pos passed. The closest position within 16 blocks on
+// the horizontal axes and 16 blocks on the vertical axis with solid ground and enough space for the portal is used. If
+// no such position exists, the portal is built at the position passed with a small obsidian platform below it. The
+// position of the lowest NetherPortal block of the portal is returned, so that an entity may be placed inside the
+// portal at that position.
+func CreateNetherPortal(w *world.World, pos cube.Pos) cube.Pos {
+	const searchRadius, searchHeight = 16, 16
+	r := w.Range()
+	// The frame of the portal is 5 blocks high, and we leave some space between the frame and the top of the world.
+	pos[1] = max(r[0]+1, min(pos[1], r[1]-10))
+
+	// The space directly above the bottom corner of the frame must be air, so only positions right below air blocks
+	// need to be checked. This allows skipping the parts of the area that do not have any air at all.
+	candidates := w.FindBlocks(
+		cube.Pos{pos[0] - searchRadius, max(r[0]+1, pos[1]-searchHeight) + 1, pos[2] - searchRadius},
+		cube.Pos{pos[0] + searchRadius, min(r[1]-5, pos[1]+searchHeight) + 1, pos[2] + searchRadius},
+		func(b world.Block) bool {
+			_, ok := b.(Air)
+			return ok
+		},
+	)
+	above := pos.Side(cube.FaceUp)
+	slices.SortFunc(candidates, func(a, b cube.Pos) int {
+		return cmp.Compare(a.Sub(above).Vec3().LenSqr(), b.Sub(above).Vec3().LenSqr())
+	})
+	for _, c := range candidates {
+		origin := c.Side(cube.FaceDown)
+		for _, axis := range []cube.Axis{cube.X, cube.Z} {
+			if netherPortalSpace(origin, axis, w) {
+				return buildNetherPortal(origin, axis, w)
+			}
+		}
+	}
+	buildNetherPortalPlatform(pos, cube.X, w)
+	return buildNetherPortal(pos, cube.X, w)
+}
+
+// netherPortalSpace checks if there is enough space for a nether portal aligned with the axis passed, with the bottom
+// corner of its frame at origin. The blocks below the frame and on both sides of it must be solid, and the space
+// above them must be air.
+func netherPortalSpace(origin cube.Pos, axis cube.Axis, w *world.World) bool {
+	dir, side := portalDirections(axis)
+	for i := 0; i < 4; i++ {
+		for k := -1; k <= 1; k++ {
+			floor := portalOffset(origin, dir, side, i, 0, k)
+			if !w.Block(floor).Model().FaceSolid(floor, cube.FaceUp, w) {
+				return false
+			}
+			for j := 1; j <= 4; j++ {
+				if _, ok := w.Block(floor.Add(cube.Pos{0, j})).(Air); !ok {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// buildNetherPortalPlatform builds an obsidian platform for a nether portal with the bottom corner of its frame at
+// origin and clears the space above it.
+func buildNetherPortalPlatform(origin cube.Pos, axis cube.Axis, w *world.World) {
+	dir, side := portalDirections(axis)
+	for i := 0; i < 4; i++ {
+		for k := -1; k <= 1; k++ {
+			floor := portalOffset(origin, dir, side, i, 0, k)
+			w.SetBlock(floor, Obsidian{}, nil)
+			for j := 1; j <= 4; j++ {
+				w.SetBlock(floor.Add(cube.Pos{0, j}), nil, nil)
+			}
+		}
+	}
+}
+
+// buildNetherPortal builds a lit nether portal with an inside of 2x3 blocks, aligned with the axis passed, with the
+// bottom corner of its frame at origin. The position of the lowest NetherPortal block of the portal is returned.
+func buildNetherPortal(origin cube.Pos, axis cube.Axis, w *world.World) cube.Pos {
+	dir, side := portalDirections(axis)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 5; j++ {
+			var b world.Block = NetherPortal{Axis: axis}
+			if i == 0 || i == 3 || j == 0 || j == 4 {
+				b = Obsidian{}
+			}
+			w.SetBlock(portalOffset(origin, dir, side, i, j, 0), b, &world.SetOpts{DisableBlockUpdates: true})
+		}
+	}
+	return portalOffset(origin, dir, side, 1, 1, 0)
+}
+
+// portalDirections returns the direction along which a nether portal aligned with the axis passed extends and the
+// direction perpendicular to it.
+func portalDirections(axis cube.Axis) (dir, side cube.Pos) {
+	if axis == cube.X {
+		return cube.Pos{1, 0, 0}, cube.Pos{0, 0, 1}
+	}
+	return cube.Pos{0, 0, 1}, cube.Pos{1, 0, 0}
+}
+
+// portalOffset returns the position i blocks in the direction dir, j blocks up and k blocks in the direction side from
+// the origin passed.
+func portalOffset(origin, dir, side cube.Pos, i, j, k int) cube.Pos {
+	return origin.Add(cube.Pos{dir[0]*i + side[0]*k, j, dir[2]*i + side[2]*k})
+}
@@ -0,0 +1,93 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// Observer is a block that emits a short redstone pulse from its back when the block in front of it changes.
+type Observer struct {
+	solid
+
+	// Facing is the direction that the observer is observing. The observer outputs power on the opposite side.
+	Facing cube.Face
+	// Powered is true if the observer is currently emitting a pulse.
+	Powered bool
+}
+
+// BreakInfo ...
+func (o Observer) BreakInfo() BreakInfo {
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(Observer{}))
+}
+
+// UseOnBlock ...
+func (o Observer) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, o)
+	if !used {
+		return false
+	}
+	o.Facing = calculateFace(user, pos).Opposite()
+	o.Powered = false
+
+	place(w, pos, o, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (o Observer) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, w *world.World) {
+	if changedNeighbour == pos.Side(o.Facing) && !o.Powered {
+		// Observers emit a pulse two ticks after the block they observe changed.
+		w.ScheduleBlockUpdate(pos, time.Second/10)
+	}
+}
+
+// ScheduledTick ...
+func (o Observer) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	o.Powered = !o.Powered
+	w.SetBlock(pos, o, nil)
+	if o.Powered {
+		// The pulse lasts for two ticks, after which the observer is turned off again.
+		w.ScheduleBlockUpdate(pos, time.Second/10)
+	}
+}
+
+// WeakPower ...
+func (o Observer) WeakPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if o.Powered && face == o.Facing.Opposite() {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (o Observer) StrongPower(pos cube.Pos, face cube.Face, w *world.World, accountForDust bool) int {
+	return o.WeakPower(pos, face, w, accountForDust)
+}
+
+// connectsWire ...
+func (o Observer) connectsWire(face cube.Face) bool {
+	return face == o.Facing.Opposite()
+}
+
+// EncodeItem ...
+func (Observer) EncodeItem() (name string, meta int16) {
+	return "minecraft:observer", 0
+}
+
+// EncodeBlock ...
+func (o Observer) EncodeBlock() (string, map[string]any) {
+	return "minecraft:observer", map[string]any{"minecraft:facing_direction": o.Facing.String(), "powered_bit": o.Powered}
+}
+
+// allObservers ...
+func allObservers() (observers []world.Block) {
+	for _, f := range cube.Faces() {
+		observers = append(observers, Observer{Facing: f})
+		observers = append(observers, Observer{Facing: f, Powered: true})
+	}
+	return
+}
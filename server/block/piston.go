@@ -0,0 +1,282 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Piston is a block that pushes the blocks in front of it when it receives redstone power. Sticky pistons also pull
+// the block in front of them back when they stop receiving power.
+type Piston struct {
+	transparent
+
+	// Facing is the face that the piston is facing. The arm of the piston extends towards this face.
+	Facing cube.Face
+	// Sticky specifies if the piston is a sticky piston, which pulls back the block in front of it when it retracts.
+	Sticky bool
+
+	// Extended is true if the arm of the piston is extended or currently extending.
+	Extended bool
+	// Progress is the progress of the arm of the piston, ranging from 0 (retracted) to 1 (extended). The progress
+	// changes by 0.5 every tick while the piston is moving.
+	Progress float64
+	// AttachedBlocks holds the positions that the blocks currently moved by the piston are moving to. It is empty
+	// if the piston is not moving.
+	AttachedBlocks []cube.Pos
+}
+
+// Model ...
+func (p Piston) Model() world.BlockModel {
+	return model.Piston{Facing: p.Facing, Extended: p.Extended || p.Progress > 0}
+}
+
+// BreakInfo ...
+func (p Piston) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, oneOf(Piston{Sticky: p.Sticky}))
+}
+
+// PistonImmovable ...
+func (p Piston) PistonImmovable() bool {
+	return p.Extended || p.Progress > 0
+}
+
+// UseOnBlock ...
+func (p Piston) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, p)
+	if !used {
+		return false
+	}
+	p.Facing = calculateFace(user, pos)
+	p.Extended, p.Progress, p.AttachedBlocks = false, 0, nil
+
+	place(w, pos, p, user, ctx)
+	if placed(ctx) {
+		// The piston might be placed next to a block that is already powered, so we check for power after placing it.
+		w.ScheduleBlockUpdate(pos, time.Second/20)
+		return true
+	}
+	return false
+}
+
+// NeighbourUpdateTick ...
+func (p Piston) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	p.update(pos, w)
+}
+
+// ScheduledTick ...
+func (p Piston) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	p.update(pos, w)
+}
+
+// Tick moves the arm of the piston if it is currently extending or retracting. Once the arm finished moving, the
+// blocks moved by the piston are placed at their new positions.
+func (p Piston) Tick(_ int64, pos cube.Pos, w *world.World) {
+	if !p.moving() {
+		return
+	}
+	if p.Extended {
+		p.Progress = math.Min(p.Progress+0.5, 1)
+	} else {
+		p.Progress = math.Max(p.Progress-0.5, 0)
+	}
+	if !p.moving() {
+		for _, attached := range p.AttachedBlocks {
+			if m, ok := w.Block(attached).(MovingBlock); ok && m.PistonPos == pos {
+				w.SetBlock(attached, m.Moving, nil)
+			}
+		}
+		p.AttachedBlocks = nil
+	}
+	w.SetBlock(pos, p, nil)
+	p.animate(pos, w)
+
+	if !p.moving() {
+		// The piston might have been powered or unpowered while it was moving.
+		p.update(pos, w)
+	}
+}
+
+// update extends or retracts the piston at the position passed if it started or stopped receiving redstone power.
+func (p Piston) update(pos cube.Pos, w *world.World) {
+	if p.moving() {
+		return
+	}
+	if powered := p.powered(pos, w); powered && !p.Extended {
+		p.extend(pos, w)
+	} else if !powered && p.Extended {
+		p.retract(pos, w)
+	}
+}
+
+// extend starts extending the arm of the piston at the position passed. The blocks in front of the piston are pushed
+// forward. If these blocks cannot be pushed, the piston does not extend.
+func (p Piston) extend(pos cube.Pos, w *world.World) {
+	head := pos.Side(p.Facing)
+	r := pistonResolver{w: w, pos: pos, push: p.Facing}
+	if !r.resolve(head, true) {
+		return
+	}
+	p.Extended, p.Progress = true, 0
+	p.AttachedBlocks = r.move(w)
+
+	w.SetBlock(head, PistonArmCollision{Facing: p.Facing, Sticky: p.Sticky}, nil)
+	w.SetBlock(pos, p, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.PistonExtend{})
+	p.animate(pos, w)
+}
+
+// retract starts retracting the arm of the piston at the position passed. Sticky pistons pull back the blocks in
+// front of their arm if possible.
+func (p Piston) retract(pos cube.Pos, w *world.World) {
+	head := pos.Side(p.Facing)
+	if _, ok := w.Block(head).(PistonArmCollision); ok {
+		w.SetBlock(head, nil, nil)
+	}
+	p.Extended, p.Progress, p.AttachedBlocks = false, 1, nil
+	if p.Sticky {
+		r := pistonResolver{w: w, pos: pos, push: p.Facing.Opposite()}
+		if r.resolve(head.Side(p.Facing), false) {
+			p.AttachedBlocks = r.move(w)
+		}
+	}
+	w.SetBlock(pos, p, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.PistonRetract{})
+	p.animate(pos, w)
+}
+
+// animate shows the current progress of the arm of the piston and the blocks that it moves to the viewers of the
+// position passed.
+func (p Piston) animate(pos cube.Pos, w *world.World) {
+	for _, v := range w.Viewers(pos.Vec3()) {
+		v.ViewBlockAction(pos, PistonAction{Extending: p.Extended, Sticky: p.Sticky, Progress: p.Progress, AttachedBlocks: p.AttachedBlocks})
+	}
+}
+
+// powered checks if the piston at the position passed receives redstone power. Pistons are not powered by blocks
+// in front of them.
+func (p Piston) powered(pos cube.Pos, w *world.World) bool {
+	for _, f := range cube.Faces() {
+		if f != p.Facing && w.RedstonePower(pos.Side(f), f.Opposite(), true) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// moving checks if the arm of the piston is currently extending or retracting.
+func (p Piston) moving() bool {
+	if p.Extended {
+		return p.Progress < 1
+	}
+	return p.Progress > 0
+}
+
+// state returns the state of the arm of the piston as used in the block entity data of the piston. 0 means the
+// piston is retracted, 1 that it is extending, 2 that it is extended and 3 that it is retracting.
+func (p Piston) state() uint8 {
+	switch {
+	case p.Extended && p.moving():
+		return 1
+	case p.Extended:
+		return 2
+	case p.moving():
+		return 3
+	}
+	return 0
+}
+
+// lastProgress returns the progress of the arm of the piston in the previous tick.
+func (p Piston) lastProgress() float64 {
+	switch p.state() {
+	case 1:
+		return math.Max(p.Progress-0.5, 0)
+	case 3:
+		return math.Min(p.Progress+0.5, 1)
+	}
+	return p.Progress
+}
+
+// DecodeNBT ...
+func (p Piston) DecodeNBT(data map[string]any) any {
+	p.Progress = float64(nbtconv.Float32(data, "Progress"))
+	switch nbtconv.Uint8(data, "State") {
+	case 1, 2:
+		p.Extended = true
+	}
+	var attached []int32
+	switch v := data["AttachedBlocks"].(type) {
+	case []int32:
+		attached = v
+	case []any:
+		for _, i := range v {
+			i32, _ := i.(int32)
+			attached = append(attached, i32)
+		}
+	}
+	p.AttachedBlocks = nil
+	for i := 0; i+2 < len(attached); i += 3 {
+		p.AttachedBlocks = append(p.AttachedBlocks, cube.Pos{int(attached[i]), int(attached[i+1]), int(attached[i+2])})
+	}
+	return p
+}
+
+// EncodeNBT ...
+func (p Piston) EncodeNBT() map[string]any {
+	attached := make([]int32, 0, len(p.AttachedBlocks)*3)
+	for _, pos := range p.AttachedBlocks {
+		attached = append(attached, int32(pos[0]), int32(pos[1]), int32(pos[2]))
+	}
+	return map[string]any{
+		"id":             "PistonArm",
+		"Progress":       float32(p.Progress),
+		"LastProgress":   float32(p.lastProgress()),
+		"State":          p.state(),
+		"NewState":       p.state(),
+		"Sticky":         boolByte(p.Sticky),
+		"AttachedBlocks": attached,
+		"BreakBlocks":    []int32{},
+	}
+}
+
+// EncodeItem ...
+func (p Piston) EncodeItem() (name string, meta int16) {
+	if p.Sticky {
+		return "minecraft:sticky_piston", 0
+	}
+	return "minecraft:piston", 0
+}
+
+// EncodeBlock ...
+func (p Piston) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:piston"
+	if p.Sticky {
+		name = "minecraft:sticky_piston"
+	}
+	return name, map[string]any{"facing_direction": pistonFacing(p.Facing)}
+}
+
+// pistonFacing returns the facing direction of a piston or piston arm facing the face passed, as encoded in its block
+// state. Horizontal faces of pistons are encoded in the opposite way of other blocks.
+func pistonFacing(f cube.Face) int32 {
+	if f.Axis() == cube.Y {
+		return int32(f)
+	}
+	return int32(f.Opposite())
+}
+
+// allPistons ...
+func allPistons() (pistons []world.Block) {
+	for _, f := range cube.Faces() {
+		pistons = append(pistons, Piston{Facing: f})
+		pistons = append(pistons, Piston{Facing: f, Sticky: true})
+	}
+	return
+}
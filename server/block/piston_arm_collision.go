@@ -0,0 +1,69 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// PistonArmCollision is the block placed in front of a piston while its arm is extended. Breaking it also breaks the
+// piston that it belongs to.
+type PistonArmCollision struct {
+	transparent
+
+	// Facing is the face that the piston the arm belongs to is facing.
+	Facing cube.Face
+	// Sticky specifies if the arm belongs to a sticky piston.
+	Sticky bool
+}
+
+// Model ...
+func (a PistonArmCollision) Model() world.BlockModel {
+	return model.PistonArm{Facing: a.Facing}
+}
+
+// BreakInfo ...
+func (a PistonArmCollision) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, oneOf(Piston{Sticky: a.Sticky})).withBreakHandler(func(pos cube.Pos, w *world.World, _ item.User) {
+		if base := pos.Side(a.Facing.Opposite()); a.attached(base, w) {
+			w.SetBlock(base, nil, nil)
+		}
+	})
+}
+
+// PistonImmovable ...
+func (PistonArmCollision) PistonImmovable() bool {
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (a PistonArmCollision) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !a.attached(pos.Side(a.Facing.Opposite()), w) {
+		w.SetBlock(pos, nil, nil)
+	}
+}
+
+// attached checks if the block at the position passed is an extended piston that the arm belongs to.
+func (a PistonArmCollision) attached(pos cube.Pos, w *world.World) bool {
+	p, ok := w.Block(pos).(Piston)
+	return ok && p.Facing == a.Facing && (p.Extended || p.Progress > 0)
+}
+
+// EncodeBlock ...
+func (a PistonArmCollision) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:piston_arm_collision"
+	if a.Sticky {
+		name = "minecraft:sticky_piston_arm_collision"
+	}
+	return name, map[string]any{"facing_direction": pistonFacing(a.Facing)}
+}
+
+// allPistonArmCollisions ...
+func allPistonArmCollisions() (arms []world.Block) {
+	for _, f := range cube.Faces() {
+		arms = append(arms, PistonArmCollision{Facing: f})
+		arms = append(arms, PistonArmCollision{Facing: f, Sticky: true})
+	}
+	return
+}
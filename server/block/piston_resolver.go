@@ -0,0 +1,216 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"slices"
+)
+
+// maxPistonPush is the maximum amount of blocks that a piston is able to move at once.
+const maxPistonPush = 12
+
+// pistonResolver resolves the blocks that are moved and the blocks that are broken when a piston extends or
+// retracts. Slime blocks that are moved also move the blocks that stick to them.
+type pistonResolver struct {
+	w *world.World
+	// pos is the position of the piston.
+	pos cube.Pos
+	// push is the face towards which the blocks are moved.
+	push cube.Face
+
+	toPush, toBreak []cube.Pos
+}
+
+// resolve resolves the blocks moved starting from the position passed. If extending is true, a block that breaks
+// when pushed at the start position is broken. False is returned if the blocks cannot be moved, or if the arm of the
+// piston or any of the blocks moved would end up outside the world border.
+func (r *pistonResolver) resolve(start cube.Pos, extending bool) bool {
+	r.toPush, r.toBreak = r.toPush[:0], r.toBreak[:0]
+	if extending && !r.w.Border().ContainsBlock(start) {
+		return false
+	}
+
+	b := r.w.Block(start)
+	if !r.movable(start, b, false) {
+		if extending && r.movable(start, b, true) {
+			r.toBreak = append(r.toBreak, start)
+			return true
+		}
+		return false
+	}
+	if !r.addLine(start) {
+		return false
+	}
+	for i := 0; i < len(r.toPush); i++ {
+		if pistonSticky(r.w.Block(r.toPush[i])) && !r.addBranches(r.toPush[i]) {
+			return false
+		}
+	}
+	for _, pos := range r.toPush {
+		if !r.w.Border().ContainsBlock(pos.Side(r.push)) {
+			return false
+		}
+	}
+	return true
+}
+
+// addLine adds the block at the origin passed to the blocks pushed, together with the blocks in front of it that it
+// pushes and the slime blocks behind it that it pulls along.
+func (r *pistonResolver) addLine(origin cube.Pos) bool {
+	b := r.w.Block(origin)
+	if _, ok := b.(Air); ok || !r.movable(origin, b, false) || slices.Contains(r.toPush, origin) {
+		return true
+	}
+	if len(r.toPush)+1 > maxPistonPush {
+		return false
+	}
+	// Blocks directly behind a slime block stick to it and are moved too.
+	n := 1
+	for pistonSticky(b) {
+		pos := offset(origin, r.push.Opposite(), n)
+		b = r.w.Block(pos)
+		if _, ok := b.(Air); ok || !r.movable(pos, b, false) {
+			break
+		}
+		if n++; len(r.toPush)+n > maxPistonPush {
+			return false
+		}
+	}
+	added := 0
+	for i := n - 1; i >= 0; i-- {
+		r.toPush = append(r.toPush, offset(origin, r.push.Opposite(), i))
+		added++
+	}
+	for i := 1; ; i++ {
+		pos := offset(origin, r.push, i)
+		if index := slices.Index(r.toPush, pos); index != -1 {
+			// The line ran into blocks already moved, so the order of the blocks is changed to make sure the blocks
+			// in front are moved first.
+			r.reorder(added, index)
+			for j := 0; j <= index+added; j++ {
+				if pistonSticky(r.w.Block(r.toPush[j])) && !r.addBranches(r.toPush[j]) {
+					return false
+				}
+			}
+			return true
+		}
+		if pos.OutOfBounds(r.w.Range()) {
+			return false
+		}
+		b = r.w.Block(pos)
+		if _, ok := b.(Air); ok {
+			return true
+		}
+		if !r.movable(pos, b, true) {
+			return false
+		}
+		if pistonBreaks(b) {
+			r.toBreak = append(r.toBreak, pos)
+			return true
+		}
+		if len(r.toPush) >= maxPistonPush {
+			return false
+		}
+		r.toPush = append(r.toPush, pos)
+		added++
+	}
+}
+
+// addBranches adds the blocks sticking to the sides of the slime block at the position passed to the blocks pushed.
+func (r *pistonResolver) addBranches(pos cube.Pos) bool {
+	for _, f := range cube.Faces() {
+		if f.Axis() != r.push.Axis() && !r.addLine(pos.Side(f)) {
+			return false
+		}
+	}
+	return true
+}
+
+// reorder moves the last n blocks added to the blocks pushed to the index passed.
+func (r *pistonResolver) reorder(n, index int) {
+	l := len(r.toPush)
+	reordered := make([]cube.Pos, 0, l)
+	reordered = append(reordered, r.toPush[:index]...)
+	reordered = append(reordered, r.toPush[l-n:]...)
+	reordered = append(reordered, r.toPush[index:l-n]...)
+	r.toPush = reordered
+}
+
+// movable checks if the block passed at the position passed may be moved by the piston. If allowBreak is true,
+// blocks that break when they are pushed are also considered movable.
+func (r *pistonResolver) movable(pos cube.Pos, b world.Block, allowBreak bool) bool {
+	if pos == r.pos || pos.OutOfBounds(r.w.Range()) {
+		return false
+	}
+	if i, ok := b.(PistonImmovable); ok && i.PistonImmovable() {
+		return false
+	}
+	if pistonBreaks(b) {
+		return allowBreak
+	}
+	if _, ok := b.(PistonImmovable); !ok {
+		if _, ok := b.(world.NBTer); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// move breaks the blocks resolved to be broken and replaces the blocks resolved to be pushed with a MovingBlock at
+// the position in front of them. The positions that the blocks are moved to are returned.
+func (r *pistonResolver) move(w *world.World) []cube.Pos {
+	for _, pos := range r.toBreak {
+		b := w.Block(pos)
+		w.SetBlock(pos, nil, nil)
+		if _, liquid := b.(world.Liquid); liquid {
+			continue
+		}
+		if breakable, ok := b.(Breakable); ok {
+			for _, drop := range breakable.BreakInfo().Drops(item.ToolNone{}, nil) {
+				dropItem(w, drop, pos.Vec3Centre())
+			}
+		}
+	}
+
+	blocks := make([]world.Block, len(r.toPush))
+	for i, pos := range r.toPush {
+		blocks[i] = w.Block(pos)
+	}
+	moved := make([]cube.Pos, len(r.toPush))
+	for i := len(r.toPush) - 1; i >= 0; i-- {
+		moved[i] = r.toPush[i].Side(r.push)
+		w.SetBlock(moved[i], MovingBlock{Moving: blocks[i], PistonPos: r.pos}, nil)
+	}
+	for _, pos := range r.toPush {
+		if !slices.Contains(moved, pos) {
+			w.SetBlock(pos, nil, nil)
+		}
+	}
+	return moved
+}
+
+// pistonSticky checks if the block passed sticks to the blocks around it when it is moved by a piston.
+func pistonSticky(b world.Block) bool {
+	_, ok := b.(SlimeBlock)
+	return ok
+}
+
+// pistonBreaks checks if the block passed breaks when it is pushed by a piston, rather than being moved.
+func pistonBreaks(b world.Block) bool {
+	if _, ok := b.(Air); ok {
+		return false
+	}
+	switch b.Model().(type) {
+	case model.Empty, model.Diode, model.Door, model.Cactus, model.Cake, model.CocoaBean, model.Lantern, model.Skull:
+		return true
+	}
+	return false
+}
+
+// offset returns the position n blocks away from the position passed towards the face passed.
+func offset(pos cube.Pos, face cube.Face, n int) cube.Pos {
+	d := cube.Pos{}.Side(face)
+	return pos.Add(cube.Pos{d[0] * n, d[1] * n, d[2] * n})
+}
@@ -0,0 +1,201 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// PoweredRail is a rail that accelerates minecarts riding on it while it is powered, and stops them while it is not.
+// Redstone power is passed on to up to eight powered rails connected to a powered rail.
+type PoweredRail struct {
+	transparent
+	empty
+
+	// Shape is the shape of the rail. Powered rails cannot be curved.
+	Shape RailShape
+	// Powered is true if the rail is powered.
+	Powered bool
+}
+
+// RailShape ...
+func (p PoweredRail) RailShape() RailShape {
+	return p.Shape
+}
+
+// Ascending returns true if the rail ascends towards one of the horizontal directions.
+func (p PoweredRail) Ascending() bool {
+	return p.Shape.Ascending()
+}
+
+// withRailShape ...
+func (p PoweredRail) withRailShape(shape RailShape) world.Block {
+	p.Shape = shape
+	return p
+}
+
+// railPowered ...
+func (p PoweredRail) railPowered() bool {
+	return p.Powered
+}
+
+// withRailPowered ...
+func (p PoweredRail) withRailPowered(powered bool) world.Block {
+	p.Powered = powered
+	return p
+}
+
+// UseOnBlock ...
+func (p PoweredRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	if !placeRail(pos, face, w, user, ctx, p) {
+		return false
+	}
+	if r, ok := w.Block(pos).(PoweredRail); ok {
+		updateRailPower(pos, w, r)
+	}
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (p PoweredRail) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !railSupported(pos, w) {
+		breakRail(pos, w, p)
+		return
+	}
+	updateRailPower(pos, w, p)
+}
+
+// BreakInfo ...
+func (p PoweredRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(PoweredRail{}))
+}
+
+// HasLiquidDrops ...
+func (p PoweredRail) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (PoweredRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:golden_rail", 0
+}
+
+// EncodeBlock ...
+func (p PoweredRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:golden_rail", map[string]any{"rail_direction": int32(p.Shape.Uint8()), "rail_data_bit": boolByte(p.Powered)}
+}
+
+// allPoweredRails ...
+func allPoweredRails() (rails []world.Block) {
+	for _, s := range StraightRailShapes() {
+		rails = append(rails, PoweredRail{Shape: s})
+		rails = append(rails, PoweredRail{Shape: s, Powered: true})
+	}
+	return
+}
+
+// poweredRail is a rail that may be powered by redstone, either directly or through rails of the same type connected
+// to it.
+type poweredRail interface {
+	shapedRail
+	railPowered() bool
+	withRailPowered(powered bool) world.Block
+}
+
+// updateRailPower updates the powered state of the rail passed at the position passed. The rail is powered if it
+// receives redstone power directly, or if a rail of the same type within eight rails receives power.
+func updateRailPower(pos cube.Pos, w *world.World, r poweredRail) {
+	powered := w.ReceivedRedstonePower(pos, true) > 0 || railSignal(pos, w, r, true, 0) || railSignal(pos, w, r, false, 0)
+	if powered == r.railPowered() {
+		return
+	}
+	w.SetBlock(pos, r.withRailPowered(powered), nil)
+	if r.RailShape().Ascending() {
+		// The rail on the upper end of an ascending rail is not directly next to it, so it does not receive a
+		// neighbour update when the rail changes.
+		for _, c := range r.RailShape().connections(pos) {
+			if other, ok := w.Block(c).(poweredRail); ok && sameRail(other, r) {
+				other.(world.NeighbourUpdateTicker).NeighbourUpdateTick(c, pos, w)
+			}
+		}
+	}
+}
+
+// railSignal checks if the rail passed at the position passed receives power from a rail of the same type that it is
+// connected to. Forward specifies the direction along the rail to search in. The search stops after eight rails.
+func railSignal(pos cube.Pos, w *world.World, r poweredRail, forward bool, depth int) bool {
+	if depth >= 8 {
+		return false
+	}
+	x, y, z := pos.X(), pos.Y(), pos.Z()
+	below, shape := true, r.RailShape()
+	switch shape {
+	case NorthSouthRail():
+		if forward {
+			z++
+		} else {
+			z--
+		}
+	case EastWestRail():
+		if forward {
+			x--
+		} else {
+			x++
+		}
+	case AscendingEastRail():
+		if forward {
+			x--
+		} else {
+			x, y, below = x+1, y+1, false
+		}
+		shape = EastWestRail()
+	case AscendingWestRail():
+		if forward {
+			x, y, below = x-1, y+1, false
+		} else {
+			x++
+		}
+		shape = EastWestRail()
+	case AscendingNorthRail():
+		if forward {
+			z++
+		} else {
+			z, y, below = z-1, y+1, false
+		}
+		shape = NorthSouthRail()
+	case AscendingSouthRail():
+		if forward {
+			z, y, below = z+1, y+1, false
+		} else {
+			z--
+		}
+		shape = NorthSouthRail()
+	}
+	next := cube.Pos{x, y, z}
+	return railPoweredFrom(next, w, r, shape, forward, depth) || (below && railPoweredFrom(next.Side(cube.FaceDown), w, r, shape, forward, depth))
+}
+
+// railPoweredFrom checks if the block at the position passed is a powered rail of the same type as the rail passed,
+// aligned with the shape passed, which is either powered directly or through the rails connected to it.
+func railPoweredFrom(pos cube.Pos, w *world.World, r poweredRail, shape RailShape, forward bool, depth int) bool {
+	other, ok := w.Block(pos).(poweredRail)
+	if !ok || !sameRail(other, r) || !other.railPowered() {
+		return false
+	}
+	otherShape := other.RailShape()
+	if shape == EastWestRail() && (otherShape == NorthSouthRail() || otherShape == AscendingNorthRail() || otherShape == AscendingSouthRail()) {
+		return false
+	}
+	if shape == NorthSouthRail() && (otherShape == EastWestRail() || otherShape == AscendingEastRail() || otherShape == AscendingWestRail()) {
+		return false
+	}
+	return w.ReceivedRedstonePower(pos, true) > 0 || railSignal(pos, w, other, forward, depth+1)
+}
+
+// sameRail checks if the two rails passed are of the same type.
+func sameRail(a, b world.Block) bool {
+	nameA, _ := a.EncodeBlock()
+	nameB, _ := b.EncodeBlock()
+	return nameA == nameB
+}
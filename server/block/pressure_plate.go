@@ -0,0 +1,168 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// PressurePlate is a non-solid block that provides redstone power while entities are standing on it. Stone and
+// polished blackstone pressure plates are only pressed by living entities, while wooden pressure plates are pressed
+// by any entity. Weighted pressure plates provide more power as more entities stand on them.
+type PressurePlate struct {
+	transparent
+	empty
+
+	// Type is the type of the pressure plate.
+	Type PressurePlateType
+	// Power is the redstone power provided by the pressure plate, ranging from 0 to 15. Pressure plates that are
+	// not weighted provide either 0 or 15 power.
+	Power int
+}
+
+// BreakInfo ...
+func (p PressurePlate) BreakInfo() BreakInfo {
+	effective := pickaxeEffective
+	if p.Type.Wooden() {
+		effective = axeEffective
+	}
+	return newBreakInfo(0.5, alwaysHarvestable, effective, oneOf(PressurePlate{Type: p.Type}))
+}
+
+// UseOnBlock ...
+func (p PressurePlate) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, p)
+	if !used {
+		return false
+	}
+	if !attachedFaceSolid(pos, cube.FaceUp, w) {
+		return false
+	}
+
+	place(w, pos, p, user, ctx)
+	return placed(ctx)
+}
+
+// EntityInside ...
+func (p PressurePlate) EntityInside(pos cube.Pos, w *world.World, _ world.Entity) {
+	if p.Power == 0 {
+		// Once pressed, the pressure plate is updated through scheduled ticks until it is released again.
+		p.update(pos, w)
+	}
+}
+
+// ScheduledTick ...
+func (p PressurePlate) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if p.Power != 0 {
+		p.update(pos, w)
+	}
+}
+
+// update recalculates the power of the pressure plate at the position passed from the entities on top of it. If the
+// power changed, the pressure plate is updated and a sound is played. As long as the pressure plate is pressed, it
+// schedules a block update to check the entities on top of it again.
+func (p PressurePlate) update(pos cube.Pos, w *world.World) {
+	power := p.calculatePower(pos, w)
+	if power != p.Power {
+		if power == 0 {
+			w.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+		} else if p.Power == 0 {
+			w.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+		}
+		p.Power = power
+		w.SetBlock(pos, p, nil)
+	}
+	if power != 0 {
+		delay := time.Second
+		if p.Type.Weighted() {
+			delay = time.Second / 2
+		}
+		w.ScheduleBlockUpdate(pos, delay)
+	}
+}
+
+// calculatePower calculates the power of the pressure plate at the position passed based on the entities on top of
+// it.
+func (p PressurePlate) calculatePower(pos cube.Pos, w *world.World) int {
+	box := cube.Box(0.0625, 0, 0.0625, 0.9375, 0.25, 0.9375).Translate(pos.Vec3())
+
+	n := 0
+	for _, e := range w.EntitiesWithin(box.Grow(2), nil) {
+		if !e.Type().BBox(e).Translate(e.Position()).IntersectsWith(box) {
+			continue
+		}
+		if _, living := e.(livingEntity); !living && !p.Type.Wooden() && !p.Type.Weighted() {
+			continue
+		}
+		n++
+	}
+	switch p.Type {
+	case LightWeightedPressurePlate():
+		return min(n, 15)
+	case HeavyWeightedPressurePlate():
+		return min(int(math.Ceil(float64(n)/10)), 15)
+	}
+	if n > 0 {
+		return 15
+	}
+	return 0
+}
+
+// NeighbourUpdateTick ...
+func (p PressurePlate) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !attachedFaceSolid(pos, cube.FaceUp, w) {
+		w.SetBlock(pos, nil, nil)
+		dropItem(w, item.NewStack(PressurePlate{Type: p.Type}, 1), pos.Vec3Centre())
+	}
+}
+
+// WeakPower ...
+func (p PressurePlate) WeakPower(cube.Pos, cube.Face, *world.World, bool) int {
+	return p.Power
+}
+
+// StrongPower ...
+func (p PressurePlate) StrongPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if face == cube.FaceDown {
+		return p.Power
+	}
+	return 0
+}
+
+// HasLiquidDrops ...
+func (p PressurePlate) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (p PressurePlate) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + pressurePlateName(p.Type), 0
+}
+
+// EncodeBlock ...
+func (p PressurePlate) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + pressurePlateName(p.Type), map[string]any{"redstone_signal": int32(p.Power)}
+}
+
+// pressurePlateName returns the name of a pressure plate of the type passed, without the namespace.
+func pressurePlateName(t PressurePlateType) string {
+	if t == WoodenPressurePlate(OakWood()) {
+		return "wooden_pressure_plate"
+	}
+	return t.String() + "_pressure_plate"
+}
+
+// allPressurePlates ...
+func allPressurePlates() (plates []world.Block) {
+	for _, t := range PressurePlateTypes() {
+		for i := 0; i <= 15; i++ {
+			plates = append(plates, PressurePlate{Type: t, Power: i})
+		}
+	}
+	return
+}
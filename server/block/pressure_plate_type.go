@@ -0,0 +1,76 @@
+package block
+
+// PressurePlateType represents a type of pressure plate. Pressure plates may be made of any type of wood, stone,
+// polished blackstone, gold (light weighted) or iron (heavy weighted).
+type PressurePlateType struct {
+	pressurePlate
+}
+
+// WoodenPressurePlate returns the pressure plate type of a pressure plate made of the WoodType passed.
+func WoodenPressurePlate(w WoodType) PressurePlateType {
+	return PressurePlateType{pressurePlate(w.Uint8())}
+}
+
+// StonePressurePlate returns the pressure plate type of a stone pressure plate.
+func StonePressurePlate() PressurePlateType {
+	return PressurePlateType{10}
+}
+
+// PolishedBlackstonePressurePlate returns the pressure plate type of a polished blackstone pressure plate.
+func PolishedBlackstonePressurePlate() PressurePlateType {
+	return PressurePlateType{11}
+}
+
+// LightWeightedPressurePlate returns the pressure plate type of a light weighted pressure plate, which is made of
+// gold.
+func LightWeightedPressurePlate() PressurePlateType {
+	return PressurePlateType{12}
+}
+
+// HeavyWeightedPressurePlate returns the pressure plate type of a heavy weighted pressure plate, which is made of
+// iron.
+func HeavyWeightedPressurePlate() PressurePlateType {
+	return PressurePlateType{13}
+}
+
+// PressurePlateTypes returns all pressure plate types.
+func PressurePlateTypes() []PressurePlateType {
+	types := make([]PressurePlateType, 0, len(WoodTypes())+4)
+	for _, w := range WoodTypes() {
+		types = append(types, WoodenPressurePlate(w))
+	}
+	return append(types, StonePressurePlate(), PolishedBlackstonePressurePlate(), LightWeightedPressurePlate(), HeavyWeightedPressurePlate())
+}
+
+type pressurePlate uint8
+
+// Uint8 returns the pressure plate as a uint8.
+func (p pressurePlate) Uint8() uint8 {
+	return uint8(p)
+}
+
+// Wooden checks if the pressure plate is made of wood.
+func (p pressurePlate) Wooden() bool {
+	return p < 10
+}
+
+// Weighted checks if the pressure plate is a weighted pressure plate, of which the power depends on the amount of
+// entities on top of it.
+func (p pressurePlate) Weighted() bool {
+	return p >= 12
+}
+
+// String ...
+func (p pressurePlate) String() string {
+	switch p {
+	case 10:
+		return "stone"
+	case 11:
+		return "polished_blackstone"
+	case 12:
+		return "light_weighted"
+	case 13:
+		return "heavy_weighted"
+	}
+	return wood(p).String()
+}
@@ -38,6 +38,19 @@ func (p Pumpkin) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world
 	return placed(ctx)
 }
 
+// Dispense places a carved pumpkin in front of the dispenser. Pumpkins that are not carved are dropped.
+func (p Pumpkin) Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *item.UseContext) bool {
+	if !p.Carved {
+		dispenseItem(w, pos, face, item.NewStack(p, 1))
+		ctx.SubtractFromCount(1)
+		return true
+	}
+	if face.Axis() != cube.Y {
+		p.Facing = face.Direction()
+	}
+	return dispensePlace(pos, face, w, p, ctx)
+}
+
 // BreakInfo ...
 func (p Pumpkin) BreakInfo() BreakInfo {
 	return newBreakInfo(1, alwaysHarvestable, axeEffective, oneOf(p))
@@ -0,0 +1,369 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MinecartRail represents a rail block that minecarts are able to ride on.
+type MinecartRail interface {
+	world.Block
+	// RailShape returns the current shape of the rail.
+	RailShape() RailShape
+}
+
+// Rail is a non-solid block that minecarts ride on. Rails automatically connect to the rails next to them and are,
+// unlike other rails, able to form curves.
+type Rail struct {
+	transparent
+	empty
+
+	// Shape is the shape of the rail.
+	Shape RailShape
+}
+
+// RailShape ...
+func (r Rail) RailShape() RailShape {
+	return r.Shape
+}
+
+// Ascending returns true if the rail ascends towards one of the horizontal directions.
+func (r Rail) Ascending() bool {
+	return r.Shape.Ascending()
+}
+
+// withRailShape ...
+func (r Rail) withRailShape(shape RailShape) world.Block {
+	r.Shape = shape
+	return r
+}
+
+// UseOnBlock ...
+func (r Rail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	return placeRail(pos, face, w, user, ctx, r)
+}
+
+// NeighbourUpdateTick ...
+func (r Rail) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, w *world.World) {
+	if !railSupported(pos, w) {
+		breakRail(pos, w, r)
+		return
+	}
+	if _, ok := w.Block(changedNeighbour).(world.Conductor); !ok {
+		return
+	}
+	// A rail with three rails around it forms a T-junction. Redstone power switches the direction of the junction.
+	s := newRailState(pos, r)
+	if s.potentialConnections(w) == 3 {
+		s.place(w.ReceivedRedstonePower(pos, true) > 0, false, w)
+	}
+}
+
+// BreakInfo ...
+func (r Rail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(Rail{}))
+}
+
+// HasLiquidDrops ...
+func (r Rail) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (Rail) EncodeItem() (name string, meta int16) {
+	return "minecraft:rail", 0
+}
+
+// EncodeBlock ...
+func (r Rail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:rail", map[string]any{"rail_direction": int32(r.Shape.Uint8())}
+}
+
+// allRails ...
+func allRails() (rails []world.Block) {
+	for _, s := range RailShapes() {
+		rails = append(rails, Rail{Shape: s})
+	}
+	return
+}
+
+// shapedRail is a MinecartRail of which the shape can be changed.
+type shapedRail interface {
+	MinecartRail
+	withRailShape(shape RailShape) world.Block
+}
+
+// placeRail places the rail passed at the position passed and connects it to the rails around it. The rail is
+// initially aligned with the direction that the user is facing.
+func placeRail(pos cube.Pos, face cube.Face, w *world.World, user item.User, ctx *item.UseContext, r shapedRail) bool {
+	pos, _, used := firstReplaceable(w, pos, face, r)
+	if !used {
+		return false
+	}
+	if !attachedFaceSolid(pos, cube.FaceUp, w) {
+		return false
+	}
+	shape := NorthSouthRail()
+	if user.Rotation().Direction().Face().Axis() == cube.X {
+		shape = EastWestRail()
+	}
+	r = r.withRailShape(shape).(shapedRail)
+
+	place(w, pos, r, user, ctx)
+	if !placed(ctx) {
+		return false
+	}
+	newRailState(pos, r).place(w.ReceivedRedstonePower(pos, true) > 0, true, w)
+	return true
+}
+
+// railSupported checks if a rail at the position passed is supported by the block below it.
+func railSupported(pos cube.Pos, w *world.World) bool {
+	return attachedFaceSolid(pos, cube.FaceUp, w)
+}
+
+// breakRail breaks the rail passed at the position passed and drops it as an item.
+func breakRail(pos cube.Pos, w *world.World, r MinecartRail) {
+	w.SetBlock(pos, nil, nil)
+	for _, drop := range r.(Breakable).BreakInfo().Drops(item.ToolNone{}, nil) {
+		dropItem(w, drop, pos.Vec3Centre())
+	}
+}
+
+// railAt returns the rail at the position passed, if any. If there is no rail at the position, the positions directly
+// above and below it are checked, so that rails going up or down a slope are found too.
+func railAt(pos cube.Pos, w *world.World) (shapedRail, cube.Pos, bool) {
+	for _, p := range []cube.Pos{pos, pos.Side(cube.FaceUp), pos.Side(cube.FaceDown)} {
+		if r, ok := w.Block(p).(shapedRail); ok {
+			return r, p, true
+		}
+	}
+	return nil, pos, false
+}
+
+// railState holds the state of a rail and the rails that it is connected to. It is used to compute the shape of a
+// rail when it is placed or when the rails around it change.
+type railState struct {
+	pos         cube.Pos
+	rail        shapedRail
+	straight    bool
+	connections []cube.Pos
+}
+
+// newRailState creates a railState for the rail passed at the position passed.
+func newRailState(pos cube.Pos, r shapedRail) *railState {
+	_, curves := r.(Rail)
+	s := &railState{pos: pos, rail: r, straight: !curves}
+	s.updateConnections(r.RailShape())
+	return s
+}
+
+// updateConnections sets the connections of the railState to those of the shape passed.
+func (s *railState) updateConnections(shape RailShape) {
+	c := shape.connections(s.pos)
+	s.connections = c[:]
+}
+
+// removeSoftConnections removes all connections to positions that no longer hold a rail connected back to this rail.
+func (s *railState) removeSoftConnections(w *world.World) {
+	connections := s.connections[:0]
+	for _, pos := range s.connections {
+		if other, ok := s.railAt(pos, w); ok && other.connectsTo(s) {
+			connections = append(connections, pos)
+		}
+	}
+	s.connections = connections
+}
+
+// railAt returns the railState of the rail at or directly above or below the position passed, if there is any.
+func (s *railState) railAt(pos cube.Pos, w *world.World) (*railState, bool) {
+	r, pos, ok := railAt(pos, w)
+	if !ok {
+		return nil, false
+	}
+	return newRailState(pos, r), true
+}
+
+// hasConnection checks if the railState has a connection to the position passed, ignoring the Y coordinate.
+func (s *railState) hasConnection(pos cube.Pos) bool {
+	for _, c := range s.connections {
+		if c.X() == pos.X() && c.Z() == pos.Z() {
+			return true
+		}
+	}
+	return false
+}
+
+// connectsTo checks if the railState is connected to the railState passed.
+func (s *railState) connectsTo(other *railState) bool {
+	return s.hasConnection(other.pos)
+}
+
+// canConnectTo checks if the railState is able to connect to the railState passed. This is the case if it is already
+// connected to it or if it has a free connection left.
+func (s *railState) canConnectTo(other *railState) bool {
+	return s.connectsTo(other) || len(s.connections) != 2
+}
+
+// potentialConnections returns the amount of rails directly next to the railState, including those one block higher
+// or lower.
+func (s *railState) potentialConnections(w *world.World) (n int) {
+	for _, face := range cube.HorizontalFaces() {
+		if _, _, ok := railAt(s.pos.Side(face), w); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// hasNeighbourRail checks if there is a rail at or directly above or below the position passed that the railState is
+// able to connect to.
+func (s *railState) hasNeighbourRail(pos cube.Pos, w *world.World) bool {
+	other, ok := s.railAt(pos, w)
+	if !ok {
+		return false
+	}
+	other.removeSoftConnections(w)
+	return other.canConnectTo(s)
+}
+
+// connectTo connects the railState to the railState passed and updates the shape of the rail in the world.
+func (s *railState) connectTo(other *railState, w *world.World) {
+	s.connections = append(s.connections, other.pos)
+	north, south := s.hasConnection(s.pos.Side(cube.FaceNorth)), s.hasConnection(s.pos.Side(cube.FaceSouth))
+	west, east := s.hasConnection(s.pos.Side(cube.FaceWest)), s.hasConnection(s.pos.Side(cube.FaceEast))
+
+	var shape RailShape
+	found := false
+	if north || south {
+		shape, found = NorthSouthRail(), true
+	}
+	if west || east {
+		shape, found = EastWestRail(), true
+	}
+	if !s.straight {
+		if south && east && !north && !west {
+			shape, found = SouthEastRail(), true
+		}
+		if south && west && !north && !east {
+			shape, found = SouthWestRail(), true
+		}
+		if north && west && !south && !east {
+			shape, found = NorthWestRail(), true
+		}
+		if north && east && !south && !west {
+			shape, found = NorthEastRail(), true
+		}
+	}
+	if !found {
+		shape = NorthSouthRail()
+	}
+	shape = s.ascend(shape, w)
+	s.rail = s.rail.withRailShape(shape).(shapedRail)
+	w.SetBlock(s.pos, s.rail, nil)
+}
+
+// ascend returns the ascending variant of the straight shape passed if there is a rail one block higher on either
+// end of the rail. If not, the shape passed is returned.
+func (s *railState) ascend(shape RailShape, w *world.World) RailShape {
+	isRail := func(face cube.Face) bool {
+		_, ok := w.Block(s.pos.Side(face).Side(cube.FaceUp)).(MinecartRail)
+		return ok
+	}
+	switch shape {
+	case NorthSouthRail():
+		if isRail(cube.FaceNorth) {
+			shape = AscendingNorthRail()
+		}
+		if isRail(cube.FaceSouth) {
+			shape = AscendingSouthRail()
+		}
+	case EastWestRail():
+		if isRail(cube.FaceEast) {
+			shape = AscendingEastRail()
+		}
+		if isRail(cube.FaceWest) {
+			shape = AscendingWestRail()
+		}
+	}
+	return shape
+}
+
+// place computes the shape of the railState from the rails around it and sets the rail in the world. The rails that
+// the rail connects to are updated to connect back to it. Powered influences the direction that a T-junction of
+// rails takes. If force is false, the rail is only updated if its shape changed.
+func (s *railState) place(powered, force bool, w *world.World) {
+	n, so := s.pos.Side(cube.FaceNorth), s.pos.Side(cube.FaceSouth)
+	we, e := s.pos.Side(cube.FaceWest), s.pos.Side(cube.FaceEast)
+	north, south := s.hasNeighbourRail(n, w), s.hasNeighbourRail(so, w)
+	west, east := s.hasNeighbourRail(we, w), s.hasNeighbourRail(e, w)
+
+	var shape RailShape
+	found := false
+	northSouth, eastWest := north || south, west || east
+	if northSouth && !eastWest {
+		shape, found = NorthSouthRail(), true
+	}
+	if eastWest && !northSouth {
+		shape, found = EastWestRail(), true
+	}
+	southEast, southWest, northEast, northWest := south && east, south && west, north && east, north && west
+	if !s.straight {
+		if southEast && !north && !west {
+			shape, found = SouthEastRail(), true
+		}
+		if southWest && !north && !east {
+			shape, found = SouthWestRail(), true
+		}
+		if northWest && !south && !east {
+			shape, found = NorthWestRail(), true
+		}
+		if northEast && !south && !west {
+			shape, found = NorthEastRail(), true
+		}
+	}
+	if !found {
+		if northSouth && eastWest {
+			shape, found = s.rail.RailShape(), true
+		} else if northSouth {
+			shape, found = NorthSouthRail(), true
+		} else if eastWest {
+			shape, found = EastWestRail(), true
+		}
+		if !s.straight {
+			// The order of these checks determines the direction that a T-junction takes: The last matching curve is
+			// used.
+			curves, ok := []RailShape{NorthWestRail(), NorthEastRail(), SouthWestRail(), SouthEastRail()}, []bool{northWest, northEast, southWest, southEast}
+			if powered {
+				curves, ok = []RailShape{SouthEastRail(), SouthWestRail(), NorthEastRail(), NorthWestRail()}, []bool{southEast, southWest, northEast, northWest}
+			}
+			for i, curve := range curves {
+				if ok[i] {
+					shape, found = curve, true
+				}
+			}
+		}
+	}
+	if !found {
+		shape = s.rail.RailShape()
+	}
+	shape = s.ascend(shape, w)
+	s.updateConnections(shape)
+
+	changed := shape != s.rail.RailShape()
+	s.rail = s.rail.withRailShape(shape).(shapedRail)
+	if !force && !changed {
+		return
+	}
+	w.SetBlock(s.pos, s.rail, nil)
+	for _, pos := range s.connections {
+		if other, ok := s.railAt(pos, w); ok {
+			other.removeSoftConnections(w)
+			if other.canConnectTo(s) {
+				other.connectTo(s, w)
+			}
+		}
+	}
+}
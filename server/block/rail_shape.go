@@ -0,0 +1,175 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// RailShape represents the shape of a rail. A rail may be straight, ascending towards one of the horizontal
+// directions or, for normal rails only, curved.
+type RailShape struct {
+	railShape
+}
+
+// NorthSouthRail is a straight rail shape along the Z axis.
+func NorthSouthRail() RailShape {
+	return RailShape{0}
+}
+
+// EastWestRail is a straight rail shape along the X axis.
+func EastWestRail() RailShape {
+	return RailShape{1}
+}
+
+// AscendingEastRail is a rail shape along the X axis that ascends towards the east.
+func AscendingEastRail() RailShape {
+	return RailShape{2}
+}
+
+// AscendingWestRail is a rail shape along the X axis that ascends towards the west.
+func AscendingWestRail() RailShape {
+	return RailShape{3}
+}
+
+// AscendingNorthRail is a rail shape along the Z axis that ascends towards the north.
+func AscendingNorthRail() RailShape {
+	return RailShape{4}
+}
+
+// AscendingSouthRail is a rail shape along the Z axis that ascends towards the south.
+func AscendingSouthRail() RailShape {
+	return RailShape{5}
+}
+
+// SouthEastRail is a curved rail shape that connects the south and east.
+func SouthEastRail() RailShape {
+	return RailShape{6}
+}
+
+// SouthWestRail is a curved rail shape that connects the south and west.
+func SouthWestRail() RailShape {
+	return RailShape{7}
+}
+
+// NorthWestRail is a curved rail shape that connects the north and west.
+func NorthWestRail() RailShape {
+	return RailShape{8}
+}
+
+// NorthEastRail is a curved rail shape that connects the north and east.
+func NorthEastRail() RailShape {
+	return RailShape{9}
+}
+
+// RailShapes returns all possible RailShapes, including curved ones.
+func RailShapes() []RailShape {
+	return []RailShape{
+		NorthSouthRail(), EastWestRail(), AscendingEastRail(), AscendingWestRail(), AscendingNorthRail(),
+		AscendingSouthRail(), SouthEastRail(), SouthWestRail(), NorthWestRail(), NorthEastRail(),
+	}
+}
+
+// StraightRailShapes returns all RailShapes that are not curved. These are the shapes that powered, detector and
+// activator rails may have.
+func StraightRailShapes() []RailShape {
+	return RailShapes()[:6]
+}
+
+type railShape uint8
+
+// Uint8 returns the RailShape as a uint8.
+func (r railShape) Uint8() uint8 {
+	return uint8(r)
+}
+
+// String returns the RailShape as a string.
+func (r railShape) String() string {
+	switch r {
+	case 0:
+		return "north_south"
+	case 1:
+		return "east_west"
+	case 2:
+		return "ascending_east"
+	case 3:
+		return "ascending_west"
+	case 4:
+		return "ascending_north"
+	case 5:
+		return "ascending_south"
+	case 6:
+		return "south_east"
+	case 7:
+		return "south_west"
+	case 8:
+		return "north_west"
+	case 9:
+		return "north_east"
+	}
+	panic("should never happen")
+}
+
+// Ascending returns true if the RailShape ascends towards one of the horizontal directions.
+func (r railShape) Ascending() bool {
+	return r >= 2 && r <= 5
+}
+
+// Curved returns true if the RailShape is a curve between two perpendicular directions.
+func (r railShape) Curved() bool {
+	return r >= 6
+}
+
+// Exits returns the two block offsets, relative to the rail, that a minecart riding on a rail of this shape moves
+// towards. For ascending rails, the exit on the lower end of the rail is one block below the rail.
+func (r railShape) Exits() [2]cube.Pos {
+	switch r {
+	case 0:
+		return [2]cube.Pos{{0, 0, -1}, {0, 0, 1}}
+	case 1:
+		return [2]cube.Pos{{-1, 0, 0}, {1, 0, 0}}
+	case 2:
+		return [2]cube.Pos{{-1, -1, 0}, {1, 0, 0}}
+	case 3:
+		return [2]cube.Pos{{-1, 0, 0}, {1, -1, 0}}
+	case 4:
+		return [2]cube.Pos{{0, 0, -1}, {0, -1, 1}}
+	case 5:
+		return [2]cube.Pos{{0, -1, -1}, {0, 0, 1}}
+	case 6:
+		return [2]cube.Pos{{0, 0, 1}, {1, 0, 0}}
+	case 7:
+		return [2]cube.Pos{{0, 0, 1}, {-1, 0, 0}}
+	case 8:
+		return [2]cube.Pos{{0, 0, -1}, {-1, 0, 0}}
+	case 9:
+		return [2]cube.Pos{{0, 0, -1}, {1, 0, 0}}
+	}
+	panic("should never happen")
+}
+
+// connections returns the positions of the two rails that a rail of this shape at the position passed connects to.
+// For ascending rails, the rail on the higher end is one block above the rail.
+func (r railShape) connections(pos cube.Pos) [2]cube.Pos {
+	switch r {
+	case 0:
+		return [2]cube.Pos{pos.Side(cube.FaceNorth), pos.Side(cube.FaceSouth)}
+	case 1:
+		return [2]cube.Pos{pos.Side(cube.FaceWest), pos.Side(cube.FaceEast)}
+	case 2:
+		return [2]cube.Pos{pos.Side(cube.FaceWest), pos.Side(cube.FaceEast).Side(cube.FaceUp)}
+	case 3:
+		return [2]cube.Pos{pos.Side(cube.FaceWest).Side(cube.FaceUp), pos.Side(cube.FaceEast)}
+	case 4:
+		return [2]cube.Pos{pos.Side(cube.FaceNorth).Side(cube.FaceUp), pos.Side(cube.FaceSouth)}
+	case 5:
+		return [2]cube.Pos{pos.Side(cube.FaceNorth), pos.Side(cube.FaceSouth).Side(cube.FaceUp)}
+	case 6:
+		return [2]cube.Pos{pos.Side(cube.FaceEast), pos.Side(cube.FaceSouth)}
+	case 7:
+		return [2]cube.Pos{pos.Side(cube.FaceWest), pos.Side(cube.FaceSouth)}
+	case 8:
+		return [2]cube.Pos{pos.Side(cube.FaceWest), pos.Side(cube.FaceNorth)}
+	case 9:
+		return [2]cube.Pos{pos.Side(cube.FaceEast), pos.Side(cube.FaceNorth)}
+	}
+	panic("should never happen")
+}
@@ -0,0 +1,144 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// RedstoneTorch is a non-solid block that emits redstone power. A redstone torch is turned off when the block it is
+// attached to is powered, which makes it invert the signal it receives.
+type RedstoneTorch struct {
+	transparent
+	empty
+
+	// Facing is the direction from the torch to the block.
+	Facing cube.Face
+	// Lit is if the redstone torch is lit and emitting power.
+	Lit bool
+}
+
+// BreakInfo ...
+func (t RedstoneTorch) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(RedstoneTorch{}))
+}
+
+// LightEmissionLevel ...
+func (t RedstoneTorch) LightEmissionLevel() uint8 {
+	if t.Lit {
+		return 7
+	}
+	return 0
+}
+
+// UseOnBlock ...
+func (t RedstoneTorch) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(w, pos, face, t)
+	if !used {
+		return false
+	}
+	if face == cube.FaceDown {
+		return false
+	}
+	if _, ok := w.Block(pos).(world.Liquid); ok {
+		return false
+	}
+	if !w.Block(pos.Side(face.Opposite())).Model().FaceSolid(pos.Side(face.Opposite()), face, w) {
+		found := false
+		for _, i := range []cube.Face{cube.FaceSouth, cube.FaceWest, cube.FaceNorth, cube.FaceEast, cube.FaceDown} {
+			if w.Block(pos.Side(i)).Model().FaceSolid(pos.Side(i), i.Opposite(), w) {
+				found = true
+				face = i.Opposite()
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	t.Facing = face.Opposite()
+	t.Lit = true
+
+	place(w, pos, t, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (t RedstoneTorch) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !w.Block(pos.Side(t.Facing)).Model().FaceSolid(pos.Side(t.Facing), t.Facing.Opposite(), w) {
+		w.SetBlock(pos, nil, nil)
+		dropItem(w, item.NewStack(RedstoneTorch{}, 1), pos.Vec3Centre())
+		return
+	}
+	if t.Lit == t.powered(pos, w) {
+		// Redstone torches take two ticks to change state after the block they are attached to is powered or
+		// unpowered.
+		w.ScheduleBlockUpdate(pos, time.Second/10)
+	}
+}
+
+// ScheduledTick ...
+func (t RedstoneTorch) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if t.Lit == t.powered(pos, w) {
+		t.Lit = !t.Lit
+		w.SetBlock(pos, t, nil)
+	}
+}
+
+// powered checks if the block that the redstone torch at the position passed is attached to is powered.
+func (t RedstoneTorch) powered(pos cube.Pos, w *world.World) bool {
+	return w.RedstonePower(pos.Side(t.Facing), t.Facing.Opposite(), true) > 0
+}
+
+// WeakPower ...
+func (t RedstoneTorch) WeakPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if t.Lit && face != t.Facing {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (t RedstoneTorch) StrongPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if t.Lit && face == cube.FaceUp {
+		return 15
+	}
+	return 0
+}
+
+// HasLiquidDrops ...
+func (t RedstoneTorch) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (t RedstoneTorch) EncodeItem() (name string, meta int16) {
+	return "minecraft:redstone_torch", 0
+}
+
+// EncodeBlock ...
+func (t RedstoneTorch) EncodeBlock() (name string, properties map[string]any) {
+	face := t.Facing.String()
+	if t.Facing == cube.FaceDown {
+		face = "top"
+	}
+	if t.Lit {
+		return "minecraft:redstone_torch", map[string]any{"torch_facing_direction": face}
+	}
+	return "minecraft:unlit_redstone_torch", map[string]any{"torch_facing_direction": face}
+}
+
+// allRedstoneTorches ...
+func allRedstoneTorches() (torches []world.Block) {
+	for i := cube.Face(0); i < 6; i++ {
+		if i == cube.FaceUp {
+			continue
+		}
+		torches = append(torches, RedstoneTorch{Facing: i})
+		torches = append(torches, RedstoneTorch{Facing: i, Lit: true})
+	}
+	return
+}
@@ -0,0 +1,182 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// RedstoneWire is the block placed when using redstone dust on the ground. It carries redstone power from a power
+// source to the redstone components it is connected to, losing one level of power for every block it travels.
+type RedstoneWire struct {
+	transparent
+	empty
+
+	// Power is the level of redstone power of the wire, ranging from 0 to 15.
+	Power int
+}
+
+// BreakInfo ...
+func (RedstoneWire) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(RedstoneWire{}))
+}
+
+// HasLiquidDrops ...
+func (RedstoneWire) HasLiquidDrops() bool {
+	return true
+}
+
+// UseOnBlock ...
+func (r RedstoneWire) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, r)
+	if !used {
+		return false
+	}
+	if _, ok := w.Block(pos).(world.Liquid); ok {
+		return false
+	}
+	if !redstoneWireSupported(pos, w) {
+		return false
+	}
+	r.Power = r.calculatePower(pos, w)
+
+	place(w, pos, r, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r RedstoneWire) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !redstoneWireSupported(pos, w) {
+		w.SetBlock(pos, nil, nil)
+		dropItem(w, item.NewStack(RedstoneWire{}, 1), pos.Vec3Centre())
+		return
+	}
+	if power := r.calculatePower(pos, w); power != r.Power {
+		// Setting the block results in the neighbours of all blocks around the wire being updated, so that the
+		// change in power spreads to other wires and components.
+		r.Power = power
+		w.SetBlock(pos, r, nil)
+	}
+}
+
+// WeakPower ...
+func (r RedstoneWire) WeakPower(pos cube.Pos, face cube.Face, w *world.World, accountForDust bool) int {
+	if !accountForDust || face == cube.FaceUp {
+		return 0
+	}
+	if face == cube.FaceDown || r.powers(pos, face, w) {
+		return r.Power
+	}
+	return 0
+}
+
+// StrongPower ...
+func (r RedstoneWire) StrongPower(pos cube.Pos, face cube.Face, w *world.World, accountForDust bool) int {
+	return r.WeakPower(pos, face, w, accountForDust)
+}
+
+// calculatePower calculates the power that the redstone wire at the position passed should have. This is the highest
+// power received from any redstone component around it, or the highest power of any redstone wire connected to it,
+// minus one.
+func (r RedstoneWire) calculatePower(pos cube.Pos, w *world.World) int {
+	power := w.ReceivedRedstonePower(pos, false)
+	if power >= 15 {
+		return 15
+	}
+	above := pos.Side(cube.FaceUp)
+	blockedAbove := w.Block(above).Model().FaceSolid(above, cube.FaceDown, w)
+	for _, face := range cube.HorizontalFaces() {
+		side := pos.Side(face)
+		power = max(power, redstoneWirePower(side, w)-1)
+		if !blockedAbove {
+			power = max(power, redstoneWirePower(side.Side(cube.FaceUp), w)-1)
+		}
+		if !w.Block(side).Model().FaceSolid(side, face.Opposite(), w) {
+			power = max(power, redstoneWirePower(side.Side(cube.FaceDown), w)-1)
+		}
+	}
+	return power
+}
+
+// powers checks if the redstone wire at the position passed points towards the horizontal face passed, so that it
+// powers the block on that side. A wire without any connections powers all sides. A wire that is only connected on
+// one side extends to the opposite side as well.
+func (r RedstoneWire) powers(pos cube.Pos, face cube.Face, w *world.World) bool {
+	var connected [6]bool
+	anyConnected := false
+	for _, f := range cube.HorizontalFaces() {
+		connected[f] = r.connected(pos, f, w)
+		anyConnected = anyConnected || connected[f]
+	}
+	if !anyConnected || connected[face] {
+		return true
+	}
+	return connected[face.Opposite()] && !connected[face.RotateLeft()] && !connected[face.RotateRight()]
+}
+
+// connected checks if the redstone wire at the position passed is connected to a redstone component on the
+// horizontal face passed. Wires connect to other wires on the same level, one block up or one block down, and to any
+// redstone component next to them.
+func (r RedstoneWire) connected(pos cube.Pos, face cube.Face, w *world.World) bool {
+	side := pos.Side(face)
+	sideBlock := w.Block(side)
+	if c, ok := sideBlock.(wireConnector); ok {
+		return c.connectsWire(face.Opposite())
+	}
+	if _, ok := sideBlock.(world.Conductor); ok {
+		return true
+	}
+	above := pos.Side(cube.FaceUp)
+	if !w.Block(above).Model().FaceSolid(above, cube.FaceDown, w) {
+		if _, ok := w.Block(side.Side(cube.FaceUp)).(RedstoneWire); ok {
+			return true
+		}
+	}
+	if !sideBlock.Model().FaceSolid(side, face.Opposite(), w) {
+		if _, ok := w.Block(side.Side(cube.FaceDown)).(RedstoneWire); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wireConnector is implemented by redstone components that redstone wire only connects to on some of their faces,
+// such as repeaters.
+type wireConnector interface {
+	// connectsWire checks if redstone wire on the face passed of the component connects to it.
+	connectsWire(face cube.Face) bool
+}
+
+// redstoneWirePower returns the power of the redstone wire at the position passed, or 0 if there is no redstone wire
+// at that position.
+func redstoneWirePower(pos cube.Pos, w *world.World) int {
+	if r, ok := w.Block(pos).(RedstoneWire); ok {
+		return r.Power
+	}
+	return 0
+}
+
+// redstoneWireSupported checks if a redstone wire at the position passed has a block below it to rest on.
+func redstoneWireSupported(pos cube.Pos, w *world.World) bool {
+	below := pos.Side(cube.FaceDown)
+	return w.Block(below).Model().FaceSolid(below, cube.FaceUp, w)
+}
+
+// EncodeItem ...
+func (RedstoneWire) EncodeItem() (name string, meta int16) {
+	return "minecraft:redstone", 0
+}
+
+// EncodeBlock ...
+func (r RedstoneWire) EncodeBlock() (string, map[string]any) {
+	return "minecraft:redstone_wire", map[string]any{"redstone_signal": int32(r.Power)}
+}
+
+// allRedstoneWires ...
+func allRedstoneWires() (wires []world.Block) {
+	for i := 0; i <= 15; i++ {
+		wires = append(wires, RedstoneWire{Power: i})
+	}
+	return
+}
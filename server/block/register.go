@@ -44,6 +44,8 @@ func init() {
 	world.RegisterBlock(Emerald{})
 	world.RegisterBlock(EnchantingTable{})
 	world.RegisterBlock(EndBricks{})
+	world.RegisterBlock(EndGateway{})
+	world.RegisterBlock(EndPortal{})
 	world.RegisterBlock(EndStone{})
 	world.RegisterBlock(FletchingTable{})
 	world.RegisterBlock(GlassPane{})
@@ -62,6 +64,7 @@ func init() {
 	world.RegisterBlock(Lapis{})
 	world.RegisterBlock(Melon{})
 	world.RegisterBlock(MossCarpet{})
+	world.RegisterBlock(MovingBlock{})
 	world.RegisterBlock(MudBricks{})
 	world.RegisterBlock(Mud{})
 	world.RegisterBlock(NetherBrickFence{})
@@ -84,6 +87,7 @@ func init() {
 	world.RegisterBlock(RawCopper{})
 	world.RegisterBlock(RawGold{})
 	world.RegisterBlock(RawIron{})
+	world.RegisterBlock(RedstoneBlock{})
 	world.RegisterBlock(ReinforcedDeepslate{})
 	world.RegisterBlock(Sand{Red: true})
 	world.RegisterBlock(Sand{})
@@ -113,14 +117,17 @@ func init() {
 		world.RegisterBlock(LapisOre{Type: ore})
 	}
 
+	registerAll(allActivatorRails())
 	registerAll(allAnvils())
 	registerAll(allBanners())
 	registerAll(allBarrels())
 	registerAll(allBasalt())
+	registerAll(allBeds())
 	registerAll(allBeetroot())
 	registerAll(allBlackstone())
 	registerAll(allBlastFurnaces())
 	registerAll(allBoneBlock())
+	registerAll(allButtons())
 	registerAll(allCactus())
 	registerAll(allCake())
 	registerAll(allCarpet())
@@ -128,15 +135,20 @@ func init() {
 	registerAll(allChains())
 	registerAll(allChests())
 	registerAll(allCocoaBeans())
+	registerAll(allComparators())
 	registerAll(allComposters())
 	registerAll(allConcrete())
 	registerAll(allConcretePowder())
 	registerAll(allCoral())
 	registerAll(allCoralBlocks())
 	registerAll(allDeepslate())
+	registerAll(allDetectorRails())
+	registerAll(allDispensers())
 	registerAll(allDoors())
 	registerAll(allDoubleFlowers())
 	registerAll(allDoubleTallGrass())
+	registerAll(allDroppers())
+	registerAll(allEndPortalFrames())
 	registerAll(allEnderChests())
 	registerAll(allFarmland())
 	registerAll(allFence())
@@ -148,6 +160,7 @@ func init() {
 	registerAll(allGlazedTerracotta())
 	registerAll(allGrindstones())
 	registerAll(allHayBales())
+	registerAll(allHoppers())
 	registerAll(allItemFrames())
 	registerAll(allKelp())
 	registerAll(allLadders())
@@ -155,6 +168,7 @@ func init() {
 	registerAll(allLava())
 	registerAll(allLeaves())
 	registerAll(allLecterns())
+	registerAll(allLevers())
 	registerAll(allLight())
 	registerAll(allLitPumpkins())
 	registerAll(allLogs())
@@ -162,14 +176,24 @@ func init() {
 	registerAll(allMelonStems())
 	registerAll(allMuddyMangroveRoots())
 	registerAll(allNetherBricks())
+	registerAll(allNetherPortals())
 	registerAll(allNetherWart())
+	registerAll(allObservers())
+	registerAll(allPistonArmCollisions())
+	registerAll(allPistons())
 	registerAll(allPlanks())
+	registerAll(allPoweredRails())
 	registerAll(allPotato())
+	registerAll(allPressurePlates())
 	registerAll(allPrismarine())
 	registerAll(allPumpkinStems())
 	registerAll(allPumpkins())
 	registerAll(allPurpurs())
 	registerAll(allQuartz())
+	registerAll(allRails())
+	registerAll(allRedstoneTorches())
+	registerAll(allRedstoneWires())
+	registerAll(allRepeaters())
 	registerAll(allSandstones())
 	registerAll(allSeaPickles())
 	registerAll(allSigns())
@@ -195,6 +219,7 @@ func init() {
 }
 
 func init() {
+	world.RegisterItem(ActivatorRail{})
 	world.RegisterItem(Air{})
 	world.RegisterItem(Amethyst{})
 	world.RegisterItem(AncientDebris{})
@@ -224,9 +249,11 @@ func init() {
 	world.RegisterItem(Cobblestone{Mossy: true})
 	world.RegisterItem(Cobblestone{})
 	world.RegisterItem(CocoaBean{})
+	world.RegisterItem(Comparator{})
 	world.RegisterItem(Composter{})
 	world.RegisterItem(CraftingTable{})
 	world.RegisterItem(DeadBush{})
+	world.RegisterItem(DetectorRail{})
 	world.RegisterItem(DeepslateBricks{Cracked: true})
 	world.RegisterItem(DeepslateBricks{})
 	world.RegisterItem(DeepslateTiles{Cracked: true})
@@ -237,12 +264,15 @@ func init() {
 	world.RegisterItem(DirtPath{})
 	world.RegisterItem(Dirt{Coarse: true})
 	world.RegisterItem(Dirt{})
+	world.RegisterItem(Dispenser{})
 	world.RegisterItem(DragonEgg{})
 	world.RegisterItem(DriedKelp{})
 	world.RegisterItem(Dripstone{})
+	world.RegisterItem(Dropper{})
 	world.RegisterItem(Emerald{})
 	world.RegisterItem(EnchantingTable{})
 	world.RegisterItem(EndBricks{})
+	world.RegisterItem(EndPortalFrame{})
 	world.RegisterItem(EndStone{})
 	world.RegisterItem(EnderChest{})
 	world.RegisterItem(Farmland{})
@@ -258,6 +288,7 @@ func init() {
 	world.RegisterItem(Grindstone{})
 	world.RegisterItem(HayBale{})
 	world.RegisterItem(Honeycomb{})
+	world.RegisterItem(Hopper{})
 	world.RegisterItem(InvisibleBedrock{})
 	world.RegisterItem(IronBars{})
 	world.RegisterItem(Iron{})
@@ -268,6 +299,7 @@ func init() {
 	world.RegisterItem(Ladder{})
 	world.RegisterItem(Lapis{})
 	world.RegisterItem(Lectern{})
+	world.RegisterItem(Lever{})
 	world.RegisterItem(LitPumpkin{})
 	world.RegisterItem(Loom{})
 	world.RegisterItem(MelonSeeds{})
@@ -286,14 +318,18 @@ func init() {
 	world.RegisterItem(Netherite{})
 	world.RegisterItem(Netherrack{})
 	world.RegisterItem(Note{Pitch: 24})
+	world.RegisterItem(Observer{})
 	world.RegisterItem(Obsidian{Crying: true})
 	world.RegisterItem(Obsidian{})
 	world.RegisterItem(PackedIce{})
 	world.RegisterItem(PackedMud{})
+	world.RegisterItem(Piston{Sticky: true})
+	world.RegisterItem(Piston{})
 	world.RegisterItem(Podzol{})
 	world.RegisterItem(PolishedBlackstoneBrick{Cracked: true})
 	world.RegisterItem(PolishedBlackstoneBrick{})
 	world.RegisterItem(Potato{})
+	world.RegisterItem(PoweredRail{})
 	world.RegisterItem(PumpkinSeeds{})
 	world.RegisterItem(Pumpkin{Carved: true})
 	world.RegisterItem(Pumpkin{})
@@ -301,11 +337,16 @@ func init() {
 	world.RegisterItem(Purpur{})
 	world.RegisterItem(QuartzBricks{})
 	world.RegisterItem(QuartzPillar{})
+	world.RegisterItem(Rail{})
 	world.RegisterItem(Quartz{Smooth: true})
 	world.RegisterItem(Quartz{})
 	world.RegisterItem(RawCopper{})
 	world.RegisterItem(RawGold{})
 	world.RegisterItem(RawIron{})
+	world.RegisterItem(RedstoneBlock{})
+	world.RegisterItem(RedstoneTorch{})
+	world.RegisterItem(RedstoneWire{})
+	world.RegisterItem(Repeater{})
 	world.RegisterItem(ReinforcedDeepslate{})
 	world.RegisterItem(Sand{Red: true})
 	world.RegisterItem(Sand{})
@@ -355,6 +396,7 @@ func init() {
 	}
 	for _, c := range item.Colours() {
 		world.RegisterItem(Banner{Colour: c})
+		world.RegisterItem(Bed{Colour: c})
 		world.RegisterItem(Carpet{Colour: c})
 		world.RegisterItem(ConcretePowder{Colour: c})
 		world.RegisterItem(Concrete{Colour: c})
@@ -431,6 +473,12 @@ func init() {
 	for _, t := range DeepslateTypes() {
 		world.RegisterItem(Deepslate{Type: t})
 	}
+	for _, t := range ButtonTypes() {
+		world.RegisterItem(Button{Type: t})
+	}
+	for _, t := range PressurePlateTypes() {
+		world.RegisterItem(PressurePlate{Type: t})
+	}
 }
 
 func registerAll(blocks []world.Block) {
@@ -0,0 +1,161 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// Repeater is a redstone component that repeats the redstone power it receives from behind at full strength after a
+// short delay. A repeater may be locked by another repeater or comparator powering it from the side, in which case it
+// keeps its current state.
+type Repeater struct {
+	transparent
+
+	// Facing is the direction in which the repeater outputs power. Power is received from the opposite direction.
+	Facing cube.Direction
+	// Delay is the delay of the repeater minus one, in redstone ticks of two game ticks each. It ranges from 0 to 3.
+	Delay int
+	// Powered is true if the repeater is currently outputting power.
+	Powered bool
+}
+
+// Model ...
+func (Repeater) Model() world.BlockModel {
+	return model.Diode{}
+}
+
+// BreakInfo ...
+func (r Repeater) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Repeater{}))
+}
+
+// UseOnBlock ...
+func (r Repeater) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, r)
+	if !used {
+		return false
+	}
+	if !attachedFaceSolid(pos, cube.FaceUp, w) {
+		return false
+	}
+	r.Facing = user.Rotation().Direction()
+	r.Powered = false
+
+	place(w, pos, r, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (r Repeater) Activate(pos cube.Pos, _ cube.Face, w *world.World, _ item.User, _ *item.UseContext) bool {
+	r.Delay = (r.Delay + 1) % 4
+	w.SetBlock(pos, r, nil)
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (r Repeater) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !attachedFaceSolid(pos, cube.FaceUp, w) {
+		w.SetBlock(pos, nil, nil)
+		dropItem(w, item.NewStack(Repeater{}, 1), pos.Vec3Centre())
+		return
+	}
+	if r.Locked(pos, w) {
+		return
+	}
+	if r.inputPower(pos, w) > 0 != r.Powered {
+		w.ScheduleBlockUpdate(pos, r.delay())
+	}
+}
+
+// ScheduledTick ...
+func (r Repeater) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if r.Locked(pos, w) {
+		return
+	}
+	powered := r.inputPower(pos, w) > 0
+	if r.Powered && !powered {
+		r.Powered = false
+		w.SetBlock(pos, r, nil)
+	} else if !r.Powered {
+		r.Powered = true
+		w.SetBlock(pos, r, nil)
+		if !powered {
+			// The input of the repeater was only powered for a short time. Repeaters always output a pulse with a
+			// length of at least their delay, so we turn off the repeater again after the delay.
+			w.ScheduleBlockUpdate(pos, r.delay())
+		}
+	}
+}
+
+// Locked checks if the repeater at the position passed is locked by a powered repeater or comparator facing into
+// one of its sides. A locked repeater keeps outputting its current power.
+func (r Repeater) Locked(pos cube.Pos, w *world.World) bool {
+	for _, d := range []cube.Direction{r.Facing.RotateLeft(), r.Facing.RotateRight()} {
+		side := pos.Side(d.Face())
+		switch b := w.Block(side).(type) {
+		case Repeater, Comparator:
+			if b.(world.Conductor).WeakPower(side, d.Opposite().Face(), w, true) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inputPower returns the power that the repeater at the position passed receives from behind.
+func (r Repeater) inputPower(pos cube.Pos, w *world.World) int {
+	return w.RedstonePower(pos.Side(r.Facing.Opposite().Face()), r.Facing.Face(), true)
+}
+
+// delay returns the delay of the repeater as a time.Duration.
+func (r Repeater) delay() time.Duration {
+	return time.Duration(r.Delay+1) * time.Second / 10
+}
+
+// WeakPower ...
+func (r Repeater) WeakPower(_ cube.Pos, face cube.Face, _ *world.World, _ bool) int {
+	if r.Powered && face == r.Facing.Face() {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (r Repeater) StrongPower(pos cube.Pos, face cube.Face, w *world.World, accountForDust bool) int {
+	return r.WeakPower(pos, face, w, accountForDust)
+}
+
+// connectsWire ...
+func (r Repeater) connectsWire(face cube.Face) bool {
+	return face.Axis() == r.Facing.Face().Axis()
+}
+
+// EncodeItem ...
+func (Repeater) EncodeItem() (name string, meta int16) {
+	return "minecraft:repeater", 0
+}
+
+// EncodeBlock ...
+func (r Repeater) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:unpowered_repeater"
+	if r.Powered {
+		name = "minecraft:powered_repeater"
+	}
+	return name, map[string]any{"minecraft:cardinal_direction": r.Facing.String(), "repeater_delay": int32(r.Delay)}
+}
+
+// allRepeaters ...
+func allRepeaters() (repeaters []world.Block) {
+	for _, d := range cube.Directions() {
+		for delay := 0; delay < 4; delay++ {
+			repeaters = append(repeaters, Repeater{Facing: d, Delay: delay})
+			repeaters = append(repeaters, Repeater{Facing: d, Delay: delay, Powered: true})
+		}
+	}
+	return
+}
@@ -30,11 +30,26 @@ func (t TNT) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, ct
 
 // Ignite ...
 func (t TNT) Ignite(pos cube.Pos, w *world.World, igniter world.Entity) bool {
+	if !w.BoolGameRule(world.GameRuleTNTExplodes) {
+		return false
+	}
 	t.igniter = igniter
 	spawnTnt(pos, w, time.Second*4, t.igniter)
 	return true
 }
 
+// Dispense spawns primed TNT in front of the dispenser.
+func (t TNT) Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *item.UseContext) bool {
+	if !w.BoolGameRule(world.GameRuleTNTExplodes) {
+		return false
+	}
+	pos = pos.Side(face)
+	w.PlaySound(pos.Vec3Centre(), sound.TNT{})
+	w.AddEntity(w.EntityRegistry().Config().TNT(pos.Vec3Centre(), time.Second*4, nil))
+	ctx.SubtractFromCount(1)
+	return true
+}
+
 // Igniter returns the entity that ignited the TNT.
 // It is nil if ignited by a world source like fire.
 func (t TNT) Igniter() world.Entity {
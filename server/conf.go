@@ -2,14 +2,12 @@ package server
 
 import (
 	"fmt"
-	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/internal/packbuilder"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/playerdb"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
-	"github.com/df-mc/dragonfly/server/world/biome"
 	"github.com/df-mc/dragonfly/server/world/generator"
 	"github.com/df-mc/dragonfly/server/world/mcdb"
 	"github.com/google/uuid"
@@ -79,14 +77,19 @@ type Config struct {
 	// chunks will always be newly generated when loaded. The world provider
 	// will be used for storing/loading the default overworld, nether and end.
 	WorldProvider world.Provider
+	// WorldsFolder is the folder that worlds loaded by name using the
+	// WorldManager of the Server are stored in. Each world is stored in a
+	// folder with its name in the WorldsFolder. If left empty, WorldsFolder
+	// will be set to "worlds".
+	WorldsFolder string
 	// ReadOnlyWorld specifies if the standard worlds should be read only. If
 	// set to true, the WorldProvider won't be saved to at all.
 	ReadOnlyWorld bool
 	// Generator should return a function that specifies the world.Generator to
 	// use for every world.Dimension (world.Overworld, world.Nether and
-	// world.End). If left empty, Generator will be set to a flat world for each
-	// of the dimensions (with netherrack and end stone for nether/end
-	// respectively).
+	// world.End). If left empty, Generator will be set to vanilla-style
+	// generators for each of the dimensions, using the seed of the
+	// WorldProvider's settings.
 	Generator func(dim world.Dimension) world.Generator
 	// RandomTickSpeed specifies the rate at which blocks should be ticked in
 	// the default worlds. Setting this value to -1 or lower will stop random
@@ -94,6 +97,12 @@ type Config struct {
 	// left as 0, the RandomTickSpeed will default to a speed of 3 blocks per
 	// sub chunk per tick (normal ticking speed).
 	RandomTickSpeed int
+	// AutosaveInterval is the interval at which the data of online players
+	// and the modified chunks of the default worlds are saved while the Server
+	// is running, so that little progress is lost if the Server crashes. If
+	// left as 0, AutosaveInterval will default to 5 minutes. Setting this
+	// value to -1 or lower disables autosaving.
+	AutosaveInterval time.Duration
 	// Entities is a world.EntityRegistry with all entity types registered that
 	// may be added to the Server's worlds. If no entity types are registered,
 	// Entities will be set to entity.DefaultRegistry.
@@ -130,10 +139,21 @@ func (conf Config) New() *Server {
 		conf.Allower = allower{}
 	}
 	if conf.WorldProvider == nil {
-		conf.WorldProvider = world.NopProvider{}
+		// Share the same settings between all worlds, so that they are
+		// generated using the same seed.
+		conf.WorldProvider = world.NopProvider{Set: world.NopProvider{}.Settings()}
 	}
 	if conf.Generator == nil {
-		conf.Generator = loadGenerator
+		seed := conf.WorldProvider.Settings().Seed
+		conf.Generator = func(dim world.Dimension) world.Generator {
+			return loadGenerator(dim, seed)
+		}
+	}
+	if conf.AutosaveInterval == 0 {
+		conf.AutosaveInterval = time.Minute * 5
+	}
+	if conf.WorldsFolder == "" {
+		conf.WorldsFolder = "worlds"
 	}
 	if conf.MaxChunkRadius == 0 {
 		conf.MaxChunkRadius = 12
@@ -152,12 +172,20 @@ func (conf Config) New() *Server {
 	srv := &Server{
 		conf:     conf,
 		incoming: make(chan *session.Session),
+		closing:  make(chan struct{}),
 		p:        make(map[uuid.UUID]*player.Player),
-		world:    &world.World{}, nether: &world.World{}, end: &world.World{},
 	}
-	srv.world = srv.createWorld(world.Overworld, &srv.nether, &srv.end)
-	srv.nether = srv.createWorld(world.Nether, &srv.world, &srv.end)
-	srv.end = srv.createWorld(world.End, &srv.nether, &srv.world)
+	srv.worlds = newWorldManager(srv, conf.WorldsFolder)
+	srv.worlds.Link(OverworldName, world.Nether, NetherName)
+	srv.worlds.Link(OverworldName, world.End, EndName)
+	srv.worlds.Link(NetherName, world.Nether, OverworldName)
+	srv.worlds.Link(NetherName, world.End, EndName)
+	srv.worlds.Link(EndName, world.Nether, NetherName)
+	srv.worlds.Link(EndName, world.End, OverworldName)
+
+	srv.world = srv.createWorld(OverworldName, world.Overworld)
+	srv.nether = srv.createWorld(NetherName, world.Nether)
+	srv.end = srv.createWorld(EndName, world.End)
 
 	srv.registerTargetFunc()
 	srv.checkNetIsolation()
@@ -204,7 +232,8 @@ type UserConfig struct {
 		SaveData bool
 		// Folder is the folder that the data of the world resides in.
 		Folder string
-		// Seed specifies the psudorandom number generators seed value when used with a generator
+		// Seed is the seed used to generate the world if it is newly created.
+		// The seed of an existing world is never changed.
 		Seed int64
 	}
 	Players struct {
@@ -257,7 +286,7 @@ func (uc UserConfig) Config(log Logger) (Config, error) {
 		DisableResourceBuilding: !uc.Resources.AutoBuildPack,
 	}
 	if uc.World.SaveData {
-		conf.WorldProvider, err = mcdb.Config{Log: log}.Open(uc.World.Folder)
+		conf.WorldProvider, err = mcdb.Config{Log: log, Seed: uc.World.Seed}.Open(uc.World.Folder)
 		if err != nil {
 			return conf, fmt.Errorf("create world provider: %w", err)
 		}
@@ -294,15 +323,16 @@ func loadResources(dir string) ([]*resource.Pack, error) {
 	return packs, nil
 }
 
-// loadGenerator loads a standard world.Generator for a world.Dimension.
-func loadGenerator(dim world.Dimension) world.Generator {
+// loadGenerator loads a standard world.Generator for a world.Dimension using
+// the seed passed.
+func loadGenerator(dim world.Dimension, seed int64) world.Generator {
 	switch dim {
 	case world.Overworld:
-		return generator.NewFlat(biome.Plains{}, []world.Block{block.Grass{}, block.Dirt{}, block.Dirt{}, block.Bedrock{}})
+		return generator.NewOverworld(seed)
 	case world.Nether:
-		return generator.NewFlat(biome.NetherWastes{}, []world.Block{block.Netherrack{}, block.Netherrack{}, block.Netherrack{}, block.Bedrock{}})
+		return generator.NewNether(seed)
 	case world.End:
-		return generator.NewFlat(biome.End{}, []world.Block{block.EndStone{}, block.EndStone{}, block.EndStone{}, block.Bedrock{}})
+		return generator.NewEnd(seed)
 	}
 	panic("should never happen")
 }
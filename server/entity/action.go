@@ -47,6 +47,9 @@ type FireworkExplosionAction struct{ action }
 // TotemUseAction is a world.EntityAction that displays the totem use particles and animation.
 type TotemUseAction struct{ action }
 
+// WakeUpAction is a world.EntityAction that makes a sleeping entity display the animation of getting out of bed.
+type WakeUpAction struct{ action }
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
@@ -0,0 +1,124 @@
+package attribute
+
+import "math"
+
+// Attribute is a property of an entity, such as its movement speed or its
+// maximum health. Every attribute has a base value, which may be changed by
+// Modifiers, for example those applied by effects or by held items.
+type Attribute struct {
+	attribute
+}
+
+// MovementSpeed returns the attribute that holds the speed of an entity in
+// blocks/tick.
+func MovementSpeed() Attribute {
+	return Attribute{0}
+}
+
+// AttackDamage returns the attribute that holds the damage dealt by melee
+// attacks of an entity.
+func AttackDamage() Attribute {
+	return Attribute{1}
+}
+
+// MaxHealth returns the attribute that holds the maximum health of an entity.
+func MaxHealth() Attribute {
+	return Attribute{2}
+}
+
+// KnockBackResistance returns the attribute that holds the fraction, from 0-1,
+// of knock back force that is resisted by an entity.
+func KnockBackResistance() Attribute {
+	return Attribute{3}
+}
+
+// FollowRange returns the attribute that holds the range in blocks within
+// which an entity tracks and follows its targets.
+func FollowRange() Attribute {
+	return Attribute{4}
+}
+
+// Attributes returns a list of all attributes.
+func Attributes() []Attribute {
+	return []Attribute{MovementSpeed(), AttackDamage(), MaxHealth(), KnockBackResistance(), FollowRange()}
+}
+
+type attribute uint8
+
+// Uint8 returns the attribute as a uint8.
+func (a attribute) Uint8() uint8 {
+	return uint8(a)
+}
+
+// Name returns the name of the attribute as it is sent over network.
+func (a attribute) Name() string {
+	switch a {
+	case 0:
+		return "minecraft:movement"
+	case 1:
+		return "minecraft:attack_damage"
+	case 2:
+		return "minecraft:health"
+	case 3:
+		return "minecraft:knockback_resistance"
+	case 4:
+		return "minecraft:follow_range"
+	}
+	panic("unknown attribute")
+}
+
+// String ...
+func (a attribute) String() string {
+	switch a {
+	case 0:
+		return "movement_speed"
+	case 1:
+		return "attack_damage"
+	case 2:
+		return "max_health"
+	case 3:
+		return "knockback_resistance"
+	case 4:
+		return "follow_range"
+	}
+	panic("unknown attribute")
+}
+
+// Default returns the default base value of the attribute.
+func (a attribute) Default() float64 {
+	switch a {
+	case 0:
+		return 0.1
+	case 1:
+		return 1
+	case 2:
+		return 20
+	case 3:
+		return 0
+	case 4:
+		return 16
+	}
+	panic("unknown attribute")
+}
+
+// Min returns the minimum value of the attribute. Values lower than Min are
+// clamped to Min.
+func (a attribute) Min() float64 {
+	switch a {
+	case 2:
+		return 1
+	}
+	return 0
+}
+
+// Max returns the maximum value of the attribute. Values higher than Max are
+// clamped to Max.
+func (a attribute) Max() float64 {
+	switch a {
+	case 3:
+		return 1
+	case 4:
+		return 2048
+	}
+	return math.MaxFloat32
+}
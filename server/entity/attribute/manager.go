@@ -0,0 +1,150 @@
+package attribute
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Manager manages the attributes of an entity. It holds the base value of
+// every Attribute and the Modifiers applied to it. Manager is safe for
+// concurrent use.
+type Manager struct {
+	mu     sync.Mutex
+	values map[Attribute]*value
+	f      func(a Attribute, before, after float64)
+}
+
+// value holds the base value, modifiers and resulting value of a single
+// Attribute.
+type value struct {
+	base, final float64
+	modifiers   []Modifier
+}
+
+// NewManager returns a new Manager with all attributes set to their default
+// base value. The function passed is called when the value of an Attribute
+// changes. f may be nil.
+func NewManager(f func(a Attribute, before, after float64)) *Manager {
+	if f == nil {
+		f = func(Attribute, float64, float64) {}
+	}
+	m := &Manager{values: make(map[Attribute]*value, len(Attributes())), f: f}
+	for _, a := range Attributes() {
+		m.values[a] = &value{base: a.Default(), final: a.Default()}
+	}
+	return m
+}
+
+// Value returns the value of the Attribute passed, with all of its Modifiers
+// applied.
+func (m *Manager) Value(a Attribute) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[a].final
+}
+
+// Base returns the base value of the Attribute passed, without any of its
+// Modifiers applied.
+func (m *Manager) Base(a Attribute) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[a].base
+}
+
+// SetBase sets the base value of the Attribute passed. Modifiers are applied
+// on top of the new base value.
+func (m *Manager) SetBase(a Attribute, base float64) {
+	m.update(a, func(v *value) {
+		v.base = base
+	})
+}
+
+// Modifiers returns all Modifiers currently applied to the Attribute passed.
+func (m *Manager) Modifiers(a Attribute) []Modifier {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.values[a].modifiers)
+}
+
+// Modifier returns the Modifier with the ID passed applied to the Attribute
+// passed. False is returned if no such Modifier exists.
+func (m *Manager) Modifier(a Attribute, id uuid.UUID) (Modifier, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mods := m.values[a].modifiers
+	if i := slices.IndexFunc(mods, func(mod Modifier) bool { return mod.ID == id }); i != -1 {
+		return mods[i], true
+	}
+	return Modifier{}, false
+}
+
+// AddModifier applies the Modifier passed to its Attribute. If a Modifier with
+// the same ID is already applied to the Attribute, it is replaced.
+func (m *Manager) AddModifier(mod Modifier) {
+	m.update(mod.Attribute, func(v *value) {
+		if i := slices.IndexFunc(v.modifiers, func(other Modifier) bool { return other.ID == mod.ID }); i != -1 {
+			v.modifiers[i] = mod
+			return
+		}
+		v.modifiers = append(v.modifiers, mod)
+	})
+}
+
+// RemoveModifier removes the Modifier with the ID passed from the Attribute
+// passed. Nothing happens if no such Modifier exists.
+func (m *Manager) RemoveModifier(a Attribute, id uuid.UUID) {
+	m.update(a, func(v *value) {
+		v.modifiers = slices.DeleteFunc(v.modifiers, func(mod Modifier) bool { return mod.ID == id })
+	})
+}
+
+// SwapModifiers removes all Modifiers in before and adds all Modifiers in
+// after. It may be used to replace the Modifiers of an item held by an entity
+// when it switches to a different item.
+func (m *Manager) SwapModifiers(before, after []Modifier) {
+	for _, mod := range before {
+		if !slices.ContainsFunc(after, func(other Modifier) bool { return other.ID == mod.ID && other.Attribute == mod.Attribute }) {
+			m.RemoveModifier(mod.Attribute, mod.ID)
+		}
+	}
+	for _, mod := range after {
+		m.AddModifier(mod)
+	}
+}
+
+// update calls the function passed on the value of the Attribute passed and
+// recalculates its final value. The function passed to NewManager is called
+// if the final value changed.
+func (m *Manager) update(a Attribute, f func(v *value)) {
+	m.mu.Lock()
+	v := m.values[a]
+	before := v.final
+	f(v)
+	v.final = v.compute(a)
+	after := v.final
+	m.mu.Unlock()
+
+	if before != after {
+		m.f(a, before, after)
+	}
+}
+
+// compute computes the final value of the Attribute passed by applying all
+// Modifiers to the base value and clamping it between the minimum and maximum
+// value of the Attribute.
+func (v *value) compute(a Attribute) float64 {
+	val := v.base
+	for _, mod := range v.modifiers {
+		if mod.Operation == OperationAdd() {
+			val += mod.Amount
+		}
+	}
+	for _, mod := range v.modifiers {
+		if mod.Operation == OperationMultiply() {
+			val *= 1 + mod.Amount
+		}
+	}
+	return math.Max(a.Min(), math.Min(a.Max(), val))
+}
@@ -0,0 +1,66 @@
+package attribute
+
+import "github.com/google/uuid"
+
+// Modifier modifies the value of an Attribute of an entity. Modifiers are
+// identified by their ID: Adding a Modifier with the same ID as one already
+// present replaces the existing Modifier.
+type Modifier struct {
+	// ID is the unique ID of the Modifier. Sources of modifiers, such as
+	// effects, typically use a fixed ID so that they can later remove the
+	// Modifier again.
+	ID uuid.UUID
+	// Name is a name describing the source of the Modifier, for example
+	// "effect.moveSpeed".
+	Name string
+	// Attribute is the Attribute that the Modifier modifies.
+	Attribute Attribute
+	// Amount is the amount by which the Modifier changes the Attribute. How
+	// the Amount is applied depends on the Operation.
+	Amount float64
+	// Operation is the Operation used to apply the Amount to the Attribute.
+	Operation Operation
+}
+
+// Operation is an operation used to apply the Amount of a Modifier to the
+// value of an Attribute.
+type Operation struct {
+	operation
+}
+
+// OperationAdd returns an Operation that adds the Amount of a Modifier to the
+// base value of an Attribute. It is applied before any OperationMultiply
+// modifiers.
+func OperationAdd() Operation {
+	return Operation{0}
+}
+
+// OperationMultiply returns an Operation that multiplies the value of an
+// Attribute with 1 + the Amount of a Modifier. Multiple OperationMultiply
+// modifiers stack multiplicatively.
+func OperationMultiply() Operation {
+	return Operation{1}
+}
+
+// Operations returns a list of all operations.
+func Operations() []Operation {
+	return []Operation{OperationAdd(), OperationMultiply()}
+}
+
+type operation uint8
+
+// Uint8 returns the operation as a uint8.
+func (o operation) Uint8() uint8 {
+	return uint8(o)
+}
+
+// String ...
+func (o operation) String() string {
+	switch o {
+	case 0:
+		return "add"
+	case 1:
+		return "multiply"
+	}
+	panic("unknown operation")
+}
@@ -46,6 +46,10 @@ type (
 
 	// ExplosionDamageSource is used for damage caused by an explosion.
 	ExplosionDamageSource struct{}
+
+	// BorderDamageSource is used for damage caused by an entity being outside
+	// of the world border.
+	BorderDamageSource struct{}
 )
 
 func (FallDamageSource) ReducedByArmour() bool     { return false }
@@ -80,6 +84,9 @@ func (ProjectileDamageSource) AffectedByEnchantment(e item.EnchantmentType) bool
 	_, prot := e.(enchantment.ProjectileProtection)
 	return prot
 }
+func (BorderDamageSource) ReducedByResistance() bool    { return false }
+func (BorderDamageSource) ReducedByArmour() bool        { return false }
+func (BorderDamageSource) Fire() bool                   { return false }
 func (ExplosionDamageSource) ReducedByResistance() bool { return true }
 func (ExplosionDamageSource) ReducedByArmour() bool     { return true }
 func (ExplosionDamageSource) Fire() bool                { return false }
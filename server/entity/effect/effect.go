@@ -1,7 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 	"time"
 )
@@ -167,3 +169,36 @@ type living interface {
 	// SetSpeed sets the speed of an entity to a new value.
 	SetSpeed(float64)
 }
+
+// attributeHolder represents an entity that has attributes, which may be modified by effects.
+type attributeHolder interface {
+	// Attributes returns the attribute.Manager holding the attributes of the entity.
+	Attributes() *attribute.Manager
+}
+
+// multiplySpeed multiplies the speed of the entity passed by the multiplier passed if it does not have attributes.
+// This keeps effects such as Speed and Slowness working for living entities that only implement Speed and SetSpeed.
+func multiplySpeed(e world.Entity, multiplier float64) {
+	if _, ok := e.(attributeHolder); ok {
+		return
+	}
+	if l, ok := e.(living); ok {
+		l.SetSpeed(l.Speed() * multiplier)
+	}
+}
+
+// addModifier adds an attribute.Modifier with the ID, attribute, amount and operation passed to the entity passed, if
+// it has attributes.
+func addModifier(e world.Entity, id uuid.UUID, name string, a attribute.Attribute, amount float64, op attribute.Operation) {
+	if h, ok := e.(attributeHolder); ok {
+		h.Attributes().AddModifier(attribute.Modifier{ID: id, Name: name, Attribute: a, Amount: amount, Operation: op})
+	}
+}
+
+// removeModifier removes the attribute.Modifier with the ID passed from the attribute passed of the entity passed, if
+// it has attributes.
+func removeModifier(e world.Entity, id uuid.UUID, a attribute.Attribute) {
+	if h, ok := e.(attributeHolder); ok {
+		h.Attributes().RemoveModifier(a, id)
+	}
+}
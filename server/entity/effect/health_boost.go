@@ -1,7 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -10,18 +12,17 @@ type HealthBoost struct {
 	nopLasting
 }
 
+// healthBoostModifierID is the ID of the attribute.Modifier applied by HealthBoost.
+var healthBoostModifierID = uuid.MustParse("5d6f0ba2-1186-46ac-b896-c61c5cee99cc")
+
 // Start ...
 func (HealthBoost) Start(e world.Entity, lvl int) {
-	if l, ok := e.(living); ok {
-		l.SetMaxHealth(l.MaxHealth() + 4*float64(lvl))
-	}
+	addModifier(e, healthBoostModifierID, "effect.healthBoost", attribute.MaxHealth(), 4*float64(lvl), attribute.OperationAdd())
 }
 
 // End ...
-func (HealthBoost) End(e world.Entity, lvl int) {
-	if l, ok := e.(living); ok {
-		l.SetMaxHealth(l.MaxHealth() - 4*float64(lvl))
-	}
+func (HealthBoost) End(e world.Entity, _ int) {
+	removeModifier(e, healthBoostModifierID, attribute.MaxHealth())
 }
 
 // RGBA ...
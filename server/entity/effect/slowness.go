@@ -1,8 +1,11 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
+	"math"
 )
 
 // Slowness is a lasting effect that decreases the movement speed of a living entity by 15% for each level
@@ -11,26 +14,20 @@ type Slowness struct {
 	nopLasting
 }
 
+// slownessModifierID is the ID of the attribute.Modifier applied by Slowness.
+var slownessModifierID = uuid.MustParse("7107de5e-7ce8-4030-940e-514c1f160890")
+
 // Start ...
 func (Slowness) Start(e world.Entity, lvl int) {
-	slowness := 1 - float64(lvl)*0.15
-	if slowness <= 0 {
-		slowness = 0.00001
-	}
-	if l, ok := e.(living); ok {
-		l.SetSpeed(l.Speed() * slowness)
-	}
+	slowness := math.Max(-float64(lvl)*0.15, -0.99999)
+	addModifier(e, slownessModifierID, "effect.moveSlowdown", attribute.MovementSpeed(), slowness, attribute.OperationMultiply())
+	multiplySpeed(e, 1+slowness)
 }
 
 // End ...
 func (Slowness) End(e world.Entity, lvl int) {
-	slowness := 1 - float64(lvl)*0.15
-	if slowness <= 0 {
-		slowness = 0.00001
-	}
-	if l, ok := e.(living); ok {
-		l.SetSpeed(l.Speed() / slowness)
-	}
+	removeModifier(e, slownessModifierID, attribute.MovementSpeed())
+	multiplySpeed(e, 1/(1+math.Max(-float64(lvl)*0.15, -0.99999)))
 }
 
 // RGBA ...
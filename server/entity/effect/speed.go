@@ -1,7 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -10,20 +12,20 @@ type Speed struct {
 	nopLasting
 }
 
+// speedModifierID is the ID of the attribute.Modifier applied by Speed.
+var speedModifierID = uuid.MustParse("91aeaa56-376b-4498-935b-2f7f68070635")
+
 // Start ...
 func (Speed) Start(e world.Entity, lvl int) {
-	speed := 1 + float64(lvl)*0.2
-	if l, ok := e.(living); ok {
-		l.SetSpeed(l.Speed() * speed)
-	}
+	speed := float64(lvl) * 0.2
+	addModifier(e, speedModifierID, "effect.moveSpeed", attribute.MovementSpeed(), speed, attribute.OperationMultiply())
+	multiplySpeed(e, 1+speed)
 }
 
 // End ...
 func (Speed) End(e world.Entity, lvl int) {
-	speed := 1 + float64(lvl)*0.2
-	if l, ok := e.(living); ok {
-		l.SetSpeed(l.Speed() / speed)
-	}
+	removeModifier(e, speedModifierID, attribute.MovementSpeed())
+	multiplySpeed(e, 1/(1+float64(lvl)*0.2))
 }
 
 // RGBA ...
@@ -1,6 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -9,6 +12,19 @@ type Strength struct {
 	nopLasting
 }
 
+// strengthModifierID is the ID of the attribute.Modifier applied by Strength.
+var strengthModifierID = uuid.MustParse("648d7064-6a60-4f59-8abe-c2c23a6dd7a9")
+
+// Start ...
+func (s Strength) Start(e world.Entity, lvl int) {
+	addModifier(e, strengthModifierID, "effect.damageBoost", attribute.AttackDamage(), s.Multiplier(lvl), attribute.OperationMultiply())
+}
+
+// End ...
+func (Strength) End(e world.Entity, _ int) {
+	removeModifier(e, strengthModifierID, attribute.AttackDamage())
+}
+
 // Multiplier returns the damage multiplier of the effect.
 func (Strength) Multiplier(lvl int) float64 {
 	return 0.3 * float64(lvl)
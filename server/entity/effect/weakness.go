@@ -1,6 +1,9 @@
 package effect
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"image/color"
 )
 
@@ -9,6 +12,19 @@ type Weakness struct {
 	nopLasting
 }
 
+// weaknessModifierID is the ID of the attribute.Modifier applied by Weakness.
+var weaknessModifierID = uuid.MustParse("22653b89-116e-49dc-9b6b-9971489b5be5")
+
+// Start ...
+func (w Weakness) Start(e world.Entity, lvl int) {
+	addModifier(e, weaknessModifierID, "effect.weakness", attribute.AttackDamage(), -w.Multiplier(lvl), attribute.OperationMultiply())
+}
+
+// End ...
+func (Weakness) End(e world.Entity, _ int) {
+	removeModifier(e, weaknessModifierID, attribute.AttackDamage())
+}
+
 // Multiplier returns the damage multiplier of the effect.
 func (Weakness) Multiplier(lvl int) float64 {
 	v := 0.2 * float64(lvl)
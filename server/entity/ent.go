@@ -33,6 +33,9 @@ func (conf Config) New(t world.EntityType, pos mgl64.Vec3) *Ent {
 type Ent struct {
 	conf Config
 	t    world.EntityType
+	// self is the world.Entity that embeds the Ent, such as a Mob. It is nil
+	// if the Ent is not embedded in another entity.
+	self world.Entity
 
 	mu  sync.Mutex
 	pos mgl64.Vec3
@@ -43,6 +46,8 @@ type Ent struct {
 
 	fireDuration time.Duration
 	age          time.Duration
+
+	portal PortalTravel
 }
 
 // Explode propagates the explosion behaviour of the underlying Behaviour.
@@ -54,6 +59,15 @@ func (e *Ent) Explode(src mgl64.Vec3, impact float64, conf block.ExplosionConfig
 	}
 }
 
+// Hit propagates a hit by the attacker passed, dealing the damage passed, to
+// the underlying Behaviour. It is used for entities that are not living but
+// may still be hit, such as minecarts.
+func (e *Ent) Hit(attacker world.Entity, dmg float64) {
+	if h, ok := e.conf.Behaviour.(Hittable); ok {
+		h.Hit(e, attacker, dmg)
+	}
+}
+
 // Type returns the world.EntityType passed to Config.New.
 func (e *Ent) Type() world.EntityType {
 	return e.t
@@ -95,7 +109,7 @@ func (e *Ent) Rotation() cube.Rotation {
 
 // World returns the world of the entity.
 func (e *Ent) World() *world.World {
-	w, _ := world.OfEntity(e)
+	w, _ := world.OfEntity(e.outer())
 	return w
 }
 
@@ -129,7 +143,7 @@ func (e *Ent) SetOnFire(duration time.Duration) {
 		return
 	}
 	for _, v := range e.World().Viewers(pos) {
-		v.ViewEntityState(e)
+		v.ViewEntityState(e.outer())
 	}
 }
 
@@ -154,7 +168,7 @@ func (e *Ent) SetNameTag(s string) {
 	e.mu.Unlock()
 
 	for _, v := range e.World().Viewers(e.Position()) {
-		v.ViewEntityState(e)
+		v.ViewEntityState(e.outer())
 	}
 }
 
@@ -165,7 +179,7 @@ func (e *Ent) Tick(w *world.World, current int64) {
 	y := e.pos[1]
 	e.mu.Unlock()
 	if y < float64(w.Range()[0]) && current%10 == 0 {
-		_ = e.Close()
+		_ = e.outer().Close()
 		return
 	}
 	e.SetOnFire(e.OnFireDuration() - time.Second/20)
@@ -173,13 +187,51 @@ func (e *Ent) Tick(w *world.World, current int64) {
 	if m := e.conf.Behaviour.Tick(e); m != nil {
 		m.Send()
 	}
+	if _, stationary := e.conf.Behaviour.(*StationaryBehaviour); !stationary && e.World() == w {
+		e.portal.Tick(e.outer(), w, 1, e.travel)
+	}
 	e.mu.Lock()
 	e.age += time.Second / 20
 	e.mu.Unlock()
 }
 
+// Teleport teleports the entity to the position passed. Unlike moving the
+// entity, viewers see the entity at the new position immediately.
+func (e *Ent) Teleport(pos mgl64.Vec3) {
+	e.mu.Lock()
+	e.pos, e.vel = pos, mgl64.Vec3{}
+	e.mu.Unlock()
+
+	for _, v := range e.World().Viewers(pos) {
+		v.ViewEntityTeleport(e.outer(), pos)
+	}
+}
+
+// travel moves the entity to the position passed in the World passed after it
+// used a portal. Any Rider riding the entity stops riding it.
+func (e *Ent) travel(dest *world.World, pos mgl64.Vec3) {
+	if r, ok := e.conf.Behaviour.(Rideable); ok {
+		if rider, ok := r.Rider(); ok {
+			rider.Dismount()
+		}
+	}
+	if dest != e.World() {
+		dest.AddEntity(e.outer())
+	}
+	e.Teleport(pos)
+}
+
 // Close closes the Ent and removes the associated entity from the world.
 func (e *Ent) Close() error {
-	e.World().RemoveEntity(e)
+	e.World().RemoveEntity(e.outer())
 	return nil
 }
+
+// outer returns the world.Entity that the Ent is part of. This is the Ent
+// itself, unless it is embedded in another entity such as a Mob.
+func (e *Ent) outer() world.Entity {
+	if e.self != nil {
+		return e.self
+	}
+	return e
+}
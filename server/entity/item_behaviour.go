@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
@@ -70,6 +72,9 @@ func (i *ItemBehaviour) Tick(e *Ent) *Movement {
 
 // tick checks if the item can be picked up or merged with nearby item stacks.
 func (i *ItemBehaviour) tick(e *Ent) {
+	if i.checkHopper(e) {
+		return
+	}
 	if i.pickupDelay == 0 {
 		i.checkNearby(e)
 	} else if i.pickupDelay < math.MaxInt16*(time.Second/20) {
@@ -77,6 +82,30 @@ func (i *ItemBehaviour) tick(e *Ent) {
 	}
 }
 
+// checkHopper checks if the item entity is inside of or directly above a
+// hopper. If so, the hopper collects as much of the item as it can, regardless
+// of the pickup delay. True is returned if the item entity was collected.
+func (i *ItemBehaviour) checkHopper(e *Ent) bool {
+	w, pos := e.World(), e.Position()
+	blockPos := cube.PosFromVec3(pos)
+	for _, p := range []cube.Pos{blockPos, blockPos.Side(cube.FaceDown)} {
+		h, ok := w.Block(p).(block.Hopper)
+		if !ok {
+			continue
+		}
+		n := h.CollectItem(i.i)
+		if n == 0 {
+			return false
+		}
+		if n < i.i.Count() {
+			w.AddEntity(NewItem(i.i.Grow(-n), pos))
+		}
+		_ = e.Close()
+		return true
+	}
+	return false
+}
+
 // checkNearby checks the nearby entities for item collectors and other item
 // stacks. If a collector is found in range, the item will be picked up. If
 // another item stack with the same item type is found in range, the item
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewMinecart creates a minecart entity at the position passed. Minecarts ride
+// on rails and may be ridden by other entities, such as players.
+func NewMinecart(pos mgl64.Vec3) *Ent {
+	return Config{Behaviour: minecartConf.New()}.New(MinecartType{}, pos)
+}
+
+// NewChestMinecart creates a minecart entity that holds a chest at the position
+// passed. The chest has an inventory of 27 slots.
+func NewChestMinecart(pos mgl64.Vec3) *Ent {
+	return Config{Behaviour: chestMinecartConf.New()}.New(ChestMinecartType{}, pos)
+}
+
+// NewHopperMinecart creates a minecart entity that holds a hopper at the
+// position passed. Hopper minecarts collect the item entities that they pass.
+func NewHopperMinecart(pos mgl64.Vec3) *Ent {
+	return Config{Behaviour: hopperMinecartConf.New()}.New(HopperMinecartType{}, pos)
+}
+
+var (
+	minecartConf = MinecartBehaviourConfig{
+		Item:     item.Minecart{Type: item.NormalMinecart()},
+		Rideable: true,
+	}
+	chestMinecartConf = MinecartBehaviourConfig{
+		Item:          item.Minecart{Type: item.ChestMinecart()},
+		ContainerSize: 27,
+	}
+	hopperMinecartConf = MinecartBehaviourConfig{
+		Item:          item.Minecart{Type: item.HopperMinecart()},
+		ContainerSize: 5,
+		CollectItems:  true,
+	}
+)
+
+// minecartBBox is the bounding box of all minecarts.
+var minecartBBox = cube.Box(-0.49, 0, -0.49, 0.49, 0.7, 0.49)
+
+// MinecartType is a world.EntityType implementation for Minecart.
+type MinecartType struct{}
+
+func (MinecartType) EncodeEntity() string        { return "minecraft:minecart" }
+func (MinecartType) BBox(world.Entity) cube.BBox { return minecartBBox }
+
+func (MinecartType) DecodeNBT(m map[string]any) world.Entity {
+	return decodeMinecart(NewMinecart(nbtconv.Vec3(m, "Pos")), m)
+}
+
+func (MinecartType) EncodeNBT(e world.Entity) map[string]any {
+	return encodeMinecart(e.(*Ent))
+}
+
+// ChestMinecartType is a world.EntityType implementation for ChestMinecart.
+type ChestMinecartType struct{}
+
+func (ChestMinecartType) EncodeEntity() string        { return "minecraft:chest_minecart" }
+func (ChestMinecartType) BBox(world.Entity) cube.BBox { return minecartBBox }
+
+func (ChestMinecartType) DecodeNBT(m map[string]any) world.Entity {
+	return decodeMinecart(NewChestMinecart(nbtconv.Vec3(m, "Pos")), m)
+}
+
+func (ChestMinecartType) EncodeNBT(e world.Entity) map[string]any {
+	return encodeMinecart(e.(*Ent))
+}
+
+// HopperMinecartType is a world.EntityType implementation for HopperMinecart.
+type HopperMinecartType struct{}
+
+func (HopperMinecartType) EncodeEntity() string        { return "minecraft:hopper_minecart" }
+func (HopperMinecartType) BBox(world.Entity) cube.BBox { return minecartBBox }
+
+func (HopperMinecartType) DecodeNBT(m map[string]any) world.Entity {
+	return decodeMinecart(NewHopperMinecart(nbtconv.Vec3(m, "Pos")), m)
+}
+
+func (HopperMinecartType) EncodeNBT(e world.Entity) map[string]any {
+	return encodeMinecart(e.(*Ent))
+}
+
+// decodeMinecart decodes the properties shared by all minecarts from the map
+// passed into the minecart entity passed.
+func decodeMinecart(e *Ent, m map[string]any) *Ent {
+	e.vel = nbtconv.Vec3(m, "Motion")
+	e.rot = nbtconv.Rotation(m)
+	if inv, ok := e.Behaviour().(*MinecartBehaviour).Inventory(); ok {
+		nbtconv.InvFromNBT(inv, nbtconv.Slice(m, "Items"))
+	}
+	return e
+}
+
+// encodeMinecart encodes the properties shared by all minecarts of the
+// minecart entity passed into a map.
+func encodeMinecart(e *Ent) map[string]any {
+	yaw, pitch := e.Rotation().Elem()
+	m := map[string]any{
+		"Pos":    nbtconv.Vec3ToFloat32Slice(e.Position()),
+		"Motion": nbtconv.Vec3ToFloat32Slice(e.Velocity()),
+		"Yaw":    float32(yaw),
+		"Pitch":  float32(pitch),
+	}
+	if inv, ok := e.Behaviour().(*MinecartBehaviour).Inventory(); ok {
+		m["Items"] = nbtconv.InvToNBT(inv)
+	}
+	return m
+}
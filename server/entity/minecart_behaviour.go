@@ -0,0 +1,400 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"sync"
+)
+
+// MinecartBehaviourConfig holds optional parameters for a MinecartBehaviour.
+type MinecartBehaviourConfig struct {
+	// Item is the item dropped when the minecart is broken.
+	Item world.Item
+	// Rideable specifies if the minecart may be ridden by a Rider.
+	Rideable bool
+	// ContainerSize is the amount of slots in the inventory of the minecart.
+	// If ContainerSize is 0, the minecart has no inventory.
+	ContainerSize int
+	// CollectItems specifies if the minecart collects item entities that it
+	// passes into its inventory, like a hopper.
+	CollectItems bool
+}
+
+// New creates a MinecartBehaviour using the parameters in conf.
+func (conf MinecartBehaviourConfig) New() *MinecartBehaviour {
+	m := &MinecartBehaviour{conf: conf, mc: &MovementComputer{
+		Gravity: 0.04,
+		Drag:    0.05,
+	}}
+	if conf.ContainerSize > 0 {
+		m.inv = inventory.New(conf.ContainerSize, nil)
+	}
+	return m
+}
+
+// MinecartBehaviour implements the behaviour of minecarts. Minecarts follow
+// the rails that they are placed on, gaining speed when going down slopes and
+// over powered rails.
+type MinecartBehaviour struct {
+	conf MinecartBehaviourConfig
+	mc   *MovementComputer
+	inv  *inventory.Inventory
+
+	mu    sync.Mutex
+	rider Rider
+
+	damage   float64
+	disabled bool
+	cooldown int
+}
+
+// Inventory returns the inventory of the minecart. False is returned if the
+// minecart has no inventory.
+func (m *MinecartBehaviour) Inventory() (*inventory.Inventory, bool) {
+	return m.inv, m.inv != nil
+}
+
+// Rider returns the Rider currently riding the minecart. False is returned if
+// no Rider is riding the minecart.
+func (m *MinecartBehaviour) Rider() (Rider, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rider, m.rider != nil
+}
+
+// AddRider makes the Rider passed ride the minecart. False is returned if the
+// minecart cannot be ridden or if another Rider is already riding it.
+func (m *MinecartBehaviour) AddRider(r Rider) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.conf.Rideable || (m.rider != nil && m.rider != r) {
+		return false
+	}
+	m.rider = r
+	return true
+}
+
+// RemoveRider removes the Rider passed from the minecart, if it was riding
+// it.
+func (m *MinecartBehaviour) RemoveRider(r Rider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rider == r {
+		m.rider = nil
+	}
+}
+
+// Hit damages the minecart after it was hit by the attacker passed. Once the
+// minecart has taken enough damage, or if it was hit by an attacker in a game
+// mode with a creative inventory, it breaks.
+func (m *MinecartBehaviour) Hit(e *Ent, attacker world.Entity, dmg float64) {
+	for _, v := range e.World().Viewers(e.Position()) {
+		v.ViewEntityAction(e, HurtAction{})
+	}
+	if g, ok := attacker.(interface{ GameMode() world.GameMode }); ok && g.GameMode().CreativeInventory() {
+		m.destroy(e, false)
+		return
+	}
+	if m.damage += math.Max(dmg, 1) * 10; m.damage > 40 {
+		m.destroy(e, true)
+	}
+}
+
+// Explode breaks the minecart.
+func (m *MinecartBehaviour) Explode(e *Ent, _ mgl64.Vec3, _ float64, _ block.ExplosionConfig) {
+	m.destroy(e, true)
+}
+
+// destroy breaks the minecart, ejecting its Rider and dropping the contents
+// of its inventory. If drop is true, the minecart itself is dropped as an
+// item too.
+func (m *MinecartBehaviour) destroy(e *Ent, drop bool) {
+	if r, ok := m.Rider(); ok {
+		r.Dismount()
+	}
+	w, pos := e.World(), e.Position()
+	if m.inv != nil {
+		for _, it := range m.inv.Clear() {
+			w.AddEntity(NewItem(it, pos))
+		}
+	}
+	if drop && w.BoolGameRule(world.GameRuleDoEntityDrops) {
+		w.AddEntity(NewItem(item.NewStack(m.conf.Item, 1), pos))
+	}
+	_ = e.Close()
+}
+
+// Tick moves the minecart along the rail that it is on, or lets it move freely
+// if it is not on a rail.
+func (m *MinecartBehaviour) Tick(e *Ent) *Movement {
+	w := e.World()
+	if m.damage > 0 {
+		m.damage--
+	}
+
+	e.mu.Lock()
+	pos, vel, rot := e.pos, e.vel, e.rot
+	e.mu.Unlock()
+
+	railPos := cube.PosFromVec3(pos)
+	if _, ok := w.Block(railPos.Side(cube.FaceDown)).(block.MinecartRail); ok {
+		railPos = railPos.Side(cube.FaceDown)
+	}
+
+	var mv *Movement
+	if r, ok := w.Block(railPos).(block.MinecartRail); ok {
+		vel[1] -= m.mc.Gravity
+		newPos, newVel := m.moveAlongRail(e, w, railPos, r, pos, vel)
+		mv = &Movement{v: w.Viewers(pos), e: e, pos: newPos, vel: newVel, dpos: newPos.Sub(pos), dvel: newVel.Sub(vel), onGround: true}
+
+		if a, ok := r.(block.ActivatorRail); ok {
+			m.activate(a.Powered)
+		}
+		if inside, ok := r.(block.EntityInsider); ok {
+			inside.EntityInside(railPos, w, e)
+		}
+	} else {
+		vel[0], vel[2] = mgl64.Clamp(vel[0], -minecartMaxSpeed, minecartMaxSpeed), mgl64.Clamp(vel[2], -minecartMaxSpeed, minecartMaxSpeed)
+		mv = m.mc.TickMovement(e, pos, vel, rot)
+	}
+	if horizontal := math.Hypot(mv.vel[0], mv.vel[2]); horizontal > 0.001 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-mv.vel[0], mv.vel[2])), 0}
+	}
+	mv.rot = rot
+
+	e.mu.Lock()
+	e.pos, e.vel, e.rot = mv.pos, mv.vel, mv.rot
+	e.mu.Unlock()
+
+	if m.conf.CollectItems {
+		m.collectItems(e, w)
+	}
+	return mv
+}
+
+// minecartMaxSpeed is the maximum speed in blocks/tick that a minecart moves
+// with along the X or Z axis.
+const minecartMaxSpeed = 0.4
+
+// moveAlongRail moves the minecart at the position passed along the rail
+// passed. The position and velocity of the minecart after the movement are
+// returned.
+func (m *MinecartBehaviour) moveAlongRail(e *Ent, w *world.World, railPos cube.Pos, r block.MinecartRail, pos, vel mgl64.Vec3) (mgl64.Vec3, mgl64.Vec3) {
+	before, onRailBefore := minecartRailPosition(w, pos)
+	shape, y := r.RailShape(), float64(railPos.Y())
+
+	powered, braking := false, false
+	if p, ok := r.(block.PoweredRail); ok {
+		powered, braking = p.Powered, !p.Powered
+	}
+
+	// Minecarts on ascending rails gain speed towards the lower end of the
+	// rail. The minecart is temporarily moved to the top of the slope, so that
+	// it does not collide with the block on the upper end of the rail.
+	const slopeSpeed = 0.0078125
+	switch shape {
+	case block.AscendingEastRail():
+		vel[0], y = vel[0]-slopeSpeed, y+1
+	case block.AscendingWestRail():
+		vel[0], y = vel[0]+slopeSpeed, y+1
+	case block.AscendingNorthRail():
+		vel[2], y = vel[2]+slopeSpeed, y+1
+	case block.AscendingSouthRail():
+		vel[2], y = vel[2]-slopeSpeed, y+1
+	}
+
+	// The velocity of the minecart is aligned with the direction of the rail.
+	exits := shape.Exits()
+	dx, dz := float64(exits[1].X()-exits[0].X()), float64(exits[1].Z()-exits[0].Z())
+	length := math.Hypot(dx, dz)
+	if vel[0]*dx+vel[2]*dz < 0 {
+		dx, dz = -dx, -dz
+	}
+	speed := math.Min(2, math.Hypot(vel[0], vel[2]))
+	vel = mgl64.Vec3{speed * dx / length, vel[1], speed * dz / length}
+
+	if braking {
+		if math.Hypot(vel[0], vel[2]) < 0.03 {
+			vel = mgl64.Vec3{}
+		} else {
+			vel = mgl64.Vec3{vel[0] * 0.5, 0, vel[2] * 0.5}
+		}
+	}
+
+	// Snap the minecart onto the rail and move it.
+	start := railPos.Vec3Middle().Add(exits[0].Vec3().Mul(0.5))
+	end := railPos.Vec3Middle().Add(exits[1].Vec3().Mul(0.5))
+	snapped := onRail(pos, railPos, start, end)
+	pos = mgl64.Vec3{snapped[0], y, snapped[2]}
+
+	multiplier := 1.0
+	if _, ok := m.Rider(); ok {
+		multiplier = 0.75
+	}
+	delta := mgl64.Vec3{
+		mgl64.Clamp(vel[0]*multiplier, -minecartMaxSpeed, minecartMaxSpeed),
+		0,
+		mgl64.Clamp(vel[2]*multiplier, -minecartMaxSpeed, minecartMaxSpeed),
+	}
+	moved, _ := m.mc.checkCollision(e, pos, delta)
+	if moved[0] != delta[0] {
+		vel[0] = 0
+	}
+	if moved[2] != delta[2] {
+		vel[2] = 0
+	}
+	pos = pos.Add(moved)
+
+	// Move the minecart up or down if it left the rail through the lower end
+	// of an ascending rail.
+	for _, exit := range exits {
+		if exit.Y() != 0 && int(math.Floor(pos[0]))-railPos.X() == exit.X() && int(math.Floor(pos[2]))-railPos.Z() == exit.Z() {
+			pos[1] += float64(exit.Y())
+			break
+		}
+	}
+
+	if _, ok := m.Rider(); ok {
+		vel = mgl64.Vec3{vel[0] * 0.997, 0, vel[2] * 0.997}
+	} else {
+		vel = mgl64.Vec3{vel[0] * 0.96, 0, vel[2] * 0.96}
+	}
+
+	// Convert the height difference of the movement into speed, so that the
+	// minecart speeds up when going down and slows down when going up.
+	if after, ok := minecartRailPosition(w, pos); ok && onRailBefore {
+		dy := (before[1] - after[1]) * 0.05
+		if horizontal := math.Hypot(vel[0], vel[2]); horizontal > 0 {
+			vel[0], vel[2] = vel[0]*(horizontal+dy)/horizontal, vel[2]*(horizontal+dy)/horizontal
+		}
+		pos[1] = after[1]
+	}
+	if x, z := int(math.Floor(pos[0])), int(math.Floor(pos[2])); x != railPos.X() || z != railPos.Z() {
+		horizontal := math.Hypot(vel[0], vel[2])
+		vel[0], vel[2] = horizontal*float64(x-railPos.X()), horizontal*float64(z-railPos.Z())
+	}
+
+	if powered {
+		if horizontal := math.Hypot(vel[0], vel[2]); horizontal > 0.01 {
+			vel[0], vel[2] = vel[0]+vel[0]/horizontal*0.06, vel[2]+vel[2]/horizontal*0.06
+		} else if shape == block.EastWestRail() {
+			// A minecart standing still on a powered rail is pushed away
+			// from a solid block next to it.
+			if solidAt(w, railPos.Side(cube.FaceWest)) {
+				vel[0] = 0.02
+			} else if solidAt(w, railPos.Side(cube.FaceEast)) {
+				vel[0] = -0.02
+			}
+		} else if shape == block.NorthSouthRail() {
+			if solidAt(w, railPos.Side(cube.FaceNorth)) {
+				vel[2] = 0.02
+			} else if solidAt(w, railPos.Side(cube.FaceSouth)) {
+				vel[2] = -0.02
+			}
+		}
+	}
+	return pos, vel
+}
+
+// activate is called when the minecart passes an activator rail. If the rail
+// is powered, the Rider of the minecart is ejected and a minecart collecting
+// items stops doing so until it passes an unpowered activator rail.
+func (m *MinecartBehaviour) activate(powered bool) {
+	m.disabled = powered
+	if !powered {
+		return
+	}
+	if r, ok := m.Rider(); ok {
+		r.Dismount()
+	}
+}
+
+// collectItems collects item entities near the minecart into its inventory.
+// After collecting an item, the minecart waits 4 ticks before collecting
+// another one.
+func (m *MinecartBehaviour) collectItems(e *Ent, w *world.World) {
+	if m.disabled {
+		return
+	}
+	if m.cooldown > 0 {
+		m.cooldown--
+		return
+	}
+	box := e.Type().BBox(e).Translate(e.Position()).GrowVec3(mgl64.Vec3{0.25, 0, 0.25})
+	for _, other := range w.EntitiesWithin(box.Grow(2), nil) {
+		it, ok := other.(*Ent)
+		if !ok || !it.Type().BBox(it).Translate(it.Position()).IntersectsWith(box) {
+			continue
+		}
+		b, ok := it.Behaviour().(*ItemBehaviour)
+		if !ok {
+			continue
+		}
+		n, _ := m.inv.AddItem(b.Item())
+		if n == 0 {
+			continue
+		}
+		if n < b.Item().Count() {
+			w.AddEntity(NewItem(b.Item().Grow(-n), it.Position()))
+		}
+		_ = it.Close()
+		m.cooldown = 4
+		return
+	}
+}
+
+// minecartRailPosition returns the position on the rail at or directly below
+// the position passed that is closest to the position passed. False is
+// returned if there is no rail at the position.
+func minecartRailPosition(w *world.World, pos mgl64.Vec3) (mgl64.Vec3, bool) {
+	railPos := cube.PosFromVec3(pos)
+	if _, ok := w.Block(railPos.Side(cube.FaceDown)).(block.MinecartRail); ok {
+		railPos = railPos.Side(cube.FaceDown)
+	}
+	r, ok := w.Block(railPos).(block.MinecartRail)
+	if !ok {
+		return mgl64.Vec3{}, false
+	}
+	exits := r.RailShape().Exits()
+	base := railPos.Vec3Middle().Add(mgl64.Vec3{0, 0.0625})
+	start, end := base.Add(exits[0].Vec3().Mul(0.5)), base.Add(exits[1].Vec3().Mul(0.5))
+
+	p := onRail(pos, railPos, start, end)
+	if dy := end[1] - start[1]; dy < 0 {
+		p[1]++
+	} else if dy > 0 {
+		p[1] += 0.5
+	}
+	return p, true
+}
+
+// onRail projects the position passed onto the line between the start and
+// end of the rail at railPos. The Y coordinate of the line is doubled in
+// steepness, as the ends of an ascending rail are only half a block apart.
+func onRail(pos mgl64.Vec3, railPos cube.Pos, start, end mgl64.Vec3) mgl64.Vec3 {
+	d := end.Sub(start)
+	d[1] *= 2
+
+	var t float64
+	switch {
+	case d[0] == 0:
+		t = pos[2] - float64(railPos.Z())
+	case d[2] == 0:
+		t = pos[0] - float64(railPos.X())
+	default:
+		t = ((pos[0]-start[0])*d[0] + (pos[2]-start[2])*d[2]) * 2
+	}
+	return start.Add(d.Mul(t))
+}
+
+// solidAt checks if the block at the position passed is a fully solid block.
+func solidAt(w *world.World, pos cube.Pos) bool {
+	_, ok := w.Block(pos).Model().(model.Solid)
+	return ok
+}
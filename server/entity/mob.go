@@ -0,0 +1,341 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// Mob is a Living entity that builds on Ent. It has health, can wear armour,
+// hold items and have effects applied to it. Mobs are the base of all
+// creatures, which implement their specific behaviour through
+// MobBehaviourConfig. Mob implements the Living interface by propagating calls
+// to its underlying MobBehaviour.
+type Mob struct {
+	Ent
+}
+
+// MobBehaviour returns the MobBehaviour of the Mob.
+func (m *Mob) MobBehaviour() *MobBehaviour {
+	return m.conf.Behaviour.(*MobBehaviour)
+}
+
+// Health returns the current health of the Mob.
+func (m *Mob) Health() float64 {
+	return m.MobBehaviour().health.Health()
+}
+
+// MaxHealth returns the maximum health of the Mob.
+func (m *Mob) MaxHealth() float64 {
+	return m.MobBehaviour().health.MaxHealth()
+}
+
+// SetMaxHealth sets the base maximum health of the Mob. If the current health
+// of the Mob is higher than the new maximum health, the health is set to the
+// new maximum.
+func (m *Mob) SetMaxHealth(v float64) {
+	m.MobBehaviour().attributes.SetBase(attribute.MaxHealth(), v)
+}
+
+// Dead checks if the Mob is considered dead. True is returned if the health of
+// the Mob is equal to or lower than 0.
+func (m *Mob) Dead() bool {
+	return m.Health() <= mgl64.Epsilon
+}
+
+// AttackImmune checks if the Mob is currently immune to entity attacks,
+// meaning it was recently attacked.
+func (m *Mob) AttackImmune() bool {
+	return m.MobBehaviour().AttackImmunity() > 0
+}
+
+// Hurt hurts the Mob for a given amount of damage. The source passed
+// represents the cause of the damage. If the final damage exceeds the health
+// that the Mob currently has, the Mob is killed, dropping its loot and
+// experience. Hurt returns the final amount of damage dealt to the Mob and
+// whether the Mob was vulnerable to the damage at all.
+func (m *Mob) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	return m.MobBehaviour().Hurt(m, dmg, src)
+}
+
+// Heal heals the Mob for a given amount of health. If the health added to the
+// original health exceeds the Mob's max health, Heal will not add the full
+// amount. If the health passed is negative or the Mob is dead, Heal will not
+// do anything.
+func (m *Mob) Heal(health float64, _ world.HealingSource) {
+	if m.Dead() || health < 0 {
+		return
+	}
+	m.MobBehaviour().health.AddHealth(health)
+}
+
+// KnockBack knocks the Mob back with a given force and height. A source is
+// passed which indicates the source of the velocity, typically the position of
+// an attacking entity. The knock back is reduced by the armour that the Mob is
+// wearing.
+func (m *Mob) KnockBack(src mgl64.Vec3, force, height float64) {
+	if m.Dead() {
+		return
+	}
+	m.MobBehaviour().KnockBack(m, src, force, height)
+}
+
+// Explode hurts the Mob and knocks it back, depending on its distance to the
+// explosion.
+func (m *Mob) Explode(src mgl64.Vec3, impact float64, conf block.ExplosionConfig) {
+	diff := m.Position().Sub(src)
+	m.Hurt(math.Floor((impact*impact+impact)*3.5*conf.Size+1), ExplosionDamageSource{})
+	m.KnockBack(src, impact, diff[1]/diff.Len()*impact)
+}
+
+// AddEffect adds an effect.Effect to the Mob. If the effect is instant, it is
+// applied immediately. If not, the effect is applied every time the Mob is
+// ticked. AddEffect will overwrite any effects present if the level of the
+// effect is higher than the existing one, or if the effects' levels are equal
+// and the new effect has a longer duration.
+func (m *Mob) AddEffect(e effect.Effect) {
+	m.MobBehaviour().effects.Add(e, m)
+	m.updateState()
+}
+
+// RemoveEffect removes any effect that might currently be active on the Mob.
+func (m *Mob) RemoveEffect(e effect.Type) {
+	m.MobBehaviour().effects.Remove(e, m)
+	m.updateState()
+}
+
+// Effect returns the effect instance and true if the Mob has the effect. If
+// not found, it will return an empty effect instance and false.
+func (m *Mob) Effect(e effect.Type) (effect.Effect, bool) {
+	return m.MobBehaviour().effects.Effect(e)
+}
+
+// Effects returns any effect currently applied to the Mob. The returned
+// effects are guaranteed not to have expired when returned.
+func (m *Mob) Effects() []effect.Effect {
+	return m.MobBehaviour().effects.Effects()
+}
+
+// Speed returns the current movement speed of the Mob in blocks/tick.
+func (m *Mob) Speed() float64 {
+	return m.MobBehaviour().attributes.Value(attribute.MovementSpeed())
+}
+
+// SetSpeed sets the base movement speed of the Mob in blocks/tick. Modifiers,
+// such as that of the Speed effect, are applied on top of it.
+func (m *Mob) SetSpeed(v float64) {
+	m.MobBehaviour().attributes.SetBase(attribute.MovementSpeed(), v)
+}
+
+// Attributes returns the attribute.Manager holding the attributes of the Mob,
+// such as its movement speed, attack damage and follow range.
+func (m *Mob) Attributes() *attribute.Manager {
+	return m.MobBehaviour().attributes
+}
+
+// Armour returns the armour inventory of the Mob. Changes to the inventory are
+// shown to viewers of the Mob.
+func (m *Mob) Armour() *inventory.Armour {
+	return m.MobBehaviour().armour
+}
+
+// HeldItems returns the items currently held in the main hand and off-hand of
+// the Mob.
+func (m *Mob) HeldItems() (mainHand, offHand item.Stack) {
+	return m.MobBehaviour().HeldItems()
+}
+
+// SetHeldItems sets the items held in the main hand and off-hand of the Mob
+// and shows them to viewers of the Mob.
+func (m *Mob) SetHeldItems(mainHand, offHand item.Stack) {
+	m.MobBehaviour().SetHeldItems(mainHand, offHand)
+	for _, v := range m.World().Viewers(m.Position()) {
+		v.ViewEntityItems(m)
+	}
+}
+
+// Hostile returns true if the Mob is hostile towards players, as specified in
+// MobBehaviourConfig.Hostile.
+func (m *Mob) Hostile() bool {
+	return m.MobBehaviour().conf.Hostile
+}
+
+// FallDistance returns the distance that the Mob has fallen since it was last
+// on the ground.
+func (m *Mob) FallDistance() float64 {
+	return m.MobBehaviour().FallDistance()
+}
+
+// ResetFallDistance resets the fall distance of the Mob.
+func (m *Mob) ResetFallDistance() {
+	m.MobBehaviour().ResetFallDistance()
+}
+
+// OnGround checks if the Mob is currently considered to be on the ground.
+func (m *Mob) OnGround() bool {
+	return m.MobBehaviour().mc.OnGround()
+}
+
+// SetRotation changes the rotation of the Mob. The new rotation is shown to
+// viewers the next time the Mob moves.
+func (m *Mob) SetRotation(rot cube.Rotation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rot = rot
+}
+
+// updateState shows the current state of the Mob, such as its effects, to all
+// of its viewers.
+func (m *Mob) updateState() {
+	for _, v := range m.World().Viewers(m.Position()) {
+		v.ViewEntityState(m)
+	}
+}
+
+// damageItem damages the item stack passed with the damage passed and returns
+// the new stack. If the item broke, a breaking sound is played.
+func (m *Mob) damageItem(s item.Stack, d int) item.Stack {
+	if d == 0 || s.MaxDurability() == -1 {
+		return s
+	}
+	if e, ok := s.Enchantment(enchantment.Unbreaking{}); ok {
+		d = (enchantment.Unbreaking{}).Reduce(s.Item(), e.Level(), d)
+	}
+	if s = s.Damage(d); s.Empty() {
+		m.World().PlaySound(m.Position(), sound.ItemBreak{})
+	}
+	return s
+}
+
+// DecodeMobNBT decodes the properties shared by all mobs, such as health,
+// equipment and effects, from the map passed into the Mob passed. It may be
+// used by implementations of world.SaveableEntityType for creatures.
+func DecodeMobNBT(m *Mob, data map[string]any) *Mob {
+	b := m.MobBehaviour()
+	m.vel = nbtconv.Vec3(data, "Motion")
+	m.rot = nbtconv.Rotation(data)
+	m.name = nbtconv.String(data, "CustomName")
+	m.fireDuration = time.Duration(nbtconv.Int16(data, "Fire")) * time.Second / 20
+	if _, ok := data["Health"]; ok {
+		b.health.AddHealth(float64(nbtconv.Float32(data, "Health")) - b.health.Health())
+	}
+
+	if armour := nbtconv.Slice(data, "Armor"); len(armour) == 4 {
+		stacks := make([]item.Stack, 4)
+		for i, s := range armour {
+			if s, ok := s.(map[string]any); ok && len(s) > 0 {
+				stacks[i] = nbtconv.Item(s, nil)
+			}
+		}
+		b.armour.Set(stacks[0], stacks[1], stacks[2], stacks[3])
+	}
+	b.SetHeldItems(decodeMobHand(data, "Mainhand"), decodeMobHand(data, "Offhand"))
+
+	for _, e := range nbtconv.Slice(data, "ActiveEffects") {
+		if e, ok := e.(map[string]any); ok {
+			if eff, ok := decodeMobEffect(e); ok {
+				b.effects.Add(eff, m)
+			}
+		}
+	}
+	return m
+}
+
+// EncodeMobNBT encodes the properties shared by all mobs, such as health,
+// equipment and effects, of the Mob passed into a map. It may be used by
+// implementations of world.SaveableEntityType for creatures.
+func EncodeMobNBT(m *Mob) map[string]any {
+	b := m.MobBehaviour()
+	yaw, pitch := m.Rotation().Elem()
+	mainHand, offHand := b.HeldItems()
+
+	armour := make([]any, 0, 4)
+	for _, s := range b.armour.Slots() {
+		armour = append(armour, encodeMobItem(s))
+	}
+	effects := make([]any, 0, len(b.effects.Effects()))
+	for _, e := range b.effects.Effects() {
+		if data, ok := encodeMobEffect(e); ok {
+			effects = append(effects, data)
+		}
+	}
+	return map[string]any{
+		"Pos":           nbtconv.Vec3ToFloat32Slice(m.Position()),
+		"Motion":        nbtconv.Vec3ToFloat32Slice(m.Velocity()),
+		"Yaw":           float32(yaw),
+		"Pitch":         float32(pitch),
+		"CustomName":    m.NameTag(),
+		"Fire":          int16(m.OnFireDuration() * 20 / time.Second),
+		"Health":        float32(m.Health()),
+		"Armor":         armour,
+		"Mainhand":      []any{encodeMobItem(mainHand)},
+		"Offhand":       []any{encodeMobItem(offHand)},
+		"ActiveEffects": effects,
+	}
+}
+
+// encodeMobItem encodes the item stack passed into a map. An empty map is
+// returned if the stack is empty.
+func encodeMobItem(s item.Stack) map[string]any {
+	if s.Empty() {
+		return map[string]any{}
+	}
+	return nbtconv.WriteItem(s, true)
+}
+
+// decodeMobHand decodes the item held in the hand stored under the key passed.
+func decodeMobHand(data map[string]any, k string) item.Stack {
+	if s := nbtconv.Slice(data, k); len(s) > 0 {
+		if s, ok := s[0].(map[string]any); ok && len(s) > 0 {
+			return nbtconv.Item(s, nil)
+		}
+	}
+	return item.Stack{}
+}
+
+// decodeMobEffect decodes an effect.Effect from the map passed. False is
+// returned if the effect type is unknown or if the effect is not lasting.
+func decodeMobEffect(data map[string]any) (effect.Effect, bool) {
+	t, ok := effect.ByID(int(nbtconv.Uint8(data, "Id")))
+	if !ok {
+		return effect.Effect{}, false
+	}
+	lt, ok := t.(effect.LastingType)
+	lvl, dur := int(nbtconv.Uint8(data, "Amplifier"))+1, time.Duration(nbtconv.Int32(data, "Duration"))*time.Second/20
+	if !ok || dur <= 0 {
+		return effect.Effect{}, false
+	}
+	if nbtconv.Bool(data, "Ambient") {
+		return effect.NewAmbient(lt, lvl, dur), true
+	}
+	if !nbtconv.Bool(data, "ShowParticles") {
+		return effect.New(lt, lvl, dur).WithoutParticles(), true
+	}
+	return effect.New(lt, lvl, dur), true
+}
+
+// encodeMobEffect encodes the effect.Effect passed into a map. False is
+// returned if the effect type has no ID.
+func encodeMobEffect(e effect.Effect) (map[string]any, bool) {
+	id, ok := effect.ID(e.Type())
+	if !ok {
+		return nil, false
+	}
+	return map[string]any{
+		"Id":            uint8(id),
+		"Amplifier":     uint8(e.Level() - 1),
+		"Duration":      int32(e.Duration() * 20 / time.Second),
+		"Ambient":       boolByte(e.Ambient()),
+		"ShowParticles": boolByte(!e.ParticlesHidden()),
+	}, true
+}
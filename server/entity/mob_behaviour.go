@@ -0,0 +1,436 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MobBehaviourConfig holds optional parameters for a MobBehaviour.
+type MobBehaviourConfig struct {
+	// MaxHealth is the base maximum health of the mob. If MaxHealth is 0 or
+	// lower, a maximum health of 20 is used.
+	MaxHealth float64
+	// Speed is the base movement speed of the mob in blocks/tick. Other
+	// attributes of the mob may be changed through Mob.Attributes.
+	Speed float64
+	// Gravity is the amount of Y velocity subtracted every tick. Most mobs
+	// have a gravity of 0.08.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick. Most mobs have a drag of 0.02.
+	Drag float64
+	// StepHeight is the maximum height of a block that the mob can walk onto
+	// without jumping. Most mobs have a step height of 0.6.
+	StepHeight float64
+	// Hostile specifies if the mob is hostile towards players. Players are
+	// unable to sleep in a bed while a hostile mob is close to it.
+	Hostile bool
+	// Drops returns the items dropped by the mob when it is killed by the
+	// world.DamageSource passed. If Drops is nil, the mob only drops its
+	// equipment.
+	Drops func(src world.DamageSource) []item.Stack
+	// Experience is the amount of experience dropped by the mob when it is
+	// killed by an entity that is able to collect experience, such as a
+	// player.
+	Experience int
+	// EquipmentDropChance is the chance, between 0 and 1, that each item held
+	// or worn by the mob is dropped when it dies.
+	EquipmentDropChance float64
+	// Tick is called every tick before the mob moves. It may be used to
+	// implement the AI of the mob, for example by changing its velocity. Tick
+	// may be nil.
+	Tick func(m *Mob)
+}
+
+// New creates a Mob with the world.EntityType and position passed, using a
+// MobBehaviour with the parameters in conf.
+func (conf MobBehaviourConfig) New(t world.EntityType, pos mgl64.Vec3) *Mob {
+	if conf.MaxHealth <= 0 {
+		conf.MaxHealth = 20
+	}
+	b := &MobBehaviour{
+		conf:    conf,
+		mc:      &MovementComputer{Gravity: conf.Gravity, Drag: conf.Drag, StepHeight: conf.StepHeight},
+		health:  NewHealthManager(conf.MaxHealth, conf.MaxHealth),
+		effects: NewEffectManager(),
+	}
+	b.attributes = attribute.NewManager(func(a attribute.Attribute, _, after float64) {
+		if a == attribute.MaxHealth() {
+			b.health.SetMaxHealth(after)
+		}
+	})
+	b.attributes.SetBase(attribute.MaxHealth(), conf.MaxHealth)
+	b.attributes.SetBase(attribute.MovementSpeed(), conf.Speed)
+
+	m := &Mob{Ent: Ent{t: t, pos: pos, conf: Config{Behaviour: b}}}
+	m.self = m
+	b.armour = inventory.NewArmour(func(int, item.Stack, item.Stack) {
+		b.updateEquipmentModifiers()
+		for _, v := range m.World().Viewers(m.Position()) {
+			v.ViewEntityArmour(m)
+		}
+	})
+	return m
+}
+
+// MobBehaviour implements the behaviour shared by all mobs. It manages the
+// health, equipment and effects of a Mob, handles the damage and knock back
+// that it takes and drops its loot and experience when it dies.
+type MobBehaviour struct {
+	conf       MobBehaviourConfig
+	mc         *MovementComputer
+	health     *HealthManager
+	effects    *EffectManager
+	attributes *attribute.Manager
+	armour     *inventory.Armour
+
+	mu                 sync.Mutex
+	mainHand, offHand  item.Stack
+	equipmentModifiers []attribute.Modifier
+	immunity           time.Duration
+	fallDistance       float64
+	deathTicks         int
+}
+
+// Attributes returns the attribute.Manager holding the attributes of the mob,
+// such as its movement speed and attack damage.
+func (b *MobBehaviour) Attributes() *attribute.Manager {
+	return b.attributes
+}
+
+// HeldItems returns the items held in the main hand and off-hand of the mob.
+func (b *MobBehaviour) HeldItems() (mainHand, offHand item.Stack) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.mainHand, b.offHand
+}
+
+// SetHeldItems sets the items held in the main hand and off-hand of the mob.
+func (b *MobBehaviour) SetHeldItems(mainHand, offHand item.Stack) {
+	b.mu.Lock()
+	b.mainHand, b.offHand = mainHand, offHand
+	b.mu.Unlock()
+	b.updateEquipmentModifiers()
+}
+
+// AttackImmunity returns the duration that the mob is still immune to entity
+// attacks.
+func (b *MobBehaviour) AttackImmunity() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.immunity
+}
+
+// SetAttackImmunity sets the duration that the mob is immune to entity
+// attacks.
+func (b *MobBehaviour) SetAttackImmunity(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.immunity = d
+}
+
+// FallDistance returns the distance that the mob has fallen since it was last
+// on the ground.
+func (b *MobBehaviour) FallDistance() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.fallDistance
+}
+
+// ResetFallDistance resets the fall distance of the mob.
+func (b *MobBehaviour) ResetFallDistance() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fallDistance = 0
+}
+
+// Tick ticks the mob, applying its effects, fire damage, AI and movement. Once
+// the mob has died, it is removed from the world after its death animation
+// finishes.
+func (b *MobBehaviour) Tick(e *Ent) *Movement {
+	m := e.self.(*Mob)
+	if m.Dead() {
+		b.mu.Lock()
+		b.deathTicks++
+		removed := b.deathTicks >= 20
+		b.mu.Unlock()
+		if removed {
+			_ = m.Close()
+		}
+		return nil
+	}
+	w := m.World()
+	b.effects.Tick(m)
+
+	b.mu.Lock()
+	if b.immunity > 0 {
+		b.immunity -= time.Second / 20
+	}
+	b.mu.Unlock()
+
+	if d := m.OnFireDuration(); d > 0 {
+		if w.RainingAt(cube.PosFromVec3(m.Position())) {
+			m.Extinguish()
+		} else if d%time.Second == 0 && !m.AttackImmune() {
+			m.Hurt(1, block.FireDamageSource{})
+		}
+	}
+	if b.conf.Tick != nil && !m.Dead() {
+		b.conf.Tick(m)
+	}
+	if m.Dead() {
+		return nil
+	}
+
+	m.mu.Lock()
+	pos, vel, rot := m.pos, m.vel, m.rot
+	m.mu.Unlock()
+
+	mv := b.mc.TickMovement(m, pos, vel, rot)
+
+	m.mu.Lock()
+	m.pos, m.vel = mv.pos, mv.vel
+	m.mu.Unlock()
+
+	b.checkEntityInsiders(m, w, mv.pos)
+	b.updateFallState(m, w, mv)
+	return mv
+}
+
+// Hurt hurts the mob for the damage passed, taking into account its armour
+// and effects. The mob is killed if its health drops to 0.
+func (b *MobBehaviour) Hurt(m *Mob, dmg float64, src world.DamageSource) (float64, bool) {
+	if _, ok := m.Effect(effect.FireResistance{}); (ok && src.Fire()) || m.Dead() || !mobDamageEnabled(m.World(), src) {
+		return 0, false
+	}
+	if dmg < 0 {
+		return 0, true
+	}
+	totalDamage := b.finalDamageFrom(m, dmg, src)
+	b.health.AddHealth(-totalDamage)
+
+	if src.ReducedByArmour() {
+		b.armour.Damage(dmg, m.damageItem)
+		var origin world.Entity
+		if s, ok := src.(AttackDamageSource); ok {
+			origin = s.Attacker
+		} else if s, ok := src.(ProjectileDamageSource); ok {
+			origin = s.Owner
+		}
+		if l, ok := origin.(Living); ok {
+			if thornsDmg := b.armour.ThornsDamage(m.damageItem); thornsDmg > 0 {
+				l.Hurt(thornsDmg, enchantment.ThornsDamageSource{Owner: m})
+			}
+		}
+	}
+	for _, v := range m.World().Viewers(m.Position()) {
+		v.ViewEntityAction(m, HurtAction{})
+	}
+	b.SetAttackImmunity(time.Second / 2)
+	if m.Dead() {
+		b.kill(m, src)
+	}
+	return totalDamage, true
+}
+
+// KnockBack knocks the mob back with the force and height passed, away from
+// the source position passed.
+func (b *MobBehaviour) KnockBack(m *Mob, src mgl64.Vec3, force, height float64) {
+	velocity := m.Position().Sub(src)
+	velocity[1] = 0
+
+	if velocity.Len() != 0 {
+		velocity = velocity.Normalize().Mul(force)
+	}
+	velocity[1] = height
+
+	m.SetVelocity(velocity.Mul(1 - b.attributes.Value(attribute.KnockBackResistance())))
+}
+
+// updateEquipmentModifiers replaces the attribute modifiers of the equipment
+// of the mob with those of the item it is currently holding and the armour it
+// is currently wearing. It is called whenever the held items or the armour of
+// the mob change.
+func (b *MobBehaviour) updateEquipmentModifiers() {
+	mainHand, _ := b.HeldItems()
+	mods := append(mainHand.AttributeModifiers(), b.armour.AttributeModifiers()...)
+
+	b.mu.Lock()
+	prev := b.equipmentModifiers
+	b.equipmentModifiers = mods
+	b.mu.Unlock()
+
+	if !slices.Equal(prev, mods) {
+		b.attributes.SwapModifiers(prev, mods)
+	}
+}
+
+// finalDamageFrom resolves the final damage received by the mob if it is hurt
+// by the source passed with the damage passed, taking into account its armour
+// and the resistance effect.
+func (b *MobBehaviour) finalDamageFrom(m *Mob, dmg float64, src world.DamageSource) float64 {
+	dmg = math.Max(dmg, 0)
+
+	dmg -= b.armour.DamageReduction(dmg, src)
+	if res, ok := m.Effect(effect.Resistance{}); ok {
+		dmg *= effect.Resistance{}.Multiplier(src, res.Level())
+	}
+	return dmg
+}
+
+// kill kills the mob, showing its death animation and dropping its loot,
+// equipment and experience if the world.GameRuleDoMobLoot game rule is
+// enabled.
+func (b *MobBehaviour) kill(m *Mob, src world.DamageSource) {
+	w, pos := m.World(), m.Position()
+	for _, v := range w.Viewers(pos) {
+		v.ViewEntityAction(m, DeathAction{})
+	}
+	for _, e := range m.Effects() {
+		m.RemoveEffect(e.Type())
+	}
+	m.Extinguish()
+	if !w.BoolGameRule(world.GameRuleDoMobLoot) {
+		return
+	}
+
+	var drops []item.Stack
+	if b.conf.Drops != nil {
+		drops = b.conf.Drops(src)
+	}
+	mainHand, offHand := b.HeldItems()
+	for _, it := range append(b.armour.Slots(), mainHand, offHand) {
+		if it.Empty() || rand.Float64() >= b.conf.EquipmentDropChance {
+			continue
+		}
+		if _, ok := it.Enchantment(enchantment.CurseOfVanishing{}); ok {
+			continue
+		}
+		drops = append(drops, it)
+	}
+	for _, it := range drops {
+		ent := NewItem(it, pos)
+		ent.SetVelocity(mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1})
+		w.AddEntity(ent)
+	}
+
+	if b.conf.Experience > 0 && killedByCollector(src) {
+		for _, orb := range NewExperienceOrbs(pos, b.conf.Experience) {
+			orb.SetVelocity(mgl64.Vec3{(rand.Float64()*0.2 - 0.1) * 2, rand.Float64() * 0.4, (rand.Float64()*0.2 - 0.1) * 2})
+			w.AddEntity(orb)
+		}
+	}
+}
+
+// updateFallState updates the fall distance of the mob using the Movement
+// passed, hurting the mob when it lands after falling too far.
+func (b *MobBehaviour) updateFallState(m *Mob, w *world.World, mv *Movement) {
+	if _, ok := w.Liquid(cube.PosFromVec3(mv.pos)); ok {
+		b.ResetFallDistance()
+		return
+	}
+	if !mv.onGround {
+		b.mu.Lock()
+		if mv.dpos[1] < 0 {
+			b.fallDistance -= mv.dpos[1]
+		} else {
+			b.fallDistance = 0
+		}
+		b.mu.Unlock()
+		return
+	}
+	if distance := b.FallDistance(); distance > 0 {
+		b.ResetFallDistance()
+		b.fall(m, w, mv.pos, distance)
+	}
+}
+
+// fall is called when the mob hits the ground after falling the distance
+// passed.
+func (b *MobBehaviour) fall(m *Mob, w *world.World, vec mgl64.Vec3, distance float64) {
+	pos := cube.PosFromVec3(vec)
+	bl := w.Block(pos)
+	if len(bl.Model().BBox(pos, w)) == 0 {
+		pos = pos.Side(cube.FaceDown)
+		bl = w.Block(pos)
+	}
+	if h, ok := bl.(block.EntityLander); ok {
+		h.EntityLand(pos, w, m, &distance)
+	}
+	dmg := distance - 3
+	if boost, ok := m.Effect(effect.JumpBoost{}); ok {
+		dmg -= float64(boost.Level())
+	}
+	if dmg < 0.5 {
+		return
+	}
+	m.Hurt(math.Ceil(dmg), FallDamageSource{})
+}
+
+// checkEntityInsiders calls EntityInside on all blocks and liquids that the
+// mob at the position passed is inside of.
+func (b *MobBehaviour) checkEntityInsiders(m *Mob, w *world.World, vec mgl64.Vec3) {
+	box := m.Type().BBox(m).Translate(vec).Grow(-0.0001)
+	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+
+	for y := low[1]; y <= high[1]; y++ {
+		for x := low[0]; x <= high[0]; x++ {
+			for z := low[2]; z <= high[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				bl := w.Block(pos)
+				if inside, ok := bl.(block.EntityInsider); ok {
+					inside.EntityInside(pos, w, m)
+					if _, liquid := bl.(world.Liquid); liquid {
+						continue
+					}
+				}
+				if l, ok := w.Liquid(pos); ok {
+					if inside, ok := l.(block.EntityInsider); ok {
+						inside.EntityInside(pos, w, m)
+					}
+				}
+			}
+		}
+	}
+}
+
+// mobDamageEnabled checks if the game rules of the world.World passed allow
+// mobs to take damage from the world.DamageSource passed.
+func mobDamageEnabled(w *world.World, src world.DamageSource) bool {
+	if src.Fire() && !w.BoolGameRule(world.GameRuleFireDamage) {
+		return false
+	}
+	switch src.(type) {
+	case FallDamageSource:
+		return w.BoolGameRule(world.GameRuleFallDamage)
+	case DrowningDamageSource:
+		return w.BoolGameRule(world.GameRuleDrowningDamage)
+	}
+	return true
+}
+
+// killedByCollector checks if the world.DamageSource passed was caused by an
+// entity that is able to collect experience, such as a player.
+func killedByCollector(src world.DamageSource) bool {
+	var killer world.Entity
+	switch s := src.(type) {
+	case AttackDamageSource:
+		killer = s.Attacker
+	case ProjectileDamageSource:
+		killer = s.Owner
+	}
+	_, ok := killer.(experienceCollector)
+	return ok
+}
@@ -12,6 +12,10 @@ import (
 type MovementComputer struct {
 	Gravity, Drag     float64
 	DragBeforeGravity bool
+	// StepHeight is the maximum height of a block that the entity can step
+	// onto while on the ground without jumping, such as a slab. If 0, the
+	// entity cannot step up blocks.
+	StepHeight float64
 
 	onGround bool
 }
@@ -69,6 +73,7 @@ func (c *MovementComputer) TickMovement(e world.Entity, pos, vel mgl64.Vec3, rot
 	velBefore := vel
 	vel = c.applyHorizontalForces(w, pos, c.applyVerticalForces(vel))
 	dPos, vel := c.checkCollision(e, pos, vel)
+	dPos, vel = c.checkBorder(w, pos, dPos, vel)
 
 	return &Movement{v: viewers, e: e,
 		pos: pos.Add(dPos), vel: vel, dpos: dPos, dvel: vel.Sub(velBefore),
@@ -116,6 +121,24 @@ func (c *MovementComputer) applyHorizontalForces(w *world.World, pos, vel mgl64.
 	return vel
 }
 
+// checkBorder prevents an entity inside the world border of the world.World from moving out of it. The entity is
+// stopped at the border, and its velocity is cancelled along the axes on which it would have crossed it. The final
+// Vec3 that the entity should move and its velocity are returned.
+func (c *MovementComputer) checkBorder(w *world.World, pos, dPos, vel mgl64.Vec3) (mgl64.Vec3, mgl64.Vec3) {
+	b := w.Border()
+	if !b.Contains(pos) || b.Contains(pos.Add(dPos)) {
+		return dPos, vel
+	}
+	clamped := b.Clamp(pos.Add(dPos)).Sub(pos)
+	if clamped[0] != dPos[0] {
+		vel[0] = 0
+	}
+	if clamped[2] != dPos[2] {
+		vel[2] = 0
+	}
+	return clamped, vel
+}
+
 // checkCollision handles the collision of the entity with blocks, adapting the velocity of the entity if it
 // happens to collide with a block.
 // The final velocity and the Vec3 that the entity should move is returned.
@@ -125,7 +148,8 @@ func (c *MovementComputer) checkCollision(e world.Entity, pos, vel mgl64.Vec3) (
 
 	// Entities only ever have a single bounding box.
 	entityBBox := e.Type().BBox(e).Translate(pos)
-	blocks := blockBBoxsAround(e, entityBBox.Extend(vel))
+	blocks := blockBBoxsAround(e, entityBBox.Extend(vel).Extend(mgl64.Vec3{0, c.StepHeight}))
+	startBBox := entityBBox
 
 	if !mgl64.FloatEqualThreshold(deltaY, 0, epsilon) {
 		// First we move the entity BBox on the Y axis.
@@ -147,6 +171,15 @@ func (c *MovementComputer) checkCollision(e world.Entity, pos, vel mgl64.Vec3) (
 			deltaZ = entityBBox.ZOffset(blockBBox, deltaZ)
 		}
 	}
+	landed := vel[1] < 0 && !mgl64.FloatEqual(deltaY, vel[1])
+	if c.StepHeight > 0 && (c.onGround || landed) && (!mgl64.FloatEqual(deltaX, vel[0]) || !mgl64.FloatEqual(deltaZ, vel[2])) {
+		// The entity collided horizontally while on the ground. Try to step up
+		// the block it collided with and use the result if the entity moves
+		// further horizontally that way.
+		if stepX, stepY, stepZ := c.step(startBBox, blocks, vel); stepX*stepX+stepZ*stepZ > deltaX*deltaX+deltaZ*deltaZ {
+			deltaX, deltaY, deltaZ = stepX, stepY, stepZ
+		}
+	}
 	if !mgl64.FloatEqual(vel[1], 0) {
 		// The Y velocity of the entity is currently not 0, meaning it is moving either up or down. We can
 		// then assume the entity is not currently on the ground.
@@ -169,6 +202,31 @@ func (c *MovementComputer) checkCollision(e world.Entity, pos, vel mgl64.Vec3) (
 	return mgl64.Vec3{deltaX, deltaY, deltaZ}, vel
 }
 
+// step attempts to move the entity BBox passed up by the StepHeight, then
+// horizontally according to vel and back down again. The resulting movement on
+// all axes is returned.
+func (c *MovementComputer) step(entityBBox cube.BBox, blocks []cube.BBox, vel mgl64.Vec3) (deltaX, deltaY, deltaZ float64) {
+	deltaX, deltaY, deltaZ = vel[0], c.StepHeight, vel[2]
+	for _, blockBBox := range blocks {
+		deltaY = entityBBox.YOffset(blockBBox, deltaY)
+	}
+	entityBBox = entityBBox.Translate(mgl64.Vec3{0, deltaY})
+	for _, blockBBox := range blocks {
+		deltaX = entityBBox.XOffset(blockBBox, deltaX)
+	}
+	entityBBox = entityBBox.Translate(mgl64.Vec3{deltaX})
+	for _, blockBBox := range blocks {
+		deltaZ = entityBBox.ZOffset(blockBBox, deltaZ)
+	}
+	entityBBox = entityBBox.Translate(mgl64.Vec3{0, 0, deltaZ})
+
+	down := -deltaY
+	for _, blockBBox := range blocks {
+		down = entityBBox.YOffset(blockBBox, down)
+	}
+	return deltaX, deltaY + down, deltaZ
+}
+
 // blockBBoxsAround returns all blocks around the entity passed, using the BBox passed to make a prediction of
 // what blocks need to have their BBox returned.
 func blockBBoxsAround(e world.Entity, box cube.BBox) []cube.BBox {
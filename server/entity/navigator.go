@@ -0,0 +1,248 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Navigator moves a Mob along a Path found by a Pathfinder. Navigator does not
+// react to block changes directly: Every tick, it checks if the next 8 points
+// of the path ahead of the Mob can still be walked and repairs the path if not,
+// so that the Mob does not walk into blocks placed in its way. Changes further
+// ahead are only noticed once the Mob gets closer. Doors opened by the Mob are
+// closed again once the Mob has passed them. A Navigator is typically ticked
+// from MobBehaviourConfig.Tick. Navigator is safe for concurrent use.
+type Navigator struct {
+	p *Pathfinder
+
+	mu     sync.Mutex
+	target mgl64.Vec3
+	path   *Path
+	index  int
+	last   mgl64.Vec3
+	stuck  int
+	// doors holds the positions of the doors opened by the Mob that have not
+	// yet been closed.
+	doors []cube.Pos
+}
+
+// NewNavigator creates a Navigator that uses the Pathfinder passed to find
+// paths.
+func NewNavigator(p *Pathfinder) *Navigator {
+	return &Navigator{p: p}
+}
+
+// MoveTo finds a path for the Mob passed to the target passed and starts
+// moving the Mob along it when Tick is called. False is returned if no path
+// that brings the Mob closer to the target could be found.
+func (n *Navigator) MoveTo(m *Mob, target mgl64.Vec3) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.target = target
+	return n.replan(m)
+}
+
+// Stop stops the Navigator from moving the Mob along its current path.
+func (n *Navigator) Stop() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.path = nil
+}
+
+// Navigating checks if the Navigator is currently moving its Mob along a path.
+func (n *Navigator) Navigating() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.path != nil
+}
+
+// Path returns the path that the Navigator is currently moving the Mob along.
+// Nil is returned if the Navigator is not navigating.
+func (n *Navigator) Path() *Path {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.path
+}
+
+// Tick moves the Mob passed one tick further along its path by changing its
+// velocity and rotation. The Mob jumps up blocks that it cannot step up, swims
+// up in water and opens doors in its way if the Pathfinder allows it. Tick
+// should be called every tick before the Mob moves, for example from
+// MobBehaviourConfig.Tick.
+func (n *Navigator) Tick(m *Mob) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.closeDoors(m)
+	if n.path == nil {
+		return
+	}
+	w, pos := m.World(), m.Position()
+	c := n.p.context(w, m.Type().BBox(m))
+	if !n.repair(m, c) {
+		return
+	}
+
+	// If the Mob barely moved for a while, it is probably stuck on something
+	// that the Pathfinder did not account for. Trying to find a new path
+	// usually gets it moving again.
+	if pos.Sub(n.last).Len() < 0.01 {
+		if n.stuck++; n.stuck >= navigatorStuckTicks && !n.replan(m) {
+			return
+		}
+	} else {
+		n.stuck = 0
+	}
+	n.last = pos
+
+	// Skip all points that the Mob has already reached.
+	for n.index < len(n.path.points) {
+		next := n.path.points[n.index].vec3()
+		if math.Hypot(next[0]-pos[0], next[2]-pos[2]) > navigatorReachDistance || math.Abs(next[1]-pos[1]) > 0.6 {
+			break
+		}
+		n.index++
+	}
+	if n.index >= len(n.path.points) {
+		// The end of the path was reached. If the path was only partial, the
+		// target might be reachable from here.
+		if n.path.complete || !n.replan(m) || n.index >= len(n.path.points) {
+			n.path = nil
+			return
+		}
+	}
+
+	next := n.path.points[n.index]
+	delta := next.vec3().Sub(pos)
+	dist := math.Hypot(delta[0], delta[2])
+
+	if n.p.conf.OpenDoors && dist < 1.5 {
+		n.openDoor(m, cube.PosFromVec3(pos))
+		n.openDoor(m, next.pos)
+	}
+
+	vel := m.Velocity()
+	if dist > 1e-5 {
+		speed := math.Min(m.Speed(), dist)
+		vel[0], vel[2] = delta[0]/dist*speed, delta[2]/dist*speed
+		m.SetRotation(cube.Rotation{mgl64.RadToDeg(math.Atan2(-delta[0], delta[2])), 0})
+	} else {
+		vel[0], vel[2] = 0, 0
+	}
+	if _, ok := w.Liquid(cube.PosFromVec3(pos)); ok && n.p.conf.Swim && delta[1] > -0.5 {
+		vel[1] = 0.1
+	} else if m.OnGround() && delta[1] > m.MobBehaviour().mc.StepHeight+1e-5 && dist < 1.5 {
+		vel[1] = 0.42
+		if e, ok := m.Effect(effect.JumpBoost{}); ok {
+			vel[1] += float64(e.Level()) / 10
+		}
+	}
+	m.SetVelocity(vel)
+}
+
+// repair checks if the part of the path directly ahead of the Mob is still
+// valid. If not, the invalid part is replaced by finding a path around it,
+// keeping the rest of the path intact. If this fails, a new path to the target
+// is found. False is returned if the Navigator stopped navigating.
+func (n *Navigator) repair(m *Mob, c pathContext) bool {
+	points := n.path.points
+	end := min(n.index+navigatorLookahead, len(points))
+	for i := max(n.index, 1); i < end; i++ {
+		from := points[i-1]
+		if i == n.index {
+			// The Mob is somewhere between the previous point and this one, so
+			// the move from its current position must be possible.
+			from = c.start(m.Position())
+			if from.pos == points[i].pos {
+				continue
+			}
+		}
+		if c.valid(from, points[i]) {
+			continue
+		}
+		// Find the first point after the invalid one that the Mob can still
+		// stand on and find a path from the last valid point to it.
+		for j := i + 1; j < len(points); j++ {
+			f, ok := c.floor(points[j].pos)
+			if !ok || !mgl64.FloatEqualThreshold(float64(points[j].pos[1])+f, points[j].y, 1e-5) {
+				continue
+			}
+			detour := c.search(from, points[j].vec3(), n.p.conf.MaxNodes/4)
+			if !detour.complete {
+				break
+			}
+			repaired := append(slices.Clone(points[:i]), detour.points[1:]...)
+			n.path = &Path{points: append(repaired, points[j+1:]...), complete: n.path.complete}
+			if i == n.index {
+				// The detour starts at the position of the Mob rather than the
+				// previous point, so the previous point is dropped.
+				n.path.points = slices.Delete(n.path.points, i-1, i)
+				n.index--
+			}
+			return true
+		}
+		return n.replan(m)
+	}
+	return true
+}
+
+// replan finds a new path from the current position of the Mob to the target
+// of the Navigator. False is returned if the new path does not bring the Mob
+// any closer to its target.
+func (n *Navigator) replan(m *Mob) bool {
+	path := n.p.FindPath(m, n.target)
+	n.index, n.stuck, n.last = 1, 0, m.Position()
+	if len(path.points) <= 1 && !path.complete {
+		n.path = nil
+		return false
+	}
+	n.path = path
+	return true
+}
+
+// openDoor opens the door at the cube.Pos passed, if there is a closed wooden
+// door at that position. The door is closed again by closeDoors once the Mob
+// has passed it.
+func (n *Navigator) openDoor(m *Mob, pos cube.Pos) {
+	w := m.World()
+	if d, ok := w.Block(pos).(block.WoodDoor); ok && !d.Open {
+		d.Activate(pos, cube.FaceUp, w, nil, nil)
+		n.doors = append(n.doors, pos)
+	}
+}
+
+// closeDoors closes the doors opened by the Mob that it has moved away from.
+// Doors that were closed or removed in the meantime are forgotten.
+func (n *Navigator) closeDoors(m *Mob) {
+	w, pos := m.World(), m.Position()
+	n.doors = slices.DeleteFunc(n.doors, func(door cube.Pos) bool {
+		centre := door.Vec3Centre()
+		if math.Hypot(centre[0]-pos[0], centre[2]-pos[2]) < navigatorDoorDistance && math.Abs(centre[1]-pos[1]) < 2 {
+			// The Mob is still walking through the door.
+			return false
+		}
+		if d, ok := w.Block(door).(block.WoodDoor); ok && d.Open {
+			d.Activate(door, cube.FaceUp, w, nil, nil)
+		}
+		return true
+	})
+}
+
+const (
+	// navigatorReachDistance is the horizontal distance within which a Mob is
+	// considered to have reached a point on its path.
+	navigatorReachDistance = 0.3
+	// navigatorLookahead is the amount of points ahead of the Mob that are
+	// checked for changes every tick.
+	navigatorLookahead = 8
+	// navigatorStuckTicks is the amount of ticks after which a Mob that has not
+	// moved finds a new path.
+	navigatorStuckTicks = 40
+	// navigatorDoorDistance is the horizontal distance from a door that a Mob
+	// must have moved away before the door it opened is closed again.
+	navigatorDoorDistance = 1.5
+)
@@ -0,0 +1,415 @@
+package entity
+
+import (
+	"container/heap"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// PathfinderConfig holds optional parameters for a Pathfinder.
+type PathfinderConfig struct {
+	// StepHeight is the maximum height that an entity can walk up without
+	// jumping. Most mobs have a step height of 0.6.
+	StepHeight float64
+	// JumpHeight is the maximum height that an entity can jump up. If
+	// JumpHeight is 0, the entity is unable to jump and only follows paths
+	// that it can walk up. Most mobs are able to jump 1.25 blocks high.
+	JumpHeight float64
+	// MaxFallDistance is the maximum amount of blocks that an entity may drop
+	// down while following a path. If MaxFallDistance is 0, a maximum fall
+	// distance of 3 is used.
+	MaxFallDistance int
+	// OpenDoors specifies if the entity is able to open wooden doors. If true,
+	// closed wooden doors are treated as if they were open.
+	OpenDoors bool
+	// Swim specifies if the entity is able to swim. If false, the entity only
+	// walks through water if it is able to stand on the ground below it.
+	Swim bool
+	// MaxNodes is the maximum amount of nodes visited in a single search. If
+	// no path to the target is found within MaxNodes, a partial path towards
+	// the target is returned. If MaxNodes is 0, a maximum of 1024 nodes is
+	// used.
+	MaxNodes int
+}
+
+// New creates a Pathfinder using the parameters in conf.
+func (conf PathfinderConfig) New() *Pathfinder {
+	if conf.MaxFallDistance <= 0 {
+		conf.MaxFallDistance = 3
+	}
+	if conf.MaxNodes <= 0 {
+		conf.MaxNodes = 1024
+	}
+	return &Pathfinder{conf: conf}
+}
+
+// Pathfinder finds paths through a world.World using the A* search algorithm.
+// It takes into account the collision models of blocks, liquids, doors and the
+// height that an entity is able to step, jump and fall. Pathfinder is safe
+// for concurrent use.
+type Pathfinder struct {
+	conf PathfinderConfig
+}
+
+// FindPath finds a path for the world.Entity passed from its current position
+// to the target passed. The path returned always starts at the position of the
+// entity. If the target could not be reached, a partial path ending at the
+// position closest to the target is returned and Path.Complete returns false.
+func (p *Pathfinder) FindPath(e world.Entity, target mgl64.Vec3) *Path {
+	c := p.context(e.World(), e.Type().BBox(e))
+	return c.search(c.start(e.Position()), target, p.conf.MaxNodes)
+}
+
+// context returns a pathContext for a world.World and an entity with the
+// cube.BBox passed.
+func (p *Pathfinder) context(w *world.World, box cube.BBox) pathContext {
+	return pathContext{conf: p.conf, w: w, box: box}
+}
+
+// Path is a path through a world.World found by a Pathfinder. It consists of
+// points that an entity should walk through in order to reach the target of
+// the path.
+type Path struct {
+	points   []pathPoint
+	complete bool
+}
+
+// Points returns the points of the Path. Each point is the position at the
+// bottom centre of a block that the entity should move to, with the Y value
+// set to the height of the ground that the entity stands on.
+func (p *Path) Points() []mgl64.Vec3 {
+	points := make([]mgl64.Vec3, len(p.points))
+	for i, point := range p.points {
+		points[i] = point.vec3()
+	}
+	return points
+}
+
+// Len returns the amount of points in the Path.
+func (p *Path) Len() int {
+	return len(p.points)
+}
+
+// Complete checks if the Path leads to the target it was created for. If
+// false, the Path only leads to the position closest to the target that could
+// be found.
+func (p *Path) Complete() bool {
+	return p.complete
+}
+
+// pathPoint is a single point on a Path. It holds the block position that the
+// entity is in and the height of the ground that it stands on.
+type pathPoint struct {
+	pos cube.Pos
+	y   float64
+}
+
+// vec3 returns the bottom centre position of the pathPoint.
+func (p pathPoint) vec3() mgl64.Vec3 {
+	return mgl64.Vec3{float64(p.pos[0]) + 0.5, p.y, float64(p.pos[2]) + 0.5}
+}
+
+// pathContext holds the state required to find a path for an entity with a
+// specific cube.BBox in a world.World.
+type pathContext struct {
+	conf PathfinderConfig
+	w    *world.World
+	box  cube.BBox
+}
+
+// start returns the pathPoint that an entity at the position passed starts a
+// path at.
+func (c pathContext) start(pos mgl64.Vec3) pathPoint {
+	p := pathPoint{pos: cube.PosFromVec3(pos), y: pos[1]}
+	if f, ok := c.floor(p.pos); ok {
+		p.y = float64(p.pos[1]) + f
+	}
+	return p
+}
+
+// search runs A* from the start pathPoint to the target passed, visiting at
+// most maxNodes nodes.
+func (c pathContext) search(start pathPoint, target mgl64.Vec3, maxNodes int) *Path {
+	goal := cube.PosFromVec3(target)
+	first := &pathNode{pathPoint: start, h: start.vec3().Sub(target).Len()}
+	first.f = first.h
+
+	nodes := map[cube.Pos]*pathNode{start.pos: first}
+	open := &pathQueue{first}
+	closest := first
+	for visited := 0; open.Len() > 0 && visited < maxNodes; visited++ {
+		n := heap.Pop(open).(*pathNode)
+		n.closed = true
+		if n.pos == goal {
+			return n.path(true)
+		}
+		if n.h < closest.h {
+			closest = n
+		}
+		c.neighbours(n.pathPoint, func(p pathPoint, cost float64) {
+			g := n.g + cost
+			other, ok := nodes[p.pos]
+			if !ok {
+				other = &pathNode{pathPoint: p, h: p.vec3().Sub(target).Len(), g: g, parent: n}
+				other.f = g + other.h
+				nodes[p.pos] = other
+				heap.Push(open, other)
+				return
+			}
+			if other.closed || g >= other.g {
+				return
+			}
+			other.pathPoint, other.g, other.f, other.parent = p, g, g+other.h, n
+			heap.Fix(open, other.index)
+		})
+	}
+	return closest.path(false)
+}
+
+// neighbours calls f for every pathPoint that an entity at the pathPoint a is
+// able to move to directly, along with the cost of that move.
+func (c pathContext) neighbours(a pathPoint, f func(p pathPoint, cost float64)) {
+	for _, offset := range pathOffsets {
+		for dy := 1; dy >= -c.conf.MaxFallDistance; dy-- {
+			pos := a.pos.Add(cube.Pos{offset[0], dy, offset[1]})
+			if p, cost, ok := c.move(a, pos); ok {
+				f(p, cost)
+				break
+			}
+			if dy <= 0 && !c.clear(c.column(pos, float64(pos[1]), float64(pos[1]))) {
+				// The entity cannot fall through this block, so there is no
+				// point in looking further down.
+				break
+			}
+		}
+	}
+	if c.conf.Swim {
+		for _, pos := range [...]cube.Pos{a.pos.Side(cube.FaceUp), a.pos.Side(cube.FaceDown)} {
+			if p, cost, ok := c.move(a, pos); ok {
+				f(p, cost)
+			}
+		}
+	}
+}
+
+// move checks if an entity at the pathPoint a is able to move directly to the
+// cube.Pos passed. If so, the resulting pathPoint and cost of the move are
+// returned.
+func (c pathContext) move(a pathPoint, pos cube.Pos) (pathPoint, float64, bool) {
+	f, ok := c.floor(pos)
+	if !ok {
+		return pathPoint{}, 0, false
+	}
+	b := pathPoint{pos: pos, y: float64(pos[1]) + f}
+	rise := b.y - a.y
+	if pos[0] == a.pos[0] && pos[2] == a.pos[2] {
+		// Moving straight up or down is only possible while swimming.
+		if !c.conf.Swim || !c.swimmable(a.pos) || !c.swimmable(pos) {
+			return pathPoint{}, 0, false
+		}
+	} else if rise > math.Max(c.conf.StepHeight, c.conf.JumpHeight)+1e-5 || -rise > float64(c.conf.MaxFallDistance) {
+		return pathPoint{}, 0, false
+	}
+
+	// The entity first moves up in its current column if it has to climb,
+	// then moves horizontally and finally drops down in the new column if it
+	// has to fall. All of these movements need to be unobstructed.
+	top := math.Max(a.y, b.y)
+	horizontal := b.vec3().Sub(a.vec3())
+	horizontal[1] = 0
+	if !c.clear(c.column(a.pos, a.y, top)) || !c.clear(c.column(pos, b.y, top)) || !c.clear(c.column(a.pos, top, top).Extend(horizontal)) {
+		return pathPoint{}, 0, false
+	}
+
+	cost := b.vec3().Sub(a.vec3()).Len()
+	if rise > c.conf.StepHeight+1e-5 {
+		cost += pathJumpPenalty
+	}
+	if _, ok := c.w.Liquid(pos); ok {
+		cost += pathWaterPenalty
+	}
+	if c.door(pos) || c.door(pos.Side(cube.FaceUp)) {
+		cost += pathDoorPenalty
+	}
+	return b, cost, true
+}
+
+// valid checks if the move from pathPoint a to pathPoint b is still possible,
+// and if b is still at the same height.
+func (c pathContext) valid(a, b pathPoint) bool {
+	p, _, ok := c.move(a, b.pos)
+	return ok && mgl64.FloatEqualThreshold(p.y, b.y, 1e-5)
+}
+
+// floor returns the height, relative to the cube.Pos passed, of the ground
+// that an entity in the block at that position stands on. False is returned if
+// the entity is unable to stand at the position, for example because there is
+// no ground or because the block is dangerous.
+func (c pathContext) floor(pos cube.Pos) (float64, bool) {
+	if pos.OutOfBounds(c.w.Range()) {
+		return 0, false
+	}
+	below := pos.Side(cube.FaceDown)
+	b, underneath := c.w.Block(pos), c.w.Block(below)
+	if pathDangerous(b) || pathDangerous(underneath) {
+		return 0, false
+	}
+	liquid, inLiquid := c.w.Liquid(pos)
+	if inLiquid && pathDangerous(liquid) {
+		return 0, false
+	}
+
+	footprint := c.box.Translate(mgl64.Vec3{float64(pos[0]) + 0.5, 0, float64(pos[2]) + 0.5})
+	f := -1.0
+	for _, box := range b.Model().BBox(pos, c.w) {
+		if h := box.Max()[1]; h < 1 && pathOverlaps(footprint, box.Translate(pos.Vec3())) {
+			f = math.Max(f, h)
+		}
+	}
+	for _, box := range underneath.Model().BBox(below, c.w) {
+		if pathOverlaps(footprint, box.Translate(below.Vec3())) {
+			f = math.Max(f, box.Max()[1]-1)
+		}
+	}
+	if f >= 0 && f < 1 {
+		return f, true
+	}
+	if inLiquid && c.conf.Swim {
+		return 0, true
+	}
+	return 0, false
+}
+
+// swimmable checks if an entity is able to swim in the block at the cube.Pos
+// passed.
+func (c pathContext) swimmable(pos cube.Pos) bool {
+	liquid, ok := c.w.Liquid(pos)
+	return ok && !pathDangerous(liquid)
+}
+
+// door checks if the block at the cube.Pos passed is a door that the entity
+// may open.
+func (c pathContext) door(pos cube.Pos) bool {
+	_, ok := c.w.Block(pos).(block.WoodDoor)
+	return ok && c.conf.OpenDoors
+}
+
+// column returns the cube.BBox of the entity standing in the block at the
+// cube.Pos passed, extended vertically so that its bottom is at the height
+// from and its bottom may move up to the height to.
+func (c pathContext) column(pos cube.Pos, from, to float64) cube.BBox {
+	return c.box.Translate(mgl64.Vec3{float64(pos[0]) + 0.5, from, float64(pos[2]) + 0.5}).Extend(mgl64.Vec3{0, to - from})
+}
+
+// clear checks if the cube.BBox passed does not intersect with any blocks.
+// Doors that the entity is able to open are ignored.
+func (c pathContext) clear(box cube.BBox) bool {
+	min, max := box.Min(), box.Max()
+	for y := int(math.Floor(min[1])); y <= int(math.Floor(max[1])); y++ {
+		for x := int(math.Floor(min[0])); x <= int(math.Floor(max[0])); x++ {
+			for z := int(math.Floor(min[2])); z <= int(math.Floor(max[2])); z++ {
+				pos := cube.Pos{x, y, z}
+				if c.door(pos) {
+					continue
+				}
+				for _, blockBox := range c.w.Block(pos).Model().BBox(pos, c.w) {
+					if box.IntersectsWith(blockBox.Translate(pos.Vec3())) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// pathOverlaps checks if the cube.BBox b overlaps with the footprint passed on
+// the X and Z axes.
+func pathOverlaps(footprint, b cube.BBox) bool {
+	return footprint.Min()[0] < b.Max()[0] && footprint.Max()[0] > b.Min()[0] &&
+		footprint.Min()[2] < b.Max()[2] && footprint.Max()[2] > b.Min()[2]
+}
+
+// pathDangerous checks if a block is dangerous for entities to walk into or
+// onto.
+func pathDangerous(b world.Block) bool {
+	switch b.(type) {
+	case block.Lava, block.Fire, block.Cactus:
+		return true
+	}
+	return false
+}
+
+const (
+	// pathJumpPenalty is the additional cost of a move that requires jumping.
+	pathJumpPenalty = 0.5
+	// pathWaterPenalty is the additional cost of a move into water.
+	pathWaterPenalty = 2
+	// pathDoorPenalty is the additional cost of a move through a door.
+	pathDoorPenalty = 1
+)
+
+// pathOffsets holds the horizontal offsets of all blocks that an entity may
+// move to from the block it is in. Diagonal moves are only possible if the
+// entity does not collide with any of the blocks it passes.
+var pathOffsets = [...][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+
+// pathNode is a node in the A* search run by a Pathfinder.
+type pathNode struct {
+	pathPoint
+	g, h, f float64
+	parent  *pathNode
+	index   int
+	closed  bool
+}
+
+// path returns the Path that leads from the start node to n.
+func (n *pathNode) path(complete bool) *Path {
+	var points []pathPoint
+	for node := n; node != nil; node = node.parent {
+		points = append(points, node.pathPoint)
+	}
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	return &Path{points: points, complete: complete}
+}
+
+// pathQueue is a priority queue of pathNodes ordered by their estimated total
+// cost. It implements heap.Interface.
+type pathQueue []*pathNode
+
+// Len ...
+func (q pathQueue) Len() int {
+	return len(q)
+}
+
+// Less ...
+func (q pathQueue) Less(i, j int) bool {
+	return q[i].f < q[j].f
+}
+
+// Swap ...
+func (q pathQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+
+// Push ...
+func (q *pathQueue) Push(x any) {
+	n := x.(*pathNode)
+	n.index = len(*q)
+	*q = append(*q, n)
+}
+
+// Pop ...
+func (q *pathQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return n
+}
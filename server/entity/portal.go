@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+// PortalTravel transports an entity through the nether portals, end portals
+// and end gateways that it stands in. It is used by Ent and by players. The
+// zero value of PortalTravel is ready for use. PortalTravel is not safe for
+// concurrent use and should only be used while the entity is ticked.
+type PortalTravel struct {
+	// ticks holds the amount of ticks that the entity has been standing in a
+	// portal. arrived is true if the entity was transported through a portal
+	// and has not yet left the portal that it arrived in.
+	ticks   int64
+	arrived bool
+}
+
+// Tick transports the entity passed through a portal that it is standing in,
+// in the World passed. Entities have to stand in a nether portal for delay
+// ticks before being transported, while end portals and end gateways
+// transport them immediately. After being transported, the entity has to leave
+// the portal it arrived in before it can use a portal again. travel is called
+// to move the entity to its destination World and position.
+func (t *PortalTravel) Tick(e world.Entity, w *world.World, delay int64, travel func(dest *world.World, pos mgl64.Vec3)) {
+	pos, portal, ok := insidePortal(e, w)
+	if !ok {
+		t.ticks, t.arrived = 0, false
+		return
+	}
+	if t.arrived {
+		return
+	}
+	if _, nether := portal.(block.NetherPortal); nether {
+		if t.ticks++; t.ticks < delay {
+			return
+		}
+	}
+	t.ticks, t.arrived = 0, true
+
+	switch portal := portal.(type) {
+	case block.NetherPortal:
+		if dest, pos, ok := netherPortalDestination(e, w); ok {
+			travel(dest, pos)
+		}
+	case block.EndPortal:
+		if dest, pos, ok := endPortalDestination(e, w); ok {
+			travel(dest, pos)
+		}
+	case block.EndGateway:
+		travel(w, portal.Destination(pos, w).Vec3Middle())
+	}
+}
+
+// insidePortal returns the position of a portal block, such as a nether
+// portal, end portal or end gateway, that the bounding box of the entity
+// passed intersects with. If the entity is not inside a portal, false is
+// returned.
+func insidePortal(e world.Entity, w *world.World) (cube.Pos, world.Block, bool) {
+	box := e.Type().BBox(e).Translate(e.Position()).Grow(-0.0001)
+	min, max := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for y := min[1]; y <= max[1]; y++ {
+		for x := min[0]; x <= max[0]; x++ {
+			for z := min[2]; z <= max[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				switch b := w.Block(pos).(type) {
+				case block.NetherPortal, block.EndPortal, block.EndGateway:
+					return pos, b, true
+				}
+			}
+		}
+	}
+	return cube.Pos{}, nil, false
+}
+
+// netherPortalDestination returns the destination of the entity passed when
+// it uses a nether portal in the World passed. Coordinates are divided by 8
+// when travelling to the nether and multiplied by 8 when travelling from the
+// nether. The entity is placed in the nearest existing portal around the
+// destination, or in a newly created portal if none could be found.
+// Portals are searched for within 128 blocks of the destination in the
+// overworld and 16 blocks in the nether. Note that the chunks close to the
+// destination are loaded, or generated, synchronously while the entity is
+// ticked, both to search for a portal and to create one.
+func netherPortalDestination(e world.Entity, w *world.World) (*world.World, mgl64.Vec3, bool) {
+	dest := w.PortalDestination(world.Nether)
+	if dest == w {
+		return nil, mgl64.Vec3{}, false
+	}
+	scale, radius := 1.0, 128
+	if w.Dimension() == world.Nether && dest.Dimension() != world.Nether {
+		scale = 8
+	} else if w.Dimension() != world.Nether && dest.Dimension() == world.Nether {
+		scale, radius = 1.0/8, 16
+	}
+	pos := e.Position()
+	target := cube.PosFromVec3(dest.Border().Clamp(mgl64.Vec3{pos[0] * scale, pos[1], pos[2] * scale}))
+
+	portal, ok := block.FindNetherPortal(dest, target, radius)
+	if !ok {
+		portal = block.CreateNetherPortal(dest, target)
+	}
+	return dest, portal.Vec3Middle(), true
+}
+
+// endPortalDestination returns the destination of the entity passed when it
+// uses an end portal in the World passed. If the destination is in the end,
+// the entity is placed on an obsidian platform that is created if needed.
+// Otherwise, the entity is placed at its spawn in the destination.
+func endPortalDestination(e world.Entity, w *world.World) (*world.World, mgl64.Vec3, bool) {
+	dest := w.PortalDestination(world.End)
+	if dest == w {
+		return nil, mgl64.Vec3{}, false
+	}
+	if dest.Dimension() == world.End {
+		block.CreateEndPlatform(dest, block.EndPlatformPos)
+		return dest, block.EndPlatformPos.Side(cube.FaceUp).Vec3Middle(), true
+	}
+	if s, ok := e.(spawnEntity); ok {
+		return dest, dest.PlayerSpawn(s.UUID()).Vec3Middle(), true
+	}
+	return dest, dest.Spawn().Vec3Middle(), true
+}
+
+// spawnEntity is an entity that may have a spawn position of its own, such as
+// a player.
+type spawnEntity interface {
+	UUID() uuid.UUID
+}
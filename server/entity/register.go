@@ -16,14 +16,18 @@ var DefaultRegistry = conf.New([]world.EntityType{
 	AreaEffectCloudType{},
 	ArrowType{},
 	BottleOfEnchantingType{},
+	ChestMinecartType{},
 	EggType{},
 	EnderPearlType{},
 	ExperienceOrbType{},
 	FallingBlockType{},
 	FireworkType{},
+	HopperMinecartType{},
 	ItemType{},
 	LightningType{},
 	LingeringPotionType{},
+	MinecartType{},
+	SmallFireballType{},
 	SnowballType{},
 	SplashPotionType{},
 	TNTType{},
@@ -77,6 +81,20 @@ var conf = world.EntityRegistryConfig{
 		p.vel = vel
 		return p
 	},
+	Minecart: func(pos mgl64.Vec3, t any) world.Entity {
+		switch t.(item.MinecartType) {
+		case item.ChestMinecart():
+			return NewChestMinecart(pos)
+		case item.HopperMinecart():
+			return NewHopperMinecart(pos)
+		}
+		return NewMinecart(pos)
+	},
+	SmallFireball: func(pos, vel mgl64.Vec3, owner world.Entity) world.Entity {
+		f := NewSmallFireball(pos, owner)
+		f.vel = vel
+		return f
+	},
 	Snowball: func(pos, vel mgl64.Vec3, owner world.Entity) world.Entity {
 		s := NewSnowball(pos, owner)
 		s.vel = vel
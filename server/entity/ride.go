@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Rider represents an entity that is able to ride other entities, such as a player riding a minecart.
+type Rider interface {
+	world.Entity
+	// Riding returns the entity that the Rider is currently riding. False is returned if the Rider is not riding any
+	// entity.
+	Riding() (world.Entity, bool)
+	// Dismount makes the Rider stop riding the entity that it is currently riding.
+	Dismount()
+}
+
+// Rideable is implemented by the Behaviour of entities that may be ridden by a Rider.
+type Rideable interface {
+	// Rider returns the Rider currently riding the entity. False is returned if the entity has no Rider.
+	Rider() (Rider, bool)
+	// AddRider makes the Rider passed ride the entity. False is returned if the Rider could not start riding the
+	// entity, for example because another Rider is already riding it.
+	AddRider(r Rider) bool
+	// RemoveRider removes the Rider passed from the entity, if it was riding it.
+	RemoveRider(r Rider)
+}
+
+// Hittable is implemented by the Behaviour of entities that are not Living but may still be hit by other entities,
+// such as minecarts.
+type Hittable interface {
+	// Hit handles the entity being hit by the attacker passed, dealing the damage passed.
+	Hit(e *Ent, attacker world.Entity, dmg float64)
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// NewSmallFireball creates a SmallFireball entity. SmallFireball is a projectile
+// shot by dispensers using fire charges. It sets the entities and blocks it hits
+// on fire.
+func NewSmallFireball(pos mgl64.Vec3, owner world.Entity) *Ent {
+	return Config{Behaviour: smallFireballConf.New(owner)}.New(SmallFireballType{}, pos)
+}
+
+var smallFireballConf = ProjectileBehaviourConfig{
+	Damage: 5,
+	Hit:    ignite,
+}
+
+// ignite sets the entity hit by a small fireball on fire, or places fire in front
+// of the block it hit.
+func ignite(e *Ent, target trace.Result) {
+	w := e.World()
+	switch r := target.(type) {
+	case trace.EntityResult:
+		if flammable, ok := r.Entity().(Flammable); ok {
+			flammable.SetOnFire(time.Second * 5)
+		}
+	case trace.BlockResult:
+		pos := r.BlockPosition().Side(r.Face())
+		if _, ok := w.Block(pos).(block.Air); ok {
+			w.SetBlock(pos, block.Fire{}, nil)
+			w.ScheduleBlockUpdate(pos, time.Duration(30+rand.Intn(10))*time.Second/20)
+		}
+	}
+}
+
+// SmallFireballType is a world.EntityType implementation for SmallFireball.
+type SmallFireballType struct{}
+
+func (SmallFireballType) EncodeEntity() string { return "minecraft:small_fireball" }
+func (SmallFireballType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.15625, 0, -0.15625, 0.15625, 0.3125, 0.15625)
+}
+
+func (SmallFireballType) DecodeNBT(m map[string]any) world.Entity {
+	f := NewSmallFireball(nbtconv.Vec3(m, "Pos"), nil)
+	f.vel = nbtconv.Vec3(m, "Motion")
+	return f
+}
+
+func (SmallFireballType) EncodeNBT(e world.Entity) map[string]any {
+	f := e.(*Ent)
+	return map[string]any{
+		"Pos":    nbtconv.Vec3ToFloat32Slice(f.Position()),
+		"Motion": nbtconv.Vec3ToFloat32Slice(f.Velocity()),
+	}
+}
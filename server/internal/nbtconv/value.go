@@ -0,0 +1,68 @@
+package nbtconv
+
+import "reflect"
+
+// IntFromAny converts an integer of any NBT type to an int. 0 is returned if v is not an integer.
+func IntFromAny(v any) int {
+	switch v := v.(type) {
+	case uint8:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+	return 0
+}
+
+// BytesFromAny converts an NBT byte array, which is decoded as a fixed size array, to a []byte.
+func BytesFromAny(v any) []byte {
+	if b, ok := v.([]byte); ok {
+		return b
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Array || val.Type().Elem().Kind() != reflect.Uint8 {
+		return nil
+	}
+	b := make([]byte, val.Len())
+	reflect.Copy(reflect.ValueOf(b), val)
+	return b
+}
+
+// Int32sFromAny converts an NBT int array, which is decoded as a fixed size array, or a list of integers to a
+// []int32.
+func Int32sFromAny(v any) []int32 {
+	switch l := v.(type) {
+	case []int32:
+		return l
+	case []any:
+		s := make([]int32, 0, len(l))
+		for _, x := range l {
+			s = append(s, int32(IntFromAny(x)))
+		}
+		return s
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Array || val.Type().Elem().Kind() != reflect.Int32 {
+		return nil
+	}
+	s := make([]int32, val.Len())
+	reflect.Copy(reflect.ValueOf(s), val)
+	return s
+}
+
+// Int64sFromAny converts an NBT long array, which is decoded as a fixed size array, to a []int64.
+func Int64sFromAny(v any) []int64 {
+	if s, ok := v.([]int64); ok {
+		return s
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Array || val.Type().Elem().Kind() != reflect.Int64 {
+		return nil
+	}
+	s := make([]int64, val.Len())
+	reflect.Copy(reflect.ValueOf(s), val)
+	return s
+}
@@ -1,6 +1,13 @@
 package item
 
-import "github.com/df-mc/dragonfly/server/item/potion"
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
 
 // Arrow is used as ammunition for bows, crossbows, and dispensers. Arrows can be modified to
 // imbue status effects on players and mobs.
@@ -9,6 +16,21 @@ type Arrow struct {
 	Tip potion.Potion
 }
 
+// Dispense shoots the arrow out of the dispenser.
+func (a Arrow) Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *UseContext) bool {
+	vel := DispenseVelocity(face, 1.1, 6)
+	rot := cube.Rotation{
+		mgl64.RadToDeg(math.Atan2(vel[0], vel[2])),
+		mgl64.RadToDeg(math.Atan2(vel[1], math.Hypot(vel[0], vel[2]))),
+	}
+	create := w.EntityRegistry().Config().Arrow
+	w.AddEntity(create(DispensePosition(pos, face), vel, rot, 2, nil, false, false, true, 0, a.Tip))
+	w.PlaySound(pos.Vec3Centre(), sound.BowShoot{})
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
 // EncodeItem ...
 func (a Arrow) EncodeItem() (name string, meta int16) {
 	if tip := a.Tip.Uint8(); tip > 4 {
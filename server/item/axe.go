@@ -2,6 +2,7 @@ package item
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -75,6 +76,11 @@ func (a Axe) AttackDamage() float64 {
 	return a.Tier.BaseAttackDamage + 2
 }
 
+// AttributeModifiers returns a modifier that increases the attack damage of the entity holding the axe.
+func (a Axe) AttributeModifiers() []attribute.Modifier {
+	return attackDamageModifiers(a.AttackDamage())
+}
+
 // ToolType ...
 func (a Axe) ToolType() ToolType {
 	return TypeAxe
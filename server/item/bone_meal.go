@@ -26,6 +26,11 @@ func (b BoneMeal) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, w *world.W
 	return false
 }
 
+// Dispense uses the bone meal on the block in front of the dispenser.
+func (b BoneMeal) Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *UseContext) bool {
+	return b.UseOnBlock(pos.Side(face), face, mgl64.Vec3{}, w, nil, ctx)
+}
+
 // EncodeItem ...
 func (b BoneMeal) EncodeItem() (name string, meta int16) {
 	return "minecraft:bone_meal", 0
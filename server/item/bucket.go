@@ -122,6 +122,31 @@ func (b Bucket) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 	return true
 }
 
+// Dispense fills the bucket from the liquid in front of the dispenser, or places the liquid in the bucket in front
+// of the dispenser.
+func (b Bucket) Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *UseContext) bool {
+	if b.Content.milk {
+		return false
+	}
+	pos = pos.Side(face)
+	if !w.Border().ContainsBlock(pos) {
+		return false
+	}
+	if b.Empty() {
+		return b.fillFrom(pos, w, ctx)
+	}
+	liq := b.Content.liquid.WithDepth(8, false)
+	if bl := w.Block(pos); !canDisplace(bl, liq) && !replaceableWith(bl, liq) {
+		return false
+	}
+	w.SetLiquid(pos, liq)
+	w.PlaySound(pos.Vec3Centre(), sound.BucketEmpty{Liquid: b.Content.liquid})
+
+	ctx.NewItem = NewStack(Bucket{}, 1)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
 // fillFrom fills a bucket from the liquid at the position passed in the world. If there is no liquid or if
 // the liquid is no source, fillFrom returns false.
 func (b Bucket) fillFrom(pos cube.Pos, w *world.World, ctx *UseContext) bool {
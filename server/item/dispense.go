@@ -0,0 +1,34 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+)
+
+// Dispensable represents an item that has special behaviour when it is dispensed by a dispenser. Items that do not
+// implement Dispensable are dropped by the dispenser like a dropper would.
+type Dispensable interface {
+	// Dispense dispenses the item from a dispenser at the position passed that is facing the face passed. The
+	// UseContext passed should be used to subtract from the count of the item or to replace it with a new item.
+	// Dispense returns false if the item could not be dispensed.
+	Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *UseContext) bool
+}
+
+// DispensePosition returns the position from which a dispenser at the position passed, facing the face passed,
+// dispenses items and projectiles.
+func DispensePosition(pos cube.Pos, face cube.Face) mgl64.Vec3 {
+	return pos.Vec3Centre().Add(cube.Pos{}.Side(face).Vec3().Mul(0.7))
+}
+
+// DispenseVelocity returns the velocity of a projectile shot by a dispenser facing the face passed. The projectile
+// is shot slightly upwards with the power passed. The direction of the projectile randomly deviates based on the
+// inaccuracy passed.
+func DispenseVelocity(face cube.Face, power, inaccuracy float64) mgl64.Vec3 {
+	dir := cube.Pos{}.Side(face).Vec3().Add(mgl64.Vec3{0, 0.1}).Normalize()
+	for i := range dir {
+		dir[i] += rand.NormFloat64() * 0.0075 * inaccuracy
+	}
+	return dir.Mul(power)
+}
@@ -0,0 +1,10 @@
+package item
+
+// EnderEye is an item that is placed in end portal frames to activate an end portal once all frames surrounding it
+// hold an eye of ender.
+type EnderEye struct{}
+
+// EncodeItem ...
+func (EnderEye) EncodeItem() (name string, meta int16) {
+	return "minecraft:ender_eye", 0
+}
@@ -33,3 +33,17 @@ func (f FireCharge) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *wo
 	}
 	return false
 }
+
+// Dispense shoots a small fireball out of the dispenser.
+func (f FireCharge) Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *UseContext) bool {
+	dir := cube.Pos{}.Side(face).Vec3()
+	for i := range dir {
+		dir[i] += rand.NormFloat64() * 0.05
+	}
+	create := w.EntityRegistry().Config().SmallFireball
+	w.AddEntity(create(DispensePosition(pos, face), dir.Normalize(), nil))
+	w.PlaySound(pos.Vec3Centre(), sound.FireCharge{})
+
+	ctx.SubtractFromCount(1)
+	return true
+}
@@ -2,6 +2,7 @@ package item
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -52,6 +53,11 @@ func (h Hoe) AttackDamage() float64 {
 	return h.Tier.BaseAttackDamage + 1
 }
 
+// AttributeModifiers returns a modifier that increases the attack damage of the entity holding the hoe.
+func (h Hoe) AttributeModifiers() []attribute.Modifier {
+	return attackDamageModifiers(h.AttackDamage())
+}
+
 // ToolType ...
 func (h Hoe) ToolType() ToolType {
 	return TypeHoe
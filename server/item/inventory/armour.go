@@ -2,9 +2,11 @@ package inventory
 
 import (
 	"fmt"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 	"math"
 	"math/rand"
 )
@@ -210,6 +212,25 @@ func (a *Armour) KnockBackResistance() float64 {
 	return resistance
 }
 
+// armourKnockBackModifierID is the ID of the attribute.Modifier applied by Armour to the knock back resistance of
+// the entity wearing it.
+var armourKnockBackModifierID = uuid.MustParse("845db27c-c624-495f-8c9f-6020a9a58b6b")
+
+// AttributeModifiers returns the attribute modifiers applied by the Armour to the entity wearing it. Nil is
+// returned if the Armour does not modify any attributes.
+func (a *Armour) AttributeModifiers() []attribute.Modifier {
+	if resistance := a.KnockBackResistance(); resistance > 0 {
+		return []attribute.Modifier{{
+			ID:        armourKnockBackModifierID,
+			Name:      "Armor modifier",
+			Attribute: attribute.KnockBackResistance(),
+			Amount:    resistance,
+			Operation: attribute.OperationAdd(),
+		}}
+	}
+	return nil
+}
+
 // Slots returns all items (including) air of the armour inventory in the order of helmet, chestplate, leggings,
 // boots.
 func (a *Armour) Slots() []item.Stack {
@@ -3,6 +3,7 @@ package item
 import (
 	"encoding/binary"
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/lang"
 	"github.com/df-mc/dragonfly/server/world"
@@ -127,6 +128,13 @@ type Weapon interface {
 	AttackDamage() float64
 }
 
+// AttributeModifier represents an item that modifies the attributes of an entity while it is held in the main hand
+// of that entity, such as a sword increasing the attack damage of the entity.
+type AttributeModifier interface {
+	// AttributeModifiers returns the attribute.Modifiers applied to an entity holding the item in its main hand.
+	AttributeModifiers() []attribute.Modifier
+}
+
 // Cooldown represents an item that has a cooldown.
 type Cooldown interface {
 	// Cooldown is the duration of the cooldown.
@@ -0,0 +1,118 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Minecart is an item that can be placed on rails to create a minecart entity. Depending on its type, the minecart
+// can be ridden or holds an inventory.
+type Minecart struct {
+	// Type is the type of the minecart.
+	Type MinecartType
+}
+
+// MaxCount always returns 1.
+func (Minecart) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock places a minecart on the rail clicked.
+func (m Minecart) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, w *world.World, _ User, ctx *UseContext) bool {
+	r, ok := w.Block(pos).(rail)
+	if !ok {
+		return false
+	}
+	create := w.EntityRegistry().Config().Minecart
+	w.AddEntity(create(minecartPosition(pos, r), m.Type))
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// Dispense places a minecart on the rail in front of the dispenser, or on the rail below the block in front of it.
+func (m Minecart) Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *UseContext) bool {
+	pos = pos.Side(face)
+	r, ok := w.Block(pos).(rail)
+	if !ok {
+		if w.Block(pos) != air() {
+			return false
+		}
+		pos = pos.Side(cube.FaceDown)
+		if r, ok = w.Block(pos).(rail); !ok {
+			return false
+		}
+	}
+	create := w.EntityRegistry().Config().Minecart
+	w.AddEntity(create(minecartPosition(pos, r), m.Type))
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// minecartPosition returns the position at which a minecart placed on the rail passed at the position passed is
+// spawned.
+func minecartPosition(pos cube.Pos, r rail) mgl64.Vec3 {
+	spawnPos := pos.Vec3Middle().Add(mgl64.Vec3{0, 0.0625})
+	if r.Ascending() {
+		spawnPos[1] += 0.5
+	}
+	return spawnPos
+}
+
+// EncodeItem ...
+func (m Minecart) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + m.Type.String(), 0
+}
+
+// rail represents a rail block that minecarts may be placed on.
+type rail interface {
+	// Ascending returns true if the rail ascends towards one of the horizontal directions.
+	Ascending() bool
+}
+
+// MinecartType represents a type of Minecart.
+type MinecartType struct {
+	minecart
+}
+
+// NormalMinecart is a minecart that may be ridden by an entity.
+func NormalMinecart() MinecartType {
+	return MinecartType{0}
+}
+
+// ChestMinecart is a minecart that holds a chest with 27 slots.
+func ChestMinecart() MinecartType {
+	return MinecartType{1}
+}
+
+// HopperMinecart is a minecart that holds a hopper. It collects items that it rides over.
+func HopperMinecart() MinecartType {
+	return MinecartType{2}
+}
+
+// MinecartTypes returns all possible MinecartTypes.
+func MinecartTypes() []MinecartType {
+	return []MinecartType{NormalMinecart(), ChestMinecart(), HopperMinecart()}
+}
+
+type minecart uint8
+
+// Uint8 returns the MinecartType as a uint8.
+func (m minecart) Uint8() uint8 {
+	return uint8(m)
+}
+
+// String returns the MinecartType as a string.
+func (m minecart) String() string {
+	switch m {
+	case 0:
+		return "minecart"
+	case 1:
+		return "chest_minecart"
+	case 2:
+		return "hopper_minecart"
+	}
+	panic("should never happen")
+}
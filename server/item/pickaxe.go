@@ -1,6 +1,7 @@
 package item
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
 	"time"
 )
@@ -39,6 +40,11 @@ func (p Pickaxe) AttackDamage() float64 {
 	return p.Tier.BaseAttackDamage + 1
 }
 
+// AttributeModifiers returns a modifier that increases the attack damage of the entity holding the pickaxe.
+func (p Pickaxe) AttributeModifiers() []attribute.Modifier {
+	return attackDamageModifiers(p.AttackDamage())
+}
+
 // EnchantmentValue ...
 func (p Pickaxe) EnchantmentValue() int {
 	return p.Tier.EnchantmentValue
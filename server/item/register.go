@@ -50,6 +50,7 @@ func init() {
 	world.RegisterItem(Emerald{})
 	world.RegisterItem(EnchantedApple{})
 	world.RegisterItem(EnchantedBook{})
+	world.RegisterItem(EnderEye{})
 	world.RegisterItem(EnderPearl{})
 	world.RegisterItem(Feather{})
 	world.RegisterItem(FermentedSpiderEye{})
@@ -156,6 +157,9 @@ func init() {
 		world.RegisterItem(Sword{Tier: t})
 		world.RegisterItem(Hoe{Tier: t})
 	}
+	for _, t := range MinecartTypes() {
+		world.RegisterItem(Minecart{Type: t})
+	}
 	for _, disc := range sound.MusicDiscs() {
 		world.RegisterItem(MusicDisc{DiscType: disc})
 	}
@@ -2,6 +2,7 @@ package item
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -54,6 +55,11 @@ func (s Shovel) AttackDamage() float64 {
 	return s.Tier.BaseAttackDamage
 }
 
+// AttributeModifiers returns a modifier that increases the attack damage of the entity holding the shovel.
+func (s Shovel) AttributeModifiers() []attribute.Modifier {
+	return attackDamageModifiers(s.AttackDamage())
+}
+
 // ToolType returns the tool type for shovels.
 func (s Shovel) ToolType() ToolType {
 	return TypeShovel
@@ -1,6 +1,7 @@
 package item
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
@@ -27,6 +28,16 @@ func (s SplashPotion) Use(w *world.World, user User, ctx *UseContext) bool {
 	return true
 }
 
+// Dispense throws the splash potion out of the dispenser.
+func (s SplashPotion) Dispense(pos cube.Pos, face cube.Face, w *world.World, ctx *UseContext) bool {
+	create := w.EntityRegistry().Config().SplashPotion
+	w.AddEntity(create(DispensePosition(pos, face), DispenseVelocity(face, 1.375, 3), s.Type, nil))
+	w.PlaySound(pos.Vec3Centre(), sound.ItemThrow{})
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
 // EncodeItem ...
 func (s SplashPotion) EncodeItem() (name string, meta int16) {
 	return "minecraft:splash_potion", int16(s.Type.Uint8())
@@ -2,6 +2,7 @@ package item
 
 import (
 	"fmt"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
 	"reflect"
 	"slices"
@@ -192,6 +193,19 @@ func (s Stack) AttackDamage() float64 {
 	return 1.0
 }
 
+// AttributeModifiers returns the attribute modifiers applied to an entity holding the stack in its main hand. Items
+// that implement Weapon but not AttributeModifier increase the attack damage of the entity by the damage returned by
+// Weapon.AttackDamage. Nil is returned if the item of the stack implements neither.
+func (s Stack) AttributeModifiers() []attribute.Modifier {
+	switch it := s.Item().(type) {
+	case AttributeModifier:
+		return it.AttributeModifiers()
+	case Weapon:
+		return attackDamageModifiers(it.AttackDamage())
+	}
+	return nil
+}
+
 // WithCustomName returns a copy of the Stack with the custom name passed. The custom name is formatted
 // according to the rules of fmt.Sprintln.
 func (s Stack) WithCustomName(a ...any) Stack {
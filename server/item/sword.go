@@ -1,6 +1,7 @@
 package item
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
 	"time"
 )
@@ -17,6 +18,11 @@ func (s Sword) AttackDamage() float64 {
 	return s.Tier.BaseAttackDamage + 3
 }
 
+// AttributeModifiers returns a modifier that increases the attack damage of the entity holding the sword.
+func (s Sword) AttributeModifiers() []attribute.Modifier {
+	return attackDamageModifiers(s.AttackDamage())
+}
+
 // MaxCount always returns 1.
 func (s Sword) MaxCount() int {
 	return 1
@@ -1,7 +1,9 @@
 package item
 
 import (
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
 )
 
 var (
@@ -118,3 +120,19 @@ func toolTierRepairable(tier ToolTier) func(Stack) bool {
 		return false
 	}
 }
+
+// attackDamageModifierID is the ID of the attribute.Modifier applied by tools to the attack damage of the entity
+// holding them.
+var attackDamageModifierID = uuid.MustParse("cb3f55d3-645c-4f38-a497-9c13a33db5cf")
+
+// attackDamageModifiers returns the attribute.Modifiers of a tool that adds the attack damage passed to the attack
+// damage of the entity holding it.
+func attackDamageModifiers(dmg float64) []attribute.Modifier {
+	return []attribute.Modifier{{
+		ID:        attackDamageModifierID,
+		Name:      "Tool modifier",
+		Attribute: attribute.AttackDamage(),
+		Amount:    dmg,
+		Operation: attribute.OperationAdd(),
+	}}
+}
@@ -52,6 +52,9 @@ type Handler interface {
 	// *w. This world may be the world the Player died in, but it might also point to a different world (the overworld)
 	// if the Player died in the nether or end.
 	HandleRespawn(pos *mgl64.Vec3, w **world.World)
+	// HandleSleep handles the player going to sleep in the bed at the position passed. ctx.Cancel() may be called to
+	// prevent the player from sleeping.
+	HandleSleep(ctx *event.Context, pos cube.Pos)
 	// HandleSkinChange handles the player changing their skin. ctx.Cancel() may be called to cancel the skin
 	// change.
 	HandleSkinChange(ctx *event.Context, skin *skin.Skin)
@@ -172,4 +175,5 @@ func (NopHandler) HandleHeal(*event.Context, *float64, world.HealingSource)
 func (NopHandler) HandleFoodLoss(*event.Context, int, *int)                                   {}
 func (NopHandler) HandleDeath(world.DamageSource, *bool)                                      {}
 func (NopHandler) HandleRespawn(*mgl64.Vec3, **world.World)                                   {}
+func (NopHandler) HandleSleep(*event.Context, cube.Pos)                                       {}
 func (NopHandler) HandleQuit()                                                                {}
@@ -2,9 +2,11 @@ package player
 
 import (
 	"fmt"
+	"image/color"
 	"math"
 	"math/rand"
 	"net"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +17,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/model"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
@@ -79,10 +82,25 @@ type Player struct {
 	// lastTickedWorld holds the world that the player was in, in the last tick.
 	lastTickedWorld *world.World
 
-	speed      atomic.Float64
-	health     *entity.HealthManager
-	experience *entity.ExperienceManager
-	effects    *entity.EffectManager
+	// portal transports the player through the portals that it stands in.
+	portal entity.PortalTravel
+
+	// riding holds the entity that the player is currently riding, such as a minecart. It is nil if the player is
+	// not riding any entity.
+	riding atomic.Value[world.Entity]
+	// sleeping is true if the player is currently sleeping in a bed. sleepPos holds the position of the head of that
+	// bed.
+	sleeping atomic.Bool
+	sleepPos atomic.Value[cube.Pos]
+
+	attributes *attribute.Manager
+	// equipment holds the held item and armour that the equipmentModifiers were computed for. equipmentModifiers
+	// holds the attribute modifiers currently applied by the held item and armour of the player.
+	equipment          atomic.Value[[]item.Stack]
+	equipmentModifiers atomic.Value[[]attribute.Modifier]
+	health             *entity.HealthManager
+	experience         *entity.ExperienceManager
+	effects            *entity.EffectManager
 
 	lastXPPickup  atomic.Value[time.Time]
 	immunityTicks atomic.Int64
@@ -125,11 +143,11 @@ func New(name string, skin skin.Skin, pos mgl64.Vec3) *Player {
 		health:            entity.NewHealthManager(20, 20),
 		experience:        entity.NewExperienceManager(),
 		effects:           entity.NewEffectManager(),
+		attributes:        attribute.NewManager(p.updateAttribute),
 		gameMode:          *atomic.NewValue[world.GameMode](world.GameModeSurvival),
 		h:                 *atomic.NewValue[Handler](NopHandler{}),
 		name:              name,
 		skin:              *atomic.NewValue(skin),
-		speed:             *atomic.NewFloat64(0.1),
 		nameTag:           *atomic.NewValue(name),
 		heldSlot:          atomic.NewUint32(0),
 		locale:            language.BritishEnglish,
@@ -276,6 +294,12 @@ func (p *Player) Messagef(f string, a ...any) {
 	p.session().SendMessage(fmt.Sprintf(f, a...))
 }
 
+// Messaget sends a translatable message to the player. The message is translated client-side using the translation
+// key passed, with the parameters passed filled in. The message is shown in the language of the player.
+func (p *Player) Messaget(key string, a ...string) {
+	p.session().SendTranslation(key, a...)
+}
+
 // SendPopup sends a formatted popup to the player. The popup is shown above the hotbar of the player and
 // overwrites/is overwritten by the name of the item equipped.
 // The popup is formatted following the rules of fmt.Sprintln without a newline at the end.
@@ -461,17 +485,52 @@ func (p *Player) ScoreTag() string {
 	return p.scoreTag.Load()
 }
 
-// SetSpeed sets the speed of the player. The value passed is the blocks/tick speed that the player will then
-// obtain.
+// SetSpeed sets the base speed of the player. The value passed is the blocks/tick speed that the player will then
+// obtain, before modifiers such as those of sprinting and the Speed effect are applied.
 func (p *Player) SetSpeed(speed float64) {
-	p.speed.Store(speed)
-	p.session().SendSpeed(speed)
+	p.attributes.SetBase(attribute.MovementSpeed(), speed)
 }
 
 // Speed returns the speed of the player, returning a value that indicates the blocks/tick speed. The default
 // speed of a player is 0.1.
 func (p *Player) Speed() float64 {
-	return p.speed.Load()
+	return p.attributes.Value(attribute.MovementSpeed())
+}
+
+// Attributes returns the attribute.Manager holding the attributes of the player, such as its movement speed and
+// attack damage. Changes to the attributes are sent to the player.
+func (p *Player) Attributes() *attribute.Manager {
+	return p.attributes
+}
+
+// updateAttribute is called when the value of an attribute of the player changes. It sends the new value to the
+// player.
+func (p *Player) updateAttribute(a attribute.Attribute, _, after float64) {
+	if a == attribute.MaxHealth() {
+		p.health.SetMaxHealth(after)
+		p.session().SendHealth(p.health)
+		return
+	}
+	p.session().SendAttribute(a, after)
+}
+
+// updateEquipmentModifiers replaces the attribute modifiers of the equipment of the player with those of the item
+// it is currently holding and the armour it is currently wearing. The modifiers are only recomputed if the type of
+// the held item or armour changed since the last call.
+func (p *Player) updateEquipmentModifiers() {
+	held, _ := p.HeldItems()
+	equipment := append([]item.Stack{held}, p.armour.Slots()...)
+	if slices.EqualFunc(p.equipment.Load(), equipment, func(a, b item.Stack) bool {
+		return a.Comparable(b) && a.Empty() == b.Empty()
+	}) {
+		return
+	}
+	p.equipment.Store(equipment)
+
+	mods := append(held.AttributeModifiers(), p.armour.AttributeModifiers()...)
+	if prev := p.equipmentModifiers.Swap(mods); !slices.Equal(prev, mods) {
+		p.attributes.SwapModifiers(prev, mods)
+	}
 }
 
 // Health returns the current health of the player. It will always be lower than Player.MaxHealth().
@@ -485,12 +544,11 @@ func (p *Player) MaxHealth() float64 {
 	return p.health.MaxHealth()
 }
 
-// SetMaxHealth sets the maximum health of the player. If the current health of the player is higher than the
-// new maximum health, the health is set to the new maximum.
-// SetMaxHealth panics if the max health passed is 0 or lower.
+// SetMaxHealth sets the base maximum health of the player. If the current health of the player is higher than
+// the new maximum health, the health is set to the new maximum. Modifiers, such as that of the HealthBoost effect,
+// are applied on top of the base maximum health.
 func (p *Player) SetMaxHealth(health float64) {
-	p.health.SetMaxHealth(health)
-	p.session().SendHealth(p.health)
+	p.attributes.SetBase(attribute.MaxHealth(), health)
 }
 
 // addHealth adds health to the player's current health.
@@ -554,6 +612,30 @@ func (p *Player) fall(distance float64) {
 	p.Hurt(math.Ceil(dmg), entity.FallDamageSource{})
 }
 
+// damageEnabled checks if the game rules of the world of the Player allow it to
+// take damage from the world.DamageSource passed.
+func (p *Player) damageEnabled(src world.DamageSource) bool {
+	w := p.World()
+	if src.Fire() && !w.BoolGameRule(world.GameRuleFireDamage) {
+		return false
+	}
+	switch s := src.(type) {
+	case entity.FallDamageSource:
+		return w.BoolGameRule(world.GameRuleFallDamage)
+	case entity.DrowningDamageSource:
+		return w.BoolGameRule(world.GameRuleDrowningDamage)
+	case entity.AttackDamageSource:
+		if _, ok := s.Attacker.(*Player); ok {
+			return w.BoolGameRule(world.GameRulePVP)
+		}
+	case entity.ProjectileDamageSource:
+		if _, ok := s.Owner.(*Player); ok {
+			return w.BoolGameRule(world.GameRulePVP)
+		}
+	}
+	return true
+}
+
 // Hurt hurts the player for a given amount of damage. The source passed
 // represents the cause of the damage, for example entity.AttackDamageSource if
 // the player is attacked by another entity. If the final damage exceeds the
@@ -566,6 +648,9 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	if _, ok := p.Effect(effect.FireResistance{}); (ok && src.Fire()) || p.Dead() || !p.GameMode().AllowsTakingDamage() {
 		return 0, false
 	}
+	if !p.damageEnabled(src) {
+		return 0, false
+	}
 	immunity := time.Second / 2
 	ctx := event.C()
 	if p.Handler().HandleHurt(ctx, &dmg, &immunity, src); ctx.Cancelled() {
@@ -574,6 +659,7 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	if dmg < 0 {
 		return 0, true
 	}
+	p.Wake()
 
 	totalDamage := p.FinalDamageFrom(dmg, src)
 	damageLeft := totalDamage
@@ -713,7 +799,8 @@ func (p *Player) knockBack(src mgl64.Vec3, force, height float64) {
 	}
 	velocity[1] = height
 
-	p.SetVelocity(velocity.Mul(1 - p.Armour().KnockBackResistance()))
+	p.updateEquipmentModifiers()
+	p.SetVelocity(velocity.Mul(1 - p.attributes.Value(attribute.KnockBackResistance())))
 }
 
 // AttackImmune checks if the player is currently immune to entity attacks, meaning it was recently attacked.
@@ -848,8 +935,10 @@ func (p *Player) kill(src world.DamageSource) {
 
 	p.addHealth(-p.MaxHealth())
 
-	keepInv := false
+	keepInv := p.World().BoolGameRule(world.GameRuleKeepInventory)
 	p.Handler().HandleDeath(src, &keepInv)
+	p.Wake()
+	p.Dismount()
 	p.StopSneaking()
 	p.StopSprinting()
 
@@ -918,7 +1007,7 @@ func (p *Player) Respawn() {
 	// We can use the principle here that returning through a portal of a specific dimension inside that dimension will
 	// always bring us back to the overworld.
 	w = w.PortalDestination(w.Dimension())
-	pos := w.PlayerSpawn(p.UUID()).Vec3Middle()
+	pos := p.spawnPosition(w)
 
 	p.Handler().HandleRespawn(&pos, &w)
 
@@ -929,6 +1018,32 @@ func (p *Player) Respawn() {
 	p.SetVisible()
 }
 
+// spawnPosition returns the position in the World passed at which the player respawns. Players that set their spawn
+// with a bed respawn next to it. If the bed was destroyed or is obstructed, the spawn of the player is reset to the
+// spawn of the World.
+func (p *Player) spawnPosition(w *world.World) mgl64.Vec3 {
+	spawn := w.PlayerSpawn(p.UUID())
+	if spawn == w.Spawn() {
+		return spawn.Vec3Middle()
+	}
+	if b, ok := w.Block(spawn).(block.Bed); ok {
+		if pos, ok := b.StandUpPosition(spawn, w); ok {
+			return pos
+		}
+	}
+	w.SetPlayerSpawn(p.UUID(), w.Spawn())
+	return w.Spawn().Vec3Middle()
+}
+
+// sprintingModifier is the attribute.Modifier applied to the movement speed of a player while it is sprinting.
+var sprintingModifier = attribute.Modifier{
+	ID:        uuid.MustParse("662a6b8d-da3e-4c1c-8813-96ea6097278d"),
+	Name:      "Sprinting speed boost",
+	Attribute: attribute.MovementSpeed(),
+	Amount:    0.3,
+	Operation: attribute.OperationMultiply(),
+}
+
 // StartSprinting makes a player start sprinting, increasing the speed of the player by 30% and making
 // particles show up under the feet. The player will only start sprinting if its food level is high enough.
 // If the player is sneaking when calling StartSprinting, it is stopped from sneaking.
@@ -944,7 +1059,7 @@ func (p *Player) StartSprinting() {
 		return
 	}
 	p.StopSneaking()
-	p.SetSpeed(p.Speed() * 1.3)
+	p.attributes.AddModifier(sprintingModifier)
 
 	p.updateState()
 }
@@ -963,7 +1078,7 @@ func (p *Player) StopSprinting() {
 	if !p.sprinting.CAS(true, false) {
 		return
 	}
-	p.SetSpeed(p.Speed() / 1.3)
+	p.attributes.RemoveModifier(attribute.MovementSpeed(), sprintingModifier.ID)
 
 	p.updateState()
 }
@@ -1420,9 +1535,9 @@ func (p *Player) UsingItem() bool {
 // UseItemOnBlock does nothing if the block at the cube.Pos passed is of the type block.Air.
 func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3) {
 	w := p.World()
-	if _, ok := w.Block(pos).(block.Air); ok || !p.canReach(pos.Vec3Centre()) {
-		// The client used its item on a block that does not exist server-side or one it couldn't reach. Stop trying
-		// to use the item immediately.
+	if _, ok := w.Block(pos).(block.Air); ok || !p.canReach(pos.Vec3Centre()) || !w.Border().ContainsBlock(pos) {
+		// The client used its item on a block that does not exist server-side, one it couldn't reach or one outside
+		// the world border. Stop trying to use the item immediately.
 		p.resendBlocks(pos, w, face)
 		return
 	}
@@ -1489,6 +1604,10 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 	if p.Handler().HandleItemUseOnEntity(ctx, e); ctx.Cancelled() {
 		return false
 	}
+	if _, rideable := rideable(e); rideable && !p.Sneaking() {
+		p.Mount(e)
+		return true
+	}
 	i, left := p.HeldItems()
 	usable, ok := i.Item().(item.UsableOnEntity)
 	if !ok {
@@ -1527,28 +1646,29 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	p.SwingArm()
 
 	i, _ := p.HeldItems()
+	p.updateEquipmentModifiers()
+	dmg := p.attributes.Value(attribute.AttackDamage())
+	if s, ok := i.Enchantment(enchantment.Sharpness{}); ok {
+		dmg += (enchantment.Sharpness{}).Addend(s.Level())
+	}
+	if critical {
+		dmg *= 1.5
+	}
+
 	living, ok := e.(entity.Living)
 	if !ok {
+		if ent, ok := e.(*entity.Ent); ok {
+			if h, ok := ent.Behaviour().(entity.Hittable); ok {
+				h.Hit(ent, p, dmg)
+				return true
+			}
+		}
 		return false
 	}
 	if living.AttackImmune() {
 		return true
 	}
 
-	dmg := i.AttackDamage()
-	if strength, ok := p.Effect(effect.Strength{}); ok {
-		dmg += dmg * effect.Strength{}.Multiplier(strength.Level())
-	}
-	if weakness, ok := p.Effect(effect.Weakness{}); ok {
-		dmg -= dmg * effect.Weakness{}.Multiplier(weakness.Level())
-	}
-	if s, ok := i.Enchantment(enchantment.Sharpness{}); ok {
-		dmg += (enchantment.Sharpness{}).Addend(s.Level())
-	}
-	if critical {
-		dmg *= 1.5
-	}
-
 	n, vulnerable := living.Hurt(dmg, entity.AttackDamageSource{Attacker: p})
 	i, left := p.HeldItems()
 
@@ -1730,7 +1850,7 @@ func (p *Player) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
 // of the player. A bool is returned indicating if a block was placed successfully.
 func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
 	w := p.World()
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() || !w.Border().ContainsBlock(pos) {
 		p.resendBlocks(pos, w, cube.Faces()...)
 		return false
 	}
@@ -1841,21 +1961,22 @@ func (p *Player) drops(held item.Stack, b world.Block) []item.Stack {
 		t = item.ToolNone{}
 	}
 	var drops []item.Stack
+	tileDrops := p.World().BoolGameRule(world.GameRuleDoTileDrops)
 	if container, ok := b.(block.Container); ok {
 		// If the block is a container, it should drop its inventory contents regardless whether the
-		// player is in creative mode or not.
+		// player is in creative mode or not, or whether tile drops are enabled.
 		drops = container.Inventory().Items()
-		if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() {
+		if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() && tileDrops {
 			if breakable.BreakInfo().Harvestable(t) {
 				drops = append(drops, breakable.BreakInfo().Drops(t, held.Enchantments())...)
 			}
 		}
 		container.Inventory().Clear()
-	} else if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() {
+	} else if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() && tileDrops {
 		if breakable.BreakInfo().Harvestable(t) {
 			drops = breakable.BreakInfo().Drops(t, held.Enchantments())
 		}
-	} else if it, ok := b.(world.Item); ok && !p.GameMode().CreativeInventory() {
+	} else if it, ok := b.(world.Item); ok && !p.GameMode().CreativeInventory() && tileDrops {
 		drops = []item.Stack{item.NewStack(it, 1)}
 	}
 	return drops
@@ -1914,6 +2035,155 @@ func (p *Player) PickBlock(pos cube.Pos) {
 	p.SetHeldItems(pickedItem, offhand)
 }
 
+// Mount makes the player start riding the entity passed, such as a minecart. Nothing happens if the entity cannot be
+// ridden or if another entity is already riding it. If the player was riding another entity, it stops riding that
+// entity first.
+func (p *Player) Mount(e world.Entity) {
+	r, ok := rideable(e)
+	if !ok || p.Dead() {
+		return
+	}
+	if riding, ok := p.Riding(); ok {
+		if riding == e {
+			return
+		}
+		p.Dismount()
+	}
+	if !r.AddRider(p) {
+		return
+	}
+	p.Wake()
+	p.riding.Store(e)
+	p.StopSprinting()
+	p.StopSwimming()
+	p.StopGliding()
+	p.pos.Store(e.Position())
+	for _, v := range p.viewers() {
+		v.ViewEntityRide(p, e, true)
+	}
+}
+
+// Dismount makes the player stop riding the entity that it is currently riding. Nothing happens if the player is
+// not riding any entity.
+func (p *Player) Dismount() {
+	e := p.riding.Swap(nil)
+	if e == nil {
+		return
+	}
+	if r, ok := rideable(e); ok {
+		r.RemoveRider(p)
+	}
+	for _, v := range p.viewers() {
+		v.ViewEntityRide(p, e, false)
+	}
+	if p.Dead() {
+		return
+	}
+	p.teleport(e.Position().Add(mgl64.Vec3{0, e.Type().BBox(e).Height()}))
+}
+
+// Riding returns the entity that the player is currently riding. False is returned if the player is not riding any
+// entity.
+func (p *Player) Riding() (world.Entity, bool) {
+	e := p.riding.Load()
+	return e, e != nil
+}
+
+// tickRiding keeps the position of the player in sync with the entity that it is riding. If that entity was removed
+// from the world of the player, the player stops riding it.
+func (p *Player) tickRiding(w *world.World) {
+	e, ok := p.Riding()
+	if !ok {
+		return
+	}
+	if ew, ok := world.OfEntity(e); !ok || ew != w {
+		p.Dismount()
+		return
+	}
+	p.pos.Store(e.Position())
+}
+
+// rideable returns the entity.Rideable behaviour of the entity passed. False is returned if the entity cannot be
+// ridden.
+func rideable(e world.Entity) (entity.Rideable, bool) {
+	if ent, ok := e.(*entity.Ent); ok {
+		r, ok := ent.Behaviour().(entity.Rideable)
+		return r, ok
+	}
+	return nil, false
+}
+
+// Sleep makes the player sleep in the bed at the position passed, which should be the position of the head of the
+// bed. The player is moved onto the bed and the bed is marked as occupied. Sleep does not check if it is night or if
+// monsters are close to the bed: These checks are performed when the player uses a bed. Nothing happens if there is
+// no bed at the position passed or if the player is already sleeping.
+func (p *Player) Sleep(pos cube.Pos) {
+	w := p.World()
+	b, ok := w.Block(pos).(block.Bed)
+	if !ok || p.Dead() || p.sleeping.Load() {
+		return
+	}
+	ctx := event.C()
+	if p.Handler().HandleSleep(ctx, pos); ctx.Cancelled() {
+		return
+	}
+	p.sleepPos.Store(pos)
+	if !p.sleeping.CAS(false, true) {
+		return
+	}
+	p.Dismount()
+	p.StopSprinting()
+	p.StopSneaking()
+
+	b.Occupied = true
+	w.SetBlock(pos, b, nil)
+	w.StartSleeping(p)
+	p.teleport(pos.Vec3Middle().Add(mgl64.Vec3{0, 0.5625}))
+	p.updateState()
+}
+
+// Wake makes the player wake up and get out of the bed that it is sleeping in. The player is moved to a free position
+// next to the bed if there is one. Nothing happens if the player is not sleeping.
+func (p *Player) Wake() {
+	if !p.sleeping.CAS(true, false) {
+		return
+	}
+	w, pos := p.World(), p.sleepPos.Load()
+	w.StopSleeping(p)
+	if b, ok := w.Block(pos).(block.Bed); ok {
+		b.Occupied = false
+		w.SetBlock(pos, b, nil)
+		if standUp, ok := b.StandUpPosition(pos, w); ok {
+			p.teleport(standUp)
+		}
+	}
+	for _, v := range p.viewers() {
+		v.ViewEntityAction(p, entity.WakeUpAction{})
+	}
+	p.updateState()
+}
+
+// Sleeping returns the position of the head of the bed that the player is sleeping in. False is returned if the
+// player is not sleeping.
+func (p *Player) Sleeping() (cube.Pos, bool) {
+	if !p.sleeping.Load() {
+		return cube.Pos{}, false
+	}
+	return p.sleepPos.Load(), true
+}
+
+// tickSleeping wakes the player up if the bed that it is sleeping in was removed or if it is no longer possible to
+// sleep in it, for example because the night has passed.
+func (p *Player) tickSleeping(w *world.World) {
+	pos, ok := p.Sleeping()
+	if !ok {
+		return
+	}
+	if _, ok := w.Block(pos).(block.Bed); !ok || !w.CanSleepAt(pos) {
+		p.Wake()
+	}
+}
+
 // Teleport teleports the player to a target position in the world. Unlike Move, it immediately changes the
 // position of the player, rather than showing an animation.
 func (p *Player) Teleport(pos mgl64.Vec3) {
@@ -1921,6 +2191,8 @@ func (p *Player) Teleport(pos mgl64.Vec3) {
 	if p.Handler().HandleTeleport(ctx, pos); ctx.Cancelled() {
 		return
 	}
+	p.Wake()
+	p.Dismount()
 	p.teleport(pos)
 }
 
@@ -1942,9 +2214,9 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 	if p.Dead() || (deltaPos.ApproxEqual(mgl64.Vec3{}) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0)) {
 		return
 	}
-	if p.immobile.Load() {
+	if _, riding := p.Riding(); riding || p.immobile.Load() {
 		if mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0) {
-			// If only the position was changed, don't continue with the movement when immobile.
+			// If only the position was changed, don't continue with the movement when immobile or riding an entity.
 			return
 		}
 		// Still update rotation if it was changed.
@@ -1955,7 +2227,14 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 		pos                   = p.Position()
 		yaw, pitch            = p.Rotation().Elem()
 		res, resYaw, resPitch = pos.Add(deltaPos), yaw + deltaYaw, pitch + deltaPitch
+		border                = w.Border()
+		outOfBorder           = border.Contains(pos) && !border.Contains(res)
 	)
+	if outOfBorder {
+		// Players inside the world border may not move out of it, so stop the player at the border.
+		res = border.Clamp(res)
+		deltaPos = res.Sub(pos)
+	}
 	ctx := event.C()
 	if p.Handler().HandleMove(ctx, res, resYaw, resPitch); ctx.Cancelled() {
 		if p.session() != session.Nop && pos.ApproxEqual(p.Position()) {
@@ -1972,6 +2251,9 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 	p.pos.Store(res)
 	p.yaw.Store(resYaw)
 	p.pitch.Store(resPitch)
+	if outOfBorder {
+		p.session().ViewEntityTeleport(p, res)
+	}
 	if deltaPos.Len() <= 3 {
 		// Only update velocity if the player is not moving too fast to prevent potential OOMs.
 		p.vel.Store(deltaPos)
@@ -2247,6 +2529,8 @@ func (p *Player) Tick(w *world.World, current int64) {
 		p.Handler().HandleChangeWorld(p.lastTickedWorld, w)
 	}
 	p.lastTickedWorld = w
+	p.tickRiding(w)
+	p.tickSleeping(w)
 	if _, ok := w.Liquid(cube.PosFromVec3(p.Position())); !ok {
 		p.StopSwimming()
 		if _, ok := p.Armour().Helmet().Item().(item.TurtleShell); ok {
@@ -2268,6 +2552,7 @@ func (p *Player) Tick(w *world.World, current int64) {
 	p.onGround.Store(p.checkOnGround(w))
 
 	p.effects.Tick(p)
+	p.updateEquipmentModifiers()
 
 	p.tickFood(w)
 	p.tickAirSupply(w)
@@ -2280,6 +2565,8 @@ func (p *Player) Tick(w *world.World, current int64) {
 	if !p.AttackImmune() && p.insideOfSolid(w) {
 		p.Hurt(1, entity.SuffocationDamageSource{})
 	}
+	p.tickBorder(w, current)
+	p.tickPortal(w)
 
 	if p.OnFireDuration() > 0 {
 		p.fireTicks.Sub(1)
@@ -2320,6 +2607,75 @@ func (p *Player) Tick(w *world.World, current int64) {
 	}
 }
 
+// tickPortal transports the player through a portal that it is standing in. Players have to stand in a nether
+// portal for four seconds before being transported, unless they cannot take damage, such as players in creative
+// mode.
+func (p *Player) tickPortal(w *world.World) {
+	delay := int64(80)
+	if !p.GameMode().AllowsTakingDamage() {
+		delay = 1
+	}
+	p.portal.Tick(p, w, delay, p.travel)
+}
+
+// travel teleports the player to the position passed in the world passed, after using a portal. If the Handler of
+// the player cancels the teleport, the player stays in its current world.
+func (p *Player) travel(dest *world.World, pos mgl64.Vec3) {
+	ctx := event.C()
+	if p.Handler().HandleTeleport(ctx, pos); ctx.Cancelled() {
+		return
+	}
+	p.Wake()
+	p.Dismount()
+	if dest != p.World() {
+		dest.AddEntity(p)
+	}
+	p.teleport(pos)
+}
+
+// tickBorder damages the player if it is outside the world border of the World passed, beyond its damage buffer, and
+// shows the border to the player if it is close to it.
+func (p *Player) tickBorder(w *world.World, current int64) {
+	b := w.Border()
+	dist := b.Distance(p.Position())
+	if d := dist + b.DamageBuffer(); d < 0 && current%10 == 0 && p.GameMode().AllowsTakingDamage() {
+		if dmg := b.DamagePerBlock(); dmg > 0 {
+			p.Hurt(math.Max(1, math.Floor(-d*dmg)), entity.BorderDamageSource{})
+		}
+	}
+	if dist < b.WarningDistance() && current%5 == 0 && p.session() != session.Nop {
+		p.showBorder(b)
+	}
+}
+
+// showBorder shows the part of the world.Border passed close to the player by showing particles along its edges.
+func (p *Player) showBorder(b *world.Border) {
+	pos := p.Position()
+	minimum, maximum := b.Bounds()
+	dist := b.WarningDistance()
+	colour := color.RGBA{R: 0xff, G: 0x30, B: 0x30, A: 0xff}
+	for _, edge := range [...]struct {
+		x    bool
+		v, d float64
+	}{
+		{x: true, v: minimum[0], d: pos[0] - minimum[0]}, {x: true, v: maximum[0], d: maximum[0] - pos[0]},
+		{v: minimum[1], d: pos[2] - minimum[1]}, {v: maximum[1], d: maximum[1] - pos[2]},
+	} {
+		if math.Abs(edge.d) > dist {
+			continue
+		}
+		for i := -3.0; i <= 3; i++ {
+			for y := -1.0; y <= 3; y++ {
+				particlePos := mgl64.Vec3{pos[0] + i, math.Floor(pos[1]) + y, edge.v}
+				if edge.x {
+					particlePos = mgl64.Vec3{edge.v, math.Floor(pos[1]) + y, pos[2] + i}
+				}
+				p.ShowParticle(particlePos, particle.Dust{Colour: colour})
+			}
+		}
+	}
+}
+
 // tickAirSupply tick's the player's air supply, consuming it when underwater, and replenishing it when out of water.
 func (p *Player) tickAirSupply(w *world.World) {
 	if !p.canBreathe(w) {
@@ -2377,7 +2733,7 @@ func (p *Player) tickFood(w *world.World) {
 
 // regenerate attempts to regenerate half a heart of health, typically caused by a full food bar.
 func (p *Player) regenerate(exhaust bool) {
-	if p.Health() == p.MaxHealth() {
+	if p.Health() == p.MaxHealth() || !p.World().BoolGameRule(world.GameRuleNaturalRegeneration) {
 		return
 	}
 	p.Heal(1, entity.FoodHealingSource{})
@@ -2806,6 +3162,8 @@ func (p *Player) close(msg string) {
 		p.Respawn()
 	}
 	p.h.Swap(NopHandler{}).HandleQuit()
+	p.Wake()
+	p.Dismount()
 
 	if s := p.s.Swap(nil); s != nil {
 		s.Disconnect(msg)
@@ -2823,7 +3181,7 @@ func (p *Player) load(data Data) {
 	p.yaw.Store(data.Yaw)
 	p.pitch.Store(data.Pitch)
 
-	p.health.SetMaxHealth(data.MaxHealth)
+	p.attributes.SetBase(attribute.MaxHealth(), data.MaxHealth)
 	p.health.AddHealth(data.Health - p.Health())
 	p.session().SendHealth(p.health)
 
@@ -2882,7 +3240,7 @@ func (p *Player) Data() Data {
 		Yaw:             yaw,
 		Pitch:           pitch,
 		Health:          p.Health(),
-		MaxHealth:       p.MaxHealth(),
+		MaxHealth:       p.attributes.Base(attribute.MaxHealth()),
 		Hunger:          p.hunger.foodLevel,
 		Experience:      p.Experience(),
 		EnchantmentSeed: p.EnchantmentSeed(),
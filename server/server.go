@@ -34,6 +34,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 )
 
 // Server implements a Dragonfly server. It runs the main server loop and
@@ -45,12 +46,14 @@ type Server struct {
 	started atomic.Bool
 
 	world, nether, end *world.World
+	worlds             *WorldManager
 
 	customBlocks []protocol.BlockEntry
 	customItems  []protocol.ItemComponentEntry
 
 	listeners []Listener
 	incoming  chan *session.Session
+	closing   chan struct{}
 
 	pmu sync.RWMutex
 	// p holds a map of all players currently connected to the server. When they
@@ -88,6 +91,9 @@ func (srv *Server) Listen() {
 	srv.conf.Log.Infof("Starting Dragonfly for Minecraft v%v...", protocol.CurrentVersion)
 	srv.startListening()
 	go srv.wait()
+	if srv.conf.AutosaveInterval > 0 {
+		go srv.autosave()
+	}
 }
 
 // Accept accepts an incoming player into the server. It blocks until a player
@@ -133,6 +139,13 @@ func (srv *Server) End() *world.World {
 	return srv.end
 }
 
+// Worlds returns the WorldManager of the server, which may be used to create,
+// load, save and unload worlds other than the default overworld, nether and
+// end, and to look up worlds by their name.
+func (srv *Server) Worlds() *WorldManager {
+	return srv.worlds
+}
+
 // MaxPlayerCount returns the maximum amount of players that are allowed to
 // play on the server at the same time. Players trying to join when the server
 // is full will be refused to enter. If the config has a maximum player count
@@ -208,6 +221,7 @@ func (srv *Server) Close() error {
 func (srv *Server) close() {
 	srv.conf.Log.Infof("Server shutting down...")
 	defer srv.conf.Log.Infof("Server stopped.")
+	close(srv.closing)
 
 	srv.conf.Log.Debugf("Disconnecting players...")
 	for _, p := range srv.Players() {
@@ -221,6 +235,7 @@ func (srv *Server) close() {
 	}
 
 	srv.conf.Log.Debugf("Closing worlds...")
+	srv.worlds.close()
 	for _, w := range []*world.World{srv.end, srv.nether, srv.world} {
 		if err := w.Close(); err != nil {
 			srv.conf.Log.Errorf("Error closing %v: %v", w.Dimension(), err)
@@ -235,6 +250,26 @@ func (srv *Server) close() {
 	}
 }
 
+// autosave saves the data of all online players every AutosaveInterval of the
+// Config until the Server is closed. Worlds are saved by themselves.
+func (srv *Server) autosave() {
+	t := time.NewTicker(srv.conf.AutosaveInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			srv.conf.Log.Debugf("Autosaving player data...")
+			for _, p := range srv.Players() {
+				if err := srv.conf.PlayerProvider.Save(p.UUID(), p.Data()); err != nil {
+					srv.conf.Log.Errorf("Error while saving data: %v", err)
+				}
+			}
+		case <-srv.closing:
+			return
+		}
+	}
+}
+
 // listen makes the Server listen for new connections from the Listener passed.
 // This may be used to listen for players on different interfaces. Note that
 // the maximum player count of additional Listeners added is not enforced
@@ -451,9 +486,9 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, data *player.Da
 }
 
 // createWorld loads a world of the server with a specific dimension, ending
-// the program if the world could not be loaded. The layers passed are used to
-// create a generator.Flat that is used as generator for the world.
-func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *world.World {
+// the program if the world could not be loaded. The world is registered in the
+// WorldManager of the server under the name passed.
+func (srv *Server) createWorld(name string, dim world.Dimension) *world.World {
 	logger := srv.conf.Log
 	if v, ok := logger.(interface {
 		WithField(key string, field any) *logrus.Entry
@@ -465,24 +500,20 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 	}
 	logger.Debugf("Loading world...")
 
-	conf := world.Config{
-		Log:             logger,
-		Dim:             dim,
-		Provider:        srv.conf.WorldProvider,
-		Generator:       srv.conf.Generator(dim),
-		RandomTickSpeed: srv.conf.RandomTickSpeed,
-		ReadOnly:        srv.conf.ReadOnlyWorld,
-		Entities:        srv.conf.Entities,
-		PortalDestination: func(dim world.Dimension) *world.World {
-			if dim == world.Nether {
-				return *nether
-			} else if dim == world.End {
-				return *end
-			}
-			return nil
-		},
+	// The Server closes its WorldProvider when its default worlds are closed.
+	w, err := srv.worlds.register(name, world.Config{
+		Log:              logger,
+		Dim:              dim,
+		Provider:         srv.conf.WorldProvider,
+		Generator:        srv.conf.Generator(dim),
+		RandomTickSpeed:  srv.conf.RandomTickSpeed,
+		ReadOnly:         srv.conf.ReadOnlyWorld,
+		Entities:         srv.conf.Entities,
+		AutosaveInterval: srv.conf.AutosaveInterval,
+	}, true)
+	if err != nil {
+		logger.Fatalf("Error creating world: %v", err)
 	}
-	w := conf.New()
 	logger.Infof(`Opened world "%v".`, w.Name())
 	return w
 }
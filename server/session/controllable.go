@@ -3,6 +3,7 @@ package session
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
@@ -17,7 +18,9 @@ import (
 
 // Controllable represents an entity that may be controlled by a Session. Generally, Controllable is
 // implemented in the form of a Player.
-// Methods in Controllable will be added as Session needs them in order to handle packets.
+// Methods in Controllable will be added as Session needs them in order to handle packets. Note that implementations
+// other than Player must now also implement Attributes, which is used to send the attributes of the entity to the
+// client, and Dismount and Wake, which are called when the client leaves the entity it rides or the bed it sleeps in.
 type Controllable interface {
 	Name() string
 	world.Entity
@@ -32,6 +35,7 @@ type Controllable interface {
 
 	Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
 	Speed() float64
+	Attributes() *attribute.Manager
 
 	Chat(msg ...any)
 	ExecuteCommand(commandLine string)
@@ -75,6 +79,8 @@ type Controllable interface {
 	Gliding() bool
 	StopGliding()
 	Jump()
+	Dismount()
+	Wake()
 
 	StartBreaking(pos cube.Pos, face cube.Face)
 	ContinueBreaking(face cube.Face)
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
@@ -34,7 +35,7 @@ func (s *Session) parseEntityMetadata(e world.Entity) protocol.EntityMetadata {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagLingering)
 	}
 	s.addSpecificMetadata(e, m)
-	if ent, ok := e.(*entity.Ent); ok {
+	if ent, ok := e.(interface{ Behaviour() entity.Behaviour }); ok {
 		s.addSpecificMetadata(ent.Behaviour(), m)
 	}
 	return m
@@ -53,6 +54,17 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if gl, ok := e.(glider); ok && gl.Gliding() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagGliding)
 	}
+	if r, ok := e.(rider); ok {
+		if _, riding := r.Riding(); riding {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagRiding)
+		}
+	}
+	if sl, ok := e.(sleeper); ok {
+		if pos, sleeping := sl.Sleeping(); sleeping {
+			m[protocol.EntityDataKeyBedPosition] = protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+			m.SetFlag(protocol.EntityDataKeyPlayerFlags, playerFlagSleeping)
+		}
+	}
 	if b, ok := e.(breather); ok {
 		m[protocol.EntityDataKeyAirSupply] = int16(b.AirSupply().Milliseconds() / 50)
 		m[protocol.EntityDataKeyAirSupplyMax] = int16(b.MaxAirSupply().Milliseconds() / 50)
@@ -182,6 +194,18 @@ type glider interface {
 	Gliding() bool
 }
 
+type rider interface {
+	Riding() (world.Entity, bool)
+}
+
+type sleeper interface {
+	Sleeping() (cube.Pos, bool)
+}
+
+// playerFlagSleeping is the index of the flag in protocol.EntityDataKeyPlayerFlags that is set while a player is
+// sleeping in a bed.
+const playerFlagSleeping = 1
+
 type breather interface {
 	Breathing() bool
 	AirSupply() time.Duration
@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/atomic"
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
@@ -234,6 +235,10 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 			} else if _, enderChest := b.(block.EnderChest); enderChest {
 				return s.openedWindow.Load(), true
 			}
+			switch b.(type) {
+			case block.Hopper, block.Dispenser, block.Dropper:
+				return s.openedWindow.Load(), true
+			}
 		}
 	case protocol.ContainerBarrel:
 		if s.containerOpened.Load() {
@@ -307,16 +312,28 @@ func (s *Session) Disconnect(message string) {
 }
 
 // SendSpeed sends the speed of the player in an UpdateAttributes packet, so that it is updated client-side.
+//
+// Deprecated: Use SendAttribute with attribute.MovementSpeed instead.
 func (s *Session) SendSpeed(speed float64) {
+	s.SendAttribute(attribute.MovementSpeed(), speed)
+}
+
+// SendAttribute sends the value of the attribute passed to the player in an UpdateAttributes packet, so that it is
+// updated client-side. The max health of the player is sent using SendHealth instead.
+func (s *Session) SendAttribute(a attribute.Attribute, v float64) {
+	if a == attribute.MaxHealth() {
+		return
+	}
 	s.writePacket(&packet.UpdateAttributes{
 		EntityRuntimeID: selfEntityRuntimeID,
 		Attributes: []protocol.Attribute{{
 			AttributeValue: protocol.AttributeValue{
-				Name:  "minecraft:movement",
-				Value: float32(speed),
-				Max:   math.MaxFloat32,
+				Name:  a.Name(),
+				Value: float32(v),
+				Min:   float32(a.Min()),
+				Max:   float32(a.Max()),
 			},
-			Default: 0.1,
+			Default: float32(a.Default()),
 		}},
 	})
 }
@@ -505,13 +522,25 @@ func (s *Session) sendGameRules(gameRules []protocol.GameRule) {
 // EnableCoordinates will either enable or disable coordinates for the player depending on the value given.
 func (s *Session) EnableCoordinates(enable bool) {
 	//noinspection SpellCheckingInspection
-	s.sendGameRules([]protocol.GameRule{{Name: "showcoordinates", Value: enable}})
+	s.overrideGameRule("showcoordinates", enable)
 }
 
 // EnableInstantRespawn will either enable or disable instant respawn for the player depending on the value given.
 func (s *Session) EnableInstantRespawn(enable bool) {
 	//noinspection SpellCheckingInspection
-	s.sendGameRules([]protocol.GameRule{{Name: "doimmediaterespawn", Value: enable}})
+	s.overrideGameRule("doimmediaterespawn", enable)
+}
+
+// overrideGameRule sets the value of a game rule for the player only and sends it to the player. The value is kept
+// when the game rules of the world of the player are sent to it.
+func (s *Session) overrideGameRule(name string, v any) {
+	s.gameRuleMu.Lock()
+	if s.gameRules == nil {
+		s.gameRules = make(map[string]any)
+	}
+	s.gameRules[name] = v
+	s.gameRuleMu.Unlock()
+	s.sendGameRules([]protocol.GameRule{{Name: name, Value: v}})
 }
 
 // addToPlayerList adds the player of a session to the player list of this session. It will be shown in the
@@ -7,6 +7,7 @@ import (
 	"github.com/df-mc/atomic"
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/attribute"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
@@ -81,6 +82,11 @@ type Session struct {
 
 	joinMessage, quitMessage string
 
+	// gameRuleMu guards gameRules, which holds the values of game rules that were changed for the controllable
+	// specifically, such as through EnableCoordinates. They take precedence over the game rules of its world.
+	gameRuleMu sync.Mutex
+	gameRules  map[string]any
+
 	closeBackground chan struct{}
 }
 
@@ -193,7 +199,9 @@ func (s *Session) Spawn(c Controllable, pos mgl64.Vec3, w *world.World, gm world
 
 	world_add(c, w)
 	s.c.SetGameMode(gm)
-	s.SendSpeed(0.1)
+	for _, a := range attribute.Attributes() {
+		s.SendAttribute(a, s.c.Attributes().Value(a))
+	}
 	for _, e := range s.c.Effects() {
 		s.SendEffect(e)
 	}
@@ -15,6 +15,16 @@ func (s *Session) SendMessage(message string) {
 	})
 }
 
+// SendTranslation ...
+func (s *Session) SendTranslation(key string, params ...string) {
+	s.writePacket(&packet.Text{
+		TextType:         packet.TextTypeTranslation,
+		NeedsTranslation: true,
+		Message:          key,
+		Parameters:       params,
+	})
+}
+
 // SendTip ...
 func (s *Session) SendTip(message string) {
 	s.writePacket(&packet.Text{
@@ -105,6 +105,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 			UUID:            v.UUID(),
 			Username:        v.Name(),
 			Yaw:             float32(yaw),
+			EntityLinks:     s.entityLinks(e),
 			AbilityData: protocol.AbilityData{
 				EntityUniqueID: int64(runtimeID),
 				Layers: []protocol.AbilityLayer{{
@@ -156,9 +157,48 @@ func (s *Session) ViewEntity(e world.Entity) {
 		Pitch:           float32(pitch),
 		Yaw:             float32(yaw),
 		HeadYaw:         float32(yaw),
+		EntityLinks:     s.entityLinks(e),
 	})
 }
 
+// entityLinks returns the links of the entity passed to the entity that it is riding and to the entity riding it.
+// Links to entities that are not viewed by the Session are left out.
+func (s *Session) entityLinks(e world.Entity) []protocol.EntityLink {
+	var links []protocol.EntityLink
+	if r, ok := e.(entity.Rider); ok {
+		if vehicle, ok := r.Riding(); ok {
+			if link, ok := s.entityLink(r, vehicle); ok {
+				links = append(links, link)
+			}
+		}
+	}
+	if ent, ok := e.(*entity.Ent); ok {
+		if r, ok := ent.Behaviour().(entity.Rideable); ok {
+			if rider, ok := r.Rider(); ok {
+				if link, ok := s.entityLink(rider, e); ok {
+					links = append(links, link)
+				}
+			}
+		}
+	}
+	return links
+}
+
+// entityLink creates an entity link between the rider and vehicle passed. False is returned if either of the two
+// entities is not viewed by the Session.
+func (s *Session) entityLink(rider, vehicle world.Entity) (protocol.EntityLink, bool) {
+	riderID, vehicleID := s.entityRuntimeID(rider), s.entityRuntimeID(vehicle)
+	if riderID == 0 || vehicleID == 0 {
+		return protocol.EntityLink{}, false
+	}
+	return protocol.EntityLink{
+		RiddenEntityUniqueID: int64(vehicleID),
+		RiderEntityUniqueID:  int64(riderID),
+		Type:                 protocol.EntityLinkRider,
+		RiderInitiated:       true,
+	}, true
+}
+
 // ViewEntityGameMode ...
 func (s *Session) ViewEntityGameMode(e world.Entity) {
 	if s.entityHidden(e) {
@@ -526,6 +566,12 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 			Position:  vec64To32(pos),
 		})
 		return
+	case sound.ClickFail:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventSoundClickFail,
+			Position:  vec64To32(pos),
+		})
+		return
 	case sound.SignWaxed:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventWaxOn,
@@ -673,6 +719,18 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventEnderChestClosed
 	case sound.EnderChestOpen:
 		pk.SoundType = packet.SoundEventEnderChestOpen
+	case sound.EnderEyePlaced:
+		pk.SoundType = packet.SoundEventEnderEyePlaced
+	case sound.EndPortalCreated:
+		pk.SoundType = packet.SoundEventEndPortalCreated
+	case sound.PowerOn:
+		pk.SoundType = packet.SoundEventPowerOn
+	case sound.PowerOff:
+		pk.SoundType = packet.SoundEventPowerOff
+	case sound.PistonExtend:
+		pk.SoundType = packet.SoundEventPistonOut
+	case sound.PistonRetract:
+		pk.SoundType = packet.SoundEventPistonIn
 	case sound.BarrelClose:
 		pk.SoundType = packet.SoundEventBarrelClose
 	case sound.BarrelOpen:
@@ -924,6 +982,11 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventTalismanActivate,
 		})
+	case entity.WakeUpAction:
+		s.writePacket(&packet.Animate{
+			ActionType:      packet.AnimateActionStopSleep,
+			EntityRuntimeID: s.entityRuntimeID(e),
+		})
 	}
 }
 
@@ -935,6 +998,22 @@ func (s *Session) ViewEntityState(e world.Entity) {
 	})
 }
 
+// ViewEntityRide ...
+func (s *Session) ViewEntityRide(rider, vehicle world.Entity, riding bool) {
+	if s.entityHidden(rider) || s.entityHidden(vehicle) {
+		return
+	}
+	link, ok := s.entityLink(rider, vehicle)
+	if !ok {
+		return
+	}
+	if !riding {
+		link.Type = protocol.EntityLinkRemove
+	}
+	s.writePacket(&packet.SetActorLink{EntityLink: link})
+	s.ViewEntityState(rider)
+}
+
 // ViewEntityAnimation ...
 func (s *Session) ViewEntityAnimation(e world.Entity, animationName string) {
 	s.writePacket(&packet.AnimateEntity{
@@ -1017,6 +1096,12 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos) {
 		containerType = protocol.ContainerTypeBlastFurnace
 	case block.Smoker:
 		containerType = protocol.ContainerTypeSmoker
+	case block.Hopper:
+		containerType = protocol.ContainerTypeHopper
+	case block.Dispenser:
+		containerType = protocol.ContainerTypeDispenser
+	case block.Dropper:
+		containerType = protocol.ContainerTypeDropper
 	}
 
 	s.writePacket(&packet.ContainerOpen{
@@ -1077,6 +1162,13 @@ func (s *Session) ViewBlockAction(pos cube.Pos, a world.BlockAction) {
 			Position:  vec64To32(pos.Vec3()),
 			EventData: int32(65535 / (t.BreakTime.Seconds() * 20)),
 		})
+	case block.PistonAction:
+		nbtData := block.Piston{Sticky: t.Sticky, Extended: t.Extending, Progress: t.Progress, AttachedBlocks: t.AttachedBlocks}.EncodeNBT()
+		nbtData["x"], nbtData["y"], nbtData["z"] = blockPos[0], blockPos[1], blockPos[2]
+		s.writePacket(&packet.BlockActorData{
+			Position: blockPos,
+			NBTData:  nbtData,
+		})
 	}
 }
 
@@ -1130,6 +1222,39 @@ func (s *Session) ViewWeather(raining, thunder bool) {
 	s.writePacket(pk)
 }
 
+// ViewSleepingPlayers ...
+func (s *Session) ViewSleepingPlayers(sleeping, required int) {
+	s.writePacket(&packet.LevelEvent{
+		EventType: packet.LevelEventSleepingPlayers,
+		EventData: int32(required<<16 | sleeping),
+	})
+}
+
+// ViewGameRules ...
+func (s *Session) ViewGameRules(rules map[string]any) {
+	s.gameRuleMu.Lock()
+	defer s.gameRuleMu.Unlock()
+
+	gameRules := make([]protocol.GameRule, 0, len(rules))
+	for name, v := range rules {
+		if override, ok := s.gameRules[name]; ok {
+			// The game rule was changed for this player specifically, for
+			// example through EnableCoordinates.
+			v = override
+		}
+		//noinspection SpellCheckingInspection
+		if name == "naturalregeneration" {
+			// Regeneration is handled by the server, so the client should never regenerate by itself.
+			v = false
+		}
+		if i, ok := v.(int32); ok {
+			v = uint32(i)
+		}
+		gameRules = append(gameRules, protocol.GameRule{Name: name, Value: v})
+	}
+	s.sendGameRules(gameRules)
+}
+
 // nextWindowID produces the next window ID for a new window. It is an int of 1-99.
 func (s *Session) nextWindowID() byte {
 	if s.openedWindowID.CAS(99, 1) {
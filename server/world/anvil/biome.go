@@ -0,0 +1,140 @@
+package anvil
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// javaBiomes maps the names of Java Edition biomes to the names of their Bedrock Edition counterparts, if the
+// names are different.
+var javaBiomes = map[string]string{
+	"badlands":                 "mesa",
+	"wooded_badlands":          "mesa_plateau_stone",
+	"eroded_badlands":          "mesa_bryce",
+	"dark_forest":              "roofed_forest",
+	"the_end":                  "the_end",
+	"end_barrens":              "the_end",
+	"end_highlands":            "the_end",
+	"end_midlands":             "the_end",
+	"small_end_islands":        "the_end",
+	"ice_spikes":               "ice_plains_spikes",
+	"sparse_jungle":            "jungle_edge",
+	"mushroom_fields":          "mushroom_island",
+	"nether_wastes":            "hell",
+	"old_growth_birch_forest":  "birch_forest_mutated",
+	"old_growth_pine_taiga":    "mega_taiga",
+	"old_growth_spruce_taiga":  "redwood_taiga_mutated",
+	"snowy_beach":              "cold_beach",
+	"snowy_plains":             "ice_plains",
+	"snowy_taiga":              "cold_taiga",
+	"soul_sand_valley":         "soulsand_valley",
+	"stony_shore":              "stone_beach",
+	"swamp":                    "swampland",
+	"windswept_forest":         "extreme_hills_plus_trees",
+	"windswept_gravelly_hills": "extreme_hills_mutated",
+	"windswept_hills":          "extreme_hills",
+	"windswept_savanna":        "savanna_mutated",
+}
+
+// plains returns the plains biome, which unmapped biomes are converted to.
+func plains() world.Biome {
+	return biome.Plains{}
+}
+
+// biome returns the world.Biome with the Java Edition name passed. If the biome is not known, plains is returned
+// and the biome is reported as unmapped.
+func (c *Converter) biome(name string) world.Biome {
+	n := strings.TrimPrefix(name, "minecraft:")
+	if bedrock, ok := javaBiomes[n]; ok {
+		n = bedrock
+	}
+	if b, ok := world.BiomeByName(n); ok {
+		return b
+	}
+	c.mu.Lock()
+	if c.unmapped == nil {
+		c.states, c.unmapped = map[string]convertedState{}, map[string]int{}
+	}
+	c.unmapped["biome:"+name]++
+	c.mu.Unlock()
+	return plains()
+}
+
+// convertSectionBiomes converts the biomes of a section from Java Edition 1.18 or later, which are stored per 4x4x4
+// area of blocks, and sets them in the sub chunk y of the chunk.Chunk passed. False is returned if the section
+// has no biomes.
+func (c *Converter) convertSectionBiomes(ch *chunk.Chunk, y int, section map[string]any) bool {
+	biomes, ok := section["biomes"].(map[string]any)
+	if !ok {
+		return false
+	}
+	palette, _ := biomes["palette"].([]any)
+	if len(palette) == 0 {
+		return false
+	}
+	ids := make([]uint32, len(palette))
+	for i, v := range palette {
+		name, _ := v.(string)
+		ids[i] = uint32(c.biome(name).EncodeBiome())
+	}
+	indices := unpack(nbtconv.Int64sFromAny(biomes["data"]), bits.Len(uint(len(palette)-1)), 64, false)
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for dy := 0; dy < 16; dy++ {
+				index := indices[(dy>>2)<<4|(z>>2)<<2|x>>2]
+				if index >= len(ids) {
+					continue
+				}
+				ch.SetBiome(uint8(x), int16(y<<4|dy), uint8(z), ids[index])
+			}
+		}
+	}
+	return true
+}
+
+// convertLegacyBiomes converts the biomes of a chunk from before Java Edition 1.18, which are stored as numeric
+// IDs. These IDs are the same as the IDs of biomes in Bedrock Edition. Chunks from Java Edition 1.15 and later
+// store the biome per 4x4x4 area in the range 0-255, while earlier chunks store a single biome per column.
+func (c *Converter) convertLegacyBiomes(ch *chunk.Chunk, ids []int32) {
+	r := ch.Range()
+	biomeID := func(id int32) uint32 {
+		if b, ok := world.BiomeByID(int(id)); ok {
+			return uint32(b.EncodeBiome())
+		}
+		return uint32(c.biome(strconv.Itoa(int(id))).EncodeBiome())
+	}
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for y := r.Min(); y <= r.Max(); y++ {
+				var id int32
+				switch len(ids) {
+				case 256:
+					id = ids[z<<4|x]
+				case 1024:
+					layer := min(max(y>>2, 0), 63)
+					id = ids[layer<<4|(z>>2)<<2|x>>2]
+				default:
+					return
+				}
+				ch.SetBiome(uint8(x), int16(y), uint8(z), biomeID(id))
+			}
+		}
+	}
+}
+
+// fillBiome sets the biome of all blocks in the chunk.Chunk passed.
+func fillBiome(ch *chunk.Chunk, b world.Biome) {
+	r, id := ch.Range(), uint32(b.EncodeBiome())
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			for y := r.Min(); y <= r.Max(); y++ {
+				ch.SetBiome(x, int16(y), z, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package anvil
+
+import (
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/schematic"
+	"math/bits"
+	"strconv"
+	"sync"
+)
+
+// ErrNotGenerated is returned by Converter.Column if the chunk passed was not yet fully generated by Java Edition.
+// Such chunks only hold part of their terrain and are left out of the converted world, so that they are generated
+// again when they are loaded.
+var ErrNotGenerated = errors.New("chunk not fully generated")
+
+// dataVersionPackedLongs is the first data version, Java Edition 1.16, in which the indices of block states no
+// longer span multiple longs.
+const dataVersionPackedLongs = 2527
+
+// Converter converts Java Edition chunks to world.Columns. The blocks and biomes of the chunks are converted to
+// their Bedrock Edition counterparts, while the NBT of block entities and entities is not converted, as it is not
+// compatible with Bedrock Edition. Blocks with a block entity are placed with their default NBT instead.
+// Chunks of Java Edition 1.13 and later are supported, as well as chunks of earlier versions that use numeric
+// block IDs. A Converter is safe for concurrent use.
+type Converter struct {
+	// Mapping is the schematic.Mapping used to convert Java Edition block states to Bedrock Edition blocks. If
+	// nil, schematic.DefaultMapping is used.
+	Mapping schematic.Mapping
+
+	mu       sync.Mutex
+	states   map[string]convertedState
+	unmapped map[string]int
+}
+
+// convertedState holds the runtime IDs of a converted block state. If the state could not be converted, ok is
+// false.
+type convertedState struct {
+	b           world.Block
+	rid, liquid uint32
+	nbt, ok     bool
+}
+
+// airRID is the runtime ID of air, used for empty blocks.
+var airRID = world.BlockRuntimeID(block.Air{})
+
+// Unmapped returns the Java Edition block states and biomes that could not be mapped to Bedrock Edition while
+// converting chunks, together with the amount of times they were encountered. Block states are in the form of
+// schematic.State.String, legacy block IDs in the form 'legacy:id:metadata' and biomes in the form
+// 'biome:name'. Unmapped blocks are converted to air and unmapped biomes to plains.
+func (c *Converter) Unmapped() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := make(map[string]int, len(c.unmapped))
+	for k, v := range c.unmapped {
+		m[k] = v
+	}
+	return m
+}
+
+// ConvertRegion converts all chunks in the Region passed and stores them in the world.Provider passed, such as a
+// mcdb.DB, in the world.Dimension passed. The amount of chunks stored is returned. Chunks that cannot be read or
+// stored do not stop the conversion, but are reported in the error returned. Chunks that were not fully generated
+// are skipped without error.
+func (c *Converter) ConvertRegion(r *Region, dim world.Dimension, p world.Provider) (int, error) {
+	var (
+		n    int
+		errs []error
+	)
+	for _, pos := range r.Chunks() {
+		data, err := r.Chunk(pos)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		chunkPos, col, err := c.Column(data, dim)
+		if errors.Is(err, ErrNotGenerated) {
+			continue
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("convert chunk %v: %w", pos, err))
+			continue
+		}
+		if err := p.StoreColumn(chunkPos, dim, col); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
+
+// Column converts the NBT of a Java Edition chunk, as returned by Region.Chunk, to a world.Column with the range
+// of the world.Dimension passed. Parts of the chunk outside the range are left out. The position of the chunk is
+// returned with the Column.
+func (c *Converter) Column(data map[string]any, dim world.Dimension) (world.ChunkPos, *world.Column, error) {
+	dataVersion := nbtconv.IntFromAny(data["DataVersion"])
+	level := data
+	if l, ok := data["Level"].(map[string]any); ok {
+		// Chunks from before Java Edition 1.18 store all data in a compound named Level.
+		level = l
+	}
+	pos := world.ChunkPos{int32(nbtconv.IntFromAny(level["xPos"])), int32(nbtconv.IntFromAny(level["zPos"]))}
+	if !generated(level) {
+		return pos, nil, ErrNotGenerated
+	}
+
+	r := dim.Range()
+	ch := chunk.New(airRID, r)
+	col := &world.Column{Chunk: ch, BlockEntities: map[cube.Pos]world.Block{}, Populated: true}
+
+	sections, ok := level["sections"].([]any)
+	if !ok {
+		sections, _ = level["Sections"].([]any)
+	}
+	hasBiomes := false
+	for _, s := range sections {
+		section, _ := s.(map[string]any)
+		y := int(int8(nbtconv.IntFromAny(section["Y"])))
+		if y < r.Min()>>4 || y > r.Max()>>4 {
+			continue
+		}
+		if err := c.convertBlocks(col, pos, y, section, dataVersion); err != nil {
+			return pos, nil, err
+		}
+		if c.convertSectionBiomes(ch, y, section) {
+			hasBiomes = true
+		}
+	}
+	if ids := nbtconv.Int32sFromAny(level["Biomes"]); len(ids) != 0 {
+		c.convertLegacyBiomes(ch, ids)
+	} else if !hasBiomes {
+		fillBiome(ch, plains())
+	}
+	return pos, col, nil
+}
+
+// generated checks if the chunk with the NBT passed was fully generated by Java Edition.
+func generated(level map[string]any) bool {
+	status, ok := level["Status"].(string)
+	if !ok {
+		// Chunks from before Java Edition 1.13 only store if they were populated.
+		if populated, ok := level["TerrainPopulated"]; ok {
+			return nbtconv.IntFromAny(populated) == 1
+		}
+		return true
+	}
+	switch status {
+	case "full", "minecraft:full", "fullchunk", "postprocessed", "spawn", "heightmaps", "lighted":
+		return true
+	}
+	return false
+}
+
+// convertBlocks converts the blocks of a section at the sub chunk y passed and places them in the Column.
+func (c *Converter) convertBlocks(col *world.Column, pos world.ChunkPos, y int, section map[string]any, dataVersion int) error {
+	var (
+		palette []any
+		data    []int64
+	)
+	if states, ok := section["block_states"].(map[string]any); ok {
+		palette, _ = states["palette"].([]any)
+		data = nbtconv.Int64sFromAny(states["data"])
+	} else if p, ok := section["Palette"].([]any); ok {
+		palette, data = p, nbtconv.Int64sFromAny(section["BlockStates"])
+	} else if ids := nbtconv.BytesFromAny(section["Blocks"]); len(ids) == 4096 {
+		c.convertLegacyBlocks(col, pos, y, ids, nbtconv.BytesFromAny(section["Data"]), nbtconv.BytesFromAny(section["Add"]))
+		return nil
+	}
+	if len(palette) == 0 {
+		return nil
+	}
+	states := make([]convertedState, len(palette))
+	for i, entry := range palette {
+		m, _ := entry.(map[string]any)
+		states[i] = c.convert(javaState(m))
+	}
+	bitsPerBlock := max(4, bits.Len(uint(len(palette)-1)))
+	if len(palette) == 1 {
+		bitsPerBlock = 0
+	}
+	indices := unpack(data, bitsPerBlock, 4096, dataVersion < dataVersionPackedLongs)
+	for i, index := range indices {
+		if index >= len(states) {
+			return fmt.Errorf("block state index %v out of range of palette with length %v", index, len(states))
+		}
+		c.set(col, pos, i&15, y<<4|i>>8, i>>4&15, states[index])
+	}
+	return nil
+}
+
+// convertLegacyBlocks converts the numeric block IDs of a section from before Java Edition 1.13.
+func (c *Converter) convertLegacyBlocks(col *world.Column, pos world.ChunkPos, y int, ids, meta, add []byte) {
+	for i, id := range ids {
+		fullID, data := uint16(id), nibble(meta, i)
+		if len(add) != 0 {
+			fullID |= uint16(nibble(add, i)) << 8
+		}
+		c.set(col, pos, i&15, y<<4|i>>8, i>>4&15, c.convertLegacy(fullID, data))
+	}
+}
+
+// set sets a converted block state at a position relative to the Column.
+func (c *Converter) set(col *world.Column, pos world.ChunkPos, x, y, z int, s convertedState) {
+	if !s.ok || (s.rid == airRID && s.liquid == airRID) {
+		return
+	}
+	col.SetBlock(uint8(x), int16(y), uint8(z), 0, s.rid)
+	if s.liquid != airRID {
+		col.SetBlock(uint8(x), int16(y), uint8(z), 1, s.liquid)
+	}
+	if s.nbt {
+		col.BlockEntities[cube.Pos{int(pos[0])<<4 | x, y, int(pos[1])<<4 | z}] = s.b
+	}
+}
+
+// convert converts a Java Edition block state, caching the result.
+func (c *Converter) convert(state schematic.State) convertedState {
+	key := state.String()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.states[key]; ok {
+		if !s.ok {
+			c.unmapped[key]++
+		}
+		return s
+	}
+	if c.states == nil {
+		c.states, c.unmapped = map[string]convertedState{}, map[string]int{}
+	}
+	s := convertedState{liquid: airRID}
+	if b, liq, ok := schematic.Convert(state, c.Mapping); ok {
+		_, s.nbt = b.(world.NBTer)
+		s.b, s.rid, s.ok = b, world.BlockRuntimeID(b), true
+		if liq != nil {
+			s.liquid = world.BlockRuntimeID(liq)
+		}
+	} else {
+		c.unmapped[key]++
+	}
+	c.states[key] = s
+	return s
+}
+
+// convertLegacy converts a legacy block ID and metadata value.
+func (c *Converter) convertLegacy(id uint16, meta uint8) convertedState {
+	if state, ok := schematic.LegacyState(id, meta); ok {
+		return c.convert(state)
+	}
+	key := "legacy:" + strconv.Itoa(int(id)) + ":" + strconv.Itoa(int(meta))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.unmapped == nil {
+		c.states, c.unmapped = map[string]convertedState{}, map[string]int{}
+	}
+	c.unmapped[key]++
+	return convertedState{}
+}
+
+// javaState converts an entry of a block palette to a schematic.State.
+func javaState(m map[string]any) schematic.State {
+	name, _ := m["Name"].(string)
+	s := schematic.State{Name: name, Properties: map[string]string{}}
+	properties, _ := m["Properties"].(map[string]any)
+	for k, v := range properties {
+		s.Properties[k], _ = v.(string)
+	}
+	return s
+}
+
+// unpack unpacks n indices of bitsPerIndex bits each from data. If spanning is true, indices may span two longs,
+// which is the case for chunks from before Java Edition 1.16.
+func unpack(data []int64, bitsPerIndex, n int, spanning bool) []int {
+	indices := make([]int, n)
+	if bitsPerIndex == 0 {
+		return indices
+	}
+	mask := uint64(1)<<bitsPerIndex - 1
+	perLong := 64 / bitsPerIndex
+	for i := range indices {
+		var v uint64
+		if spanning {
+			bit := i * bitsPerIndex
+			li, offset := bit/64, bit%64
+			if li >= len(data) {
+				break
+			}
+			v = uint64(data[li]) >> offset
+			if offset+bitsPerIndex > 64 && li+1 < len(data) {
+				v |= uint64(data[li+1]) << (64 - offset)
+			}
+		} else {
+			li := i / perLong
+			if li >= len(data) {
+				break
+			}
+			v = uint64(data[li]) >> ((i % perLong) * bitsPerIndex)
+		}
+		indices[i] = int(v & mask)
+	}
+	return indices
+}
+
+// nibble returns the four bits at index i of a nibble array.
+func nibble(b []byte, i int) uint8 {
+	if i>>1 >= len(b) {
+		return 0
+	}
+	if i&1 == 0 {
+		return b[i>>1] & 0xf
+	}
+	return b[i>>1] >> 4
+}
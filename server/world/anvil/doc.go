@@ -0,0 +1,5 @@
+// Package anvil implements conversion of Java Edition worlds, stored in the Anvil format, to dragonfly worlds. Region
+// files are read using OpenRegion, and the chunks in them are converted to world.Columns by a Converter, which maps
+// Java Edition block states and biomes to their Bedrock Edition counterparts. The Columns may then be stored in a
+// world.Provider such as mcdb.DB.
+package anvil
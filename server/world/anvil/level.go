@@ -0,0 +1,57 @@
+package anvil
+
+import (
+	"compress/gzip"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"os"
+)
+
+// ReadSettings reads the Java Edition level.dat file at the path passed and applies its name, seed, spawn
+// position, time, weather, default game mode and difficulty to the world.Settings passed.
+func ReadSettings(path string, s *world.Settings) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("read level.dat: %w", err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("read level.dat: %w", err)
+	}
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(r, nbt.BigEndian).Decode(&m); err != nil {
+		return fmt.Errorf("read level.dat: decode nbt: %w", err)
+	}
+	data, ok := m["Data"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("read level.dat: missing Data compound")
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if name, ok := data["LevelName"].(string); ok {
+		s.Name = name
+	}
+	if seed, ok := data["RandomSeed"].(int64); ok {
+		s.Seed = seed
+	} else if gen, ok := data["WorldGenSettings"].(map[string]any); ok {
+		// Java Edition 1.16 and later store the seed with the other world generation settings.
+		s.Seed, _ = gen["seed"].(int64)
+	}
+	s.Spawn = cube.Pos{nbtconv.IntFromAny(data["SpawnX"]), nbtconv.IntFromAny(data["SpawnY"]), nbtconv.IntFromAny(data["SpawnZ"])}
+	s.Time, _ = data["DayTime"].(int64)
+	s.CurrentTick, _ = data["Time"].(int64)
+	s.Raining, s.Thundering = nbtconv.IntFromAny(data["raining"]) == 1, nbtconv.IntFromAny(data["thundering"]) == 1
+	s.RainTime, s.ThunderTime = int64(nbtconv.IntFromAny(data["rainTime"])), int64(nbtconv.IntFromAny(data["thunderTime"]))
+	if mode, ok := world.GameModeByID(nbtconv.IntFromAny(data["GameType"])); ok {
+		s.DefaultGameMode = mode
+	}
+	if diff, ok := world.DifficultyByID(nbtconv.IntFromAny(data["Difficulty"])); ok {
+		s.Difficulty = diff
+	}
+	return nil
+}
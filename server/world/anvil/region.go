@@ -0,0 +1,130 @@
+package anvil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// sectorSize is the size in bytes of the sectors that region files are divided in.
+	sectorSize = 4096
+	// regionWidth is the width and length of a region in chunks.
+	regionWidth = 32
+)
+
+// Compression types used to compress the chunks stored in a region file.
+const (
+	compressionGzip         = 1
+	compressionZlib         = 2
+	compressionUncompressed = 3
+)
+
+// Region is a Java Edition Anvil region file (.mca). A Region holds up to 32x32 chunks. A Region must be closed
+// after use by calling Close.
+type Region struct {
+	f    *os.File
+	x, z int32
+
+	locations [regionWidth * regionWidth]uint32
+}
+
+// OpenRegion opens the region file at the path passed. The position of the region is taken from the name of the
+// file, which must be in the form 'r.x.z.mca'.
+func OpenRegion(path string) (*Region, error) {
+	parts := strings.Split(filepath.Base(path), ".")
+	if len(parts) != 4 || parts[0] != "r" || parts[3] != "mca" {
+		return nil, fmt.Errorf("open region %v: invalid region file name", path)
+	}
+	x, errX := strconv.Atoi(parts[1])
+	z, errZ := strconv.Atoi(parts[2])
+	if errX != nil || errZ != nil {
+		return nil, fmt.Errorf("open region %v: invalid region file name", path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open region %v: %w", path, err)
+	}
+	r := &Region{f: f, x: int32(x), z: int32(z)}
+	if err := binary.Read(f, binary.BigEndian, &r.locations); err != nil && !errors.Is(err, io.EOF) {
+		_ = f.Close()
+		return nil, fmt.Errorf("open region %v: read header: %w", path, err)
+	}
+	return r, nil
+}
+
+// Chunks returns the positions of all chunks present in the Region.
+func (r *Region) Chunks() []world.ChunkPos {
+	positions := make([]world.ChunkPos, 0, len(r.locations))
+	for i, loc := range r.locations {
+		if loc == 0 {
+			continue
+		}
+		positions = append(positions, world.ChunkPos{r.x*regionWidth + int32(i%regionWidth), r.z*regionWidth + int32(i/regionWidth)})
+	}
+	return positions
+}
+
+// Chunk reads the NBT of the chunk at the position passed. If the chunk is not present in the Region, or if the
+// position is not within the Region, an error is returned.
+func (r *Region) Chunk(pos world.ChunkPos) (map[string]any, error) {
+	x, z := pos[0]-r.x*regionWidth, pos[1]-r.z*regionWidth
+	if x < 0 || x >= regionWidth || z < 0 || z >= regionWidth {
+		return nil, fmt.Errorf("read chunk %v: chunk not in region (%v, %v)", pos, r.x, r.z)
+	}
+	loc := r.locations[x+z*regionWidth]
+	if loc == 0 {
+		return nil, fmt.Errorf("read chunk %v: chunk not present", pos)
+	}
+	offset, sectors := int64(loc>>8)*sectorSize, int64(loc&0xff)*sectorSize
+
+	var header [5]byte
+	if _, err := r.f.ReadAt(header[:], offset); err != nil {
+		return nil, fmt.Errorf("read chunk %v: %w", pos, err)
+	}
+	length := int64(binary.BigEndian.Uint32(header[:4]))
+	if length < 1 || length+4 > sectors {
+		return nil, fmt.Errorf("read chunk %v: invalid chunk length %v", pos, length)
+	}
+	data := make([]byte, length-1)
+	if _, err := r.f.ReadAt(data, offset+5); err != nil {
+		return nil, fmt.Errorf("read chunk %v: %w", pos, err)
+	}
+
+	var (
+		rd  io.Reader = bytes.NewReader(data)
+		err error
+	)
+	switch header[4] {
+	case compressionGzip:
+		rd, err = gzip.NewReader(rd)
+	case compressionZlib:
+		rd, err = zlib.NewReader(rd)
+	case compressionUncompressed:
+	default:
+		return nil, fmt.Errorf("read chunk %v: unsupported compression type %v", pos, header[4])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read chunk %v: %w", pos, err)
+	}
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(rd, nbt.BigEndian).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read chunk %v: decode nbt: %w", pos, err)
+	}
+	return m, nil
+}
+
+// Close closes the region file.
+func (r *Region) Close() error {
+	return r.f.Close()
+}
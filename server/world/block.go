@@ -116,6 +116,9 @@ func RegisterBlock(b Block) {
 	if _, ok := b.(LiquidDisplacer); ok {
 		liquidDisplacingBlocks[rid] = true
 	}
+	if _, ok := b.(Conductor); ok {
+		conductorBlocks[rid] = true
+	}
 	if c, ok := b.(CustomBlock); ok {
 		if _, ok := customBlocks[name]; !ok {
 			customBlocks[name] = c
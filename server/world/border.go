@@ -0,0 +1,186 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"sync"
+	"time"
+)
+
+// DefaultBorderSize is the size of the Border of a World if it was not changed.
+const DefaultBorderSize = 59999968
+
+// Border is the world border of a World. It is a square area around a centre, outside which blocks may not be placed
+// and into which entities inside of it cannot move. Players outside of the Border take damage once they are further
+// away from it than the damage buffer. The size of a Border may change gradually over time. A Border is obtained by
+// calling World.Border. It is safe for concurrent use.
+type Border struct {
+	mu sync.Mutex
+
+	centre mgl64.Vec2
+	// from and to are the sizes of the Border at the start and end of a resize that started at the time start and
+	// lasts for duration.
+	from, to float64
+	start    time.Time
+	duration time.Duration
+
+	damageBuffer, damagePerBlock float64
+	warningDistance              float64
+}
+
+// newBorder creates a Border with the default size, centred around x=0, z=0.
+func newBorder() *Border {
+	return &Border{from: DefaultBorderSize, to: DefaultBorderSize, damageBuffer: 5, damagePerBlock: 0.2, warningDistance: 5}
+}
+
+// Centre returns the centre of the Border. The X and Y of the mgl64.Vec2 returned are the X and Z coordinates of the
+// centre respectively.
+func (b *Border) Centre() mgl64.Vec2 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.centre
+}
+
+// SetCentre changes the centre of the Border. The X and Y of the mgl64.Vec2 passed are the X and Z coordinates of the
+// centre respectively.
+func (b *Border) SetCentre(centre mgl64.Vec2) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.centre = centre
+}
+
+// Size returns the current size of the Border, which is the length of its sides in blocks. If the Border is being
+// resized, the size returned lies between the size at the start of the resize and the target size.
+func (b *Border) Size() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size()
+}
+
+// size returns the current size of the Border. b.mu must be held when calling size.
+func (b *Border) size() float64 {
+	if b.duration <= 0 {
+		return b.to
+	}
+	progress := float64(time.Since(b.start)) / float64(b.duration)
+	if progress >= 1 {
+		return b.to
+	}
+	return b.from + (b.to-b.from)*progress
+}
+
+// TargetSize returns the size that the Border will have once it is done resizing. If the Border is not resizing,
+// TargetSize returns the same value as Size.
+func (b *Border) TargetSize() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.to
+}
+
+// SetSize immediately changes the size of the Border to the size passed, stopping any resize in progress. The size is
+// the length of the sides of the Border in blocks.
+func (b *Border) SetSize(size float64) {
+	b.ResizeTo(size, 0)
+}
+
+// ResizeTo gradually changes the size of the Border from its current size to the size passed over the duration
+// passed. If the duration is 0 or lower, the size is changed immediately.
+func (b *Border) ResizeTo(size float64, duration time.Duration) {
+	size = max(size, 1)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.from, b.to, b.start, b.duration = b.size(), size, time.Now(), duration
+}
+
+// Resizing checks if the Border is currently changing size as a result of a call to ResizeTo.
+func (b *Border) Resizing() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.duration > 0 && time.Since(b.start) < b.duration
+}
+
+// DamageBuffer returns the distance in blocks that players may be outside the Border without taking damage.
+func (b *Border) DamageBuffer() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.damageBuffer
+}
+
+// SetDamageBuffer changes the distance in blocks that players may be outside the Border without taking damage.
+func (b *Border) SetDamageBuffer(buffer float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.damageBuffer = max(buffer, 0)
+}
+
+// DamagePerBlock returns the damage dealt to players every half second for every block that they are outside the
+// Border, beyond the damage buffer.
+func (b *Border) DamagePerBlock() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.damagePerBlock
+}
+
+// SetDamagePerBlock changes the damage dealt to players every half second for every block that they are outside the
+// Border, beyond the damage buffer. Setting it to 0 disables damage by the Border.
+func (b *Border) SetDamagePerBlock(damage float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.damagePerBlock = max(damage, 0)
+}
+
+// WarningDistance returns the distance in blocks to the Border at which players are shown the Border.
+func (b *Border) WarningDistance() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.warningDistance
+}
+
+// SetWarningDistance changes the distance in blocks to the Border at which players are shown the Border. Setting it
+// to 0 disables showing the Border.
+func (b *Border) SetWarningDistance(distance float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.warningDistance = max(distance, 0)
+}
+
+// Bounds returns the current minimum and maximum X and Z coordinates of the Border. The X and Y of the mgl64.Vec2s
+// returned are the X and Z coordinates respectively.
+func (b *Border) Bounds() (minimum, maximum mgl64.Vec2) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	half := b.size() / 2
+	return b.centre.Sub(mgl64.Vec2{half, half}), b.centre.Add(mgl64.Vec2{half, half})
+}
+
+// Distance returns the distance in blocks from the position passed to the nearest edge of the Border on the
+// horizontal axes. The distance is positive if the position is inside the Border and negative if it is outside.
+func (b *Border) Distance(pos mgl64.Vec3) float64 {
+	minimum, maximum := b.Bounds()
+	return min(pos[0]-minimum[0], maximum[0]-pos[0], pos[2]-minimum[1], maximum[1]-pos[2])
+}
+
+// Contains checks if the position passed is inside the Border. Positions exactly on the edge of the Border are
+// considered inside.
+func (b *Border) Contains(pos mgl64.Vec3) bool {
+	return b.Distance(pos) >= 0
+}
+
+// ContainsBlock checks if the block at the cube.Pos passed is at least partially inside the Border. Blocks may only
+// be placed at positions for which ContainsBlock returns true.
+func (b *Border) ContainsBlock(pos cube.Pos) bool {
+	minimum, maximum := b.Bounds()
+	x, z := float64(pos[0]), float64(pos[2])
+	return x+1 > minimum[0] && x < maximum[0] && z+1 > minimum[1] && z < maximum[1]
+}
+
+// Clamp returns the position closest to the position passed that is inside the Border. The Y coordinate of the
+// position is not changed.
+func (b *Border) Clamp(pos mgl64.Vec3) mgl64.Vec3 {
+	minimum, maximum := b.Bounds()
+	return mgl64.Vec3{
+		max(minimum[0], min(pos[0], maximum[0])),
+		pos[1],
+		max(minimum[1], min(pos[2], maximum[1])),
+	}
+}
@@ -24,7 +24,9 @@ type Config struct {
 	// be NopProvider, which does not store any data to disk.
 	Provider Provider
 	// Generator is the Generator implementation used to generate new areas of the World. If set to nil, the Generator
-	// used will be NopGenerator, which generates completely empty chunks.
+	// used will be NopGenerator, which generates completely empty chunks. Generators that depend on a seed should be
+	// created using the Seed of the Settings returned by the Provider, so that the World generates the same terrain
+	// every time it is loaded.
 	Generator Generator
 	// ReadOnly specifies if the World should be read-only, meaning no new data will be written to the Provider.
 	ReadOnly bool
@@ -32,6 +34,10 @@ type Config struct {
 	// 3 blocks randomly ticked per sub chunk, so the default value is 3. Setting this value to -1 or lower will stop
 	// random ticking altogether, while setting it higher results in faster ticking.
 	RandomTickSpeed int
+	// AutosaveInterval is the interval at which the modified chunks and the settings of the World are saved to the
+	// Provider while the World is running. If set to 0 or lower, the World is only saved when chunks are unloaded
+	// and when the World is closed.
+	AutosaveInterval time.Duration
 	// RandSource is the rand.Source used for generation of random numbers in a World, such as when selecting blocks to
 	// tick or when deciding where to strike lightning. If set to nil, `rand.NewSource(time.Now().Unix())` will be used
 	// to generate a new source.
@@ -74,9 +80,11 @@ func (conf Config) New() *World {
 		scheduledUpdates: make(map[cube.Pos]int64),
 		entities:         make(map[Entity]ChunkPos),
 		viewers:          make(map[*Loader]Viewer),
+		sleeping:         make(map[Sleeper]struct{}),
 		chunks:           make(map[ChunkPos]*Column),
 		closing:          make(chan struct{}),
 		handler:          *atomic.NewValue[Handler](NopHandler{}),
+		border:           newBorder(),
 		r:                rand.New(conf.RandSource),
 		advance:          s.ref.Inc() == 1,
 		conf:             conf,
@@ -87,5 +95,8 @@ func (conf Config) New() *World {
 
 	go w.tickLoop()
 	go w.chunkCacheJanitor()
+	if conf.AutosaveInterval > 0 && !conf.ReadOnly {
+		go w.autosave()
+	}
 	return w
 }
@@ -108,6 +108,8 @@ type EntityRegistryConfig struct {
 	EnderPearl         func(pos, vel mgl64.Vec3, owner Entity) Entity
 	Firework           func(pos mgl64.Vec3, rot cube.Rotation, attached bool, firework Item, owner Entity) Entity
 	LingeringPotion    func(pos, vel mgl64.Vec3, t any, owner Entity) Entity
+	Minecart           func(pos mgl64.Vec3, t any) Entity
+	SmallFireball      func(pos, vel mgl64.Vec3, owner Entity) Entity
 	Snowball           func(pos, vel mgl64.Vec3, owner Entity) Entity
 	SplashPotion       func(pos, vel mgl64.Vec3, t any, owner Entity) Entity
 	Lightning          func(pos mgl64.Vec3) Entity
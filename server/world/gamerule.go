@@ -0,0 +1,219 @@
+package world
+
+import (
+	"golang.org/x/exp/maps"
+	"slices"
+	"strings"
+)
+
+// GameRule is a rule that changes the behaviour of a World, such as whether fire spreads or whether players keep
+// their inventory when they die. A GameRule is either a BoolGameRule or an IntGameRule. The values of the game rules
+// of a World are stored in its Settings and may be changed using World.SetBoolGameRule and World.SetIntGameRule.
+type GameRule interface {
+	// Name returns the name of the GameRule, as it is stored in the level.dat and sent to clients.
+	Name() string
+	// Default returns the value that the GameRule has if it was not changed. The value is either a bool or an int32.
+	Default() any
+}
+
+// BoolGameRule is a GameRule that may be either enabled or disabled.
+type BoolGameRule struct {
+	name string
+	def  bool
+}
+
+// Name ...
+func (r BoolGameRule) Name() string {
+	return r.name
+}
+
+// Default ...
+func (r BoolGameRule) Default() any {
+	return r.def
+}
+
+// IntGameRule is a GameRule that holds a numeric value.
+type IntGameRule struct {
+	name string
+	def  int32
+}
+
+// Name ...
+func (r IntGameRule) Name() string {
+	return r.name
+}
+
+// Default ...
+func (r IntGameRule) Default() any {
+	return r.def
+}
+
+var (
+	// GameRuleDoDaylightCycle specifies if the time of a World advances. It is the same as the TimeCycle field of the
+	// Settings of the World.
+	GameRuleDoDaylightCycle = registerBoolGameRule("dodaylightcycle", true)
+	// GameRuleDoWeatherCycle specifies if the weather of a World changes. It is the same as the WeatherCycle field of
+	// the Settings of the World.
+	GameRuleDoWeatherCycle = registerBoolGameRule("doweathercycle", true)
+	// GameRuleDoFireTick specifies if fire spreads and burns out.
+	GameRuleDoFireTick = registerBoolGameRule("dofiretick", true)
+	// GameRuleKeepInventory specifies if players keep their inventory and experience when they die.
+	GameRuleKeepInventory = registerBoolGameRule("keepinventory", false)
+	// GameRuleMobGriefing specifies if mobs may change blocks, for example by breaking them.
+	GameRuleMobGriefing = registerBoolGameRule("mobgriefing", true)
+	// GameRuleNaturalRegeneration specifies if players regenerate health when their food level is high enough.
+	GameRuleNaturalRegeneration = registerBoolGameRule("naturalregeneration", true)
+	// GameRuleShowCoordinates specifies if players are shown their coordinates.
+	GameRuleShowCoordinates = registerBoolGameRule("showcoordinates", false)
+	// GameRuleDoImmediateRespawn specifies if players respawn immediately when they die, without being shown the
+	// death screen.
+	GameRuleDoImmediateRespawn = registerBoolGameRule("doimmediaterespawn", false)
+	// GameRuleFallDamage specifies if players take fall damage.
+	GameRuleFallDamage = registerBoolGameRule("falldamage", true)
+	// GameRuleFireDamage specifies if players take damage from fire and lava.
+	GameRuleFireDamage = registerBoolGameRule("firedamage", true)
+	// GameRuleDrowningDamage specifies if players take damage from drowning.
+	GameRuleDrowningDamage = registerBoolGameRule("drowningdamage", true)
+	// GameRuleDoTileDrops specifies if blocks drop items when they are broken.
+	GameRuleDoTileDrops = registerBoolGameRule("dotiledrops", true)
+	// GameRuleDoEntityDrops specifies if entities, such as minecarts, drop items when they are destroyed.
+	GameRuleDoEntityDrops = registerBoolGameRule("doentitydrops", true)
+	// GameRuleDoMobLoot specifies if mobs drop items when they die.
+	GameRuleDoMobLoot = registerBoolGameRule("domobloot", true)
+	// GameRulePVP specifies if players may damage other players.
+	GameRulePVP = registerBoolGameRule("pvp", true)
+	// GameRuleTNTExplodes specifies if TNT explodes when it is ignited.
+	GameRuleTNTExplodes = registerBoolGameRule("tntexplodes", true)
+	// GameRulePlayersSleepingPercentage is the percentage of players that must be sleeping to skip the night.
+	GameRulePlayersSleepingPercentage = registerIntGameRule("playerssleepingpercentage", 100)
+)
+
+// gameRules holds all registered game rules indexed by their name.
+var gameRules = map[string]GameRule{}
+
+// registerBoolGameRule registers a BoolGameRule with the name and default value passed.
+func registerBoolGameRule(name string, def bool) BoolGameRule {
+	r := BoolGameRule{name: name, def: def}
+	gameRules[name] = r
+	return r
+}
+
+// registerIntGameRule registers an IntGameRule with the name and default value passed.
+func registerIntGameRule(name string, def int32) IntGameRule {
+	r := IntGameRule{name: name, def: def}
+	gameRules[name] = r
+	return r
+}
+
+// GameRules returns all game rules, sorted by their name.
+func GameRules() []GameRule {
+	rules := maps.Values(gameRules)
+	slices.SortFunc(rules, func(a, b GameRule) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return rules
+}
+
+// GameRuleByName looks up a GameRule by its name. If no GameRule with the name exists, false is returned.
+func GameRuleByName(name string) (GameRule, bool) {
+	r, ok := gameRules[name]
+	return r, ok
+}
+
+// BoolGameRule returns the value of a BoolGameRule in the World.
+func (w *World) BoolGameRule(r BoolGameRule) bool {
+	if w == nil {
+		return r.def
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	switch r {
+	case GameRuleDoDaylightCycle:
+		return w.set.TimeCycle
+	case GameRuleDoWeatherCycle:
+		return w.set.WeatherCycle
+	}
+	if v, ok := w.set.GameRules[r.name].(bool); ok {
+		return v
+	}
+	return r.def
+}
+
+// SetBoolGameRule changes the value of a BoolGameRule in the World and updates it for all viewers of the World.
+func (w *World) SetBoolGameRule(r BoolGameRule, v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	switch r {
+	case GameRuleDoDaylightCycle:
+		w.set.TimeCycle = v
+	case GameRuleDoWeatherCycle:
+		w.set.WeatherCycle = v
+	default:
+		w.set.setGameRule(r.name, v)
+	}
+	w.set.Unlock()
+	w.viewGameRules(map[string]any{r.name: v})
+}
+
+// IntGameRule returns the value of an IntGameRule in the World.
+func (w *World) IntGameRule(r IntGameRule) int32 {
+	if w == nil {
+		return r.def
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	if v, ok := w.set.GameRules[r.name].(int32); ok {
+		return v
+	}
+	return r.def
+}
+
+// SetIntGameRule changes the value of an IntGameRule in the World and updates it for all viewers of the World.
+func (w *World) SetIntGameRule(r IntGameRule, v int32) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	w.set.setGameRule(r.name, v)
+	w.set.Unlock()
+	w.viewGameRules(map[string]any{r.name: v})
+}
+
+// GameRules returns the values of all game rules in the World, indexed by the names of the game rules.
+func (w *World) GameRules() map[string]any {
+	rules := make(map[string]any, len(gameRules))
+	for name, r := range gameRules {
+		rules[name] = r.Default()
+	}
+	if w == nil {
+		return rules
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	for name, v := range w.set.GameRules {
+		if _, ok := gameRules[name]; ok {
+			rules[name] = v
+		}
+	}
+	rules[GameRuleDoDaylightCycle.name] = w.set.TimeCycle
+	rules[GameRuleDoWeatherCycle.name] = w.set.WeatherCycle
+	return rules
+}
+
+// viewGameRules shows the game rules passed to all viewers of the World.
+func (w *World) viewGameRules(rules map[string]any) {
+	viewers, _ := w.allViewers()
+	for _, v := range viewers {
+		v.ViewGameRules(rules)
+	}
+}
+
+// setGameRule sets the value of a game rule in the Settings. s.Mutex must be held when calling setGameRule.
+func (s *Settings) setGameRule(name string, v any) {
+	if s.GameRules == nil {
+		s.GameRules = make(map[string]any)
+	}
+	s.GameRules[name] = v
+}
@@ -0,0 +1,79 @@
+package generator
+
+import "math"
+
+const (
+	// aquiferWidth and aquiferHeight are the sizes of the cells that aquifers are made up of. Every cell has its
+	// own fluid level.
+	aquiferWidth, aquiferHeight = 16, 12
+	// noFluid is the fluid level of an aquifer cell that is not flooded.
+	noFluid = math.MinInt32
+)
+
+// aquifer decides what fills the open spaces in the terrain. In oceans and rivers, open spaces are filled with water
+// up to the sea level. Deeper underground, the terrain is divided into cells that each have their own
+// fluid level, so that caves may be partially flooded with water, or, deep enough, with lava. Where two cells with
+// a different fluid level meet, a barrier of stone is placed to keep the fluid of the higher cell from hanging in
+// the air.
+// An aquifer is not safe for concurrent use. Every chunk generated has its own aquifer.
+type aquifer struct {
+	g      *Overworld
+	levels map[[3]int]int
+}
+
+// fluid returns the runtime ID of the block that should fill an open space at a position. surface is the predicted
+// height of the surface in the column of the position. The block returned is either air, water, lava or, for
+// barriers between aquifer cells, stone.
+func (a aquifer) fluid(x, y, z int, surface float64) uint32 {
+	if y <= lavaLevel {
+		return lava
+	}
+	if surface < seaLevel {
+		// Oceans and rivers, including any caves below them, are filled up to the global sea level.
+		if y <= seaLevel {
+			return water
+		}
+		return air
+	} else if float64(y) > surface-8 {
+		return air
+	}
+	cx, cy, cz := floorDiv(x, aquiferWidth), floorDiv(y, aquiferHeight), floorDiv(z, aquiferWidth)
+	level := a.level(cx, cy, cz)
+	if y > level {
+		return air
+	}
+	// The fluid in this cell must be kept in place where it borders cells with a lower fluid level.
+	lx, ly, lz := x-cx*aquiferWidth, y-cy*aquiferHeight, z-cz*aquiferWidth
+	if (lx == 0 && a.level(cx-1, cy, cz) < y) || (lx == aquiferWidth-1 && a.level(cx+1, cy, cz) < y) ||
+		(lz == 0 && a.level(cx, cy, cz-1) < y) || (lz == aquiferWidth-1 && a.level(cx, cy, cz+1) < y) ||
+		(ly == 0 && a.level(cx, cy-1, cz) < y-1) {
+		return a.g.stoneAt(x, y, z)
+	}
+	if level < -10 && posHash(a.g.seed, cx, cy, cz)%4 == 0 {
+		return lava
+	}
+	return water
+}
+
+// level returns the fluid level of the aquifer cell at cx, cy and cz, or noFluid if the cell is not flooded.
+func (a aquifer) level(cx, cy, cz int) int {
+	k := [3]int{cx, cy, cz}
+	if l, ok := a.levels[k]; ok {
+		return l
+	}
+	l := noFluid
+	flooding := a.g.flooding.sample(float64(cx*aquiferWidth), float64(cy*aquiferHeight), float64(cz*aquiferWidth))
+	if flooding > 0.3 {
+		l = cy*aquiferHeight + 2 + int(posHash(a.g.seed, cz, cx, cy)%(aquiferHeight-2))
+	}
+	a.levels[k] = l
+	return l
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return (a - b + 1) / b
+	}
+	return a / b
+}
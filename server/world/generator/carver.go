@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	// carverRange is the radius in chunks around a chunk in which caves and canyons may start that reach into the
+	// chunk.
+	carverRange = 8
+
+	saltCaves   = 0x63617665
+	saltCanyons = 0x63616e79
+)
+
+// carve carves caves and canyons into the chunk. Caves and canyons may start in any chunk within carverRange chunks of
+// the chunk. Because the shape of a cave only depends on the seed and the chunk it starts in, every chunk carves its
+// own part of caves that started elsewhere without needing the other chunks to be generated.
+func (ch *overworldChunk) carve() {
+	for x := ch.pos[0] - carverRange; x <= ch.pos[0]+carverRange; x++ {
+		for z := ch.pos[1] - carverRange; z <= ch.pos[1]+carverRange; z++ {
+			ch.caves(chunkRand(ch.g.seed, x, z, saltCaves), int(x), int(z))
+			ch.canyons(chunkRand(ch.g.seed, x, z, saltCanyons), int(x), int(z))
+		}
+	}
+}
+
+// caves carves the caves that start in the chunk at x and z. A chunk may have several caves start in it, each of
+// which may be made up of multiple tunnels and rooms.
+func (ch *overworldChunk) caves(r *rand.Rand, chunkX, chunkZ int) {
+	n := r.Intn(r.Intn(r.Intn(15)+1) + 1)
+	if r.Intn(7) != 0 {
+		return
+	}
+	minY := ch.r.Min() + 8
+	for i := 0; i < n; i++ {
+		x := float64(chunkX*16 + r.Intn(16))
+		y := float64(minY + r.Intn(r.Intn(236)+8))
+		z := float64(chunkZ*16 + r.Intn(16))
+
+		tunnels := 1
+		if r.Intn(4) == 0 {
+			ch.tunnel(tunnel{seed: r.Int63(), x: x, y: y, z: z, width: 1 + r.Float64()*6, start: -1, heightScale: 0.5})
+			tunnels += r.Intn(4)
+		}
+		for j := 0; j < tunnels; j++ {
+			yaw, pitch := r.Float64()*math.Pi*2, (r.Float64()-0.5)*2/8
+			width := r.Float64()*2 + r.Float64()
+			if r.Intn(10) == 0 {
+				width *= r.Float64()*r.Float64()*3 + 1
+			}
+			ch.tunnel(tunnel{seed: r.Int63(), x: x, y: y, z: z, width: width, yaw: yaw, pitch: pitch, heightScale: 1})
+		}
+	}
+}
+
+// canyons carves the canyon that may start in the chunk at x and z.
+func (ch *overworldChunk) canyons(r *rand.Rand, chunkX, chunkZ int) {
+	if r.Intn(50) != 0 {
+		return
+	}
+	x := float64(chunkX*16 + r.Intn(16))
+	y := float64(r.Intn(r.Intn(40)+8) + 10)
+	z := float64(chunkZ*16 + r.Intn(16))
+	yaw, pitch := r.Float64()*math.Pi*2, (r.Float64()-0.5)*2/8
+	width := (r.Float64()*2 + r.Float64()) * 2
+	ch.tunnel(tunnel{seed: r.Int63(), x: x, y: y, z: z, width: width, yaw: yaw, pitch: pitch, heightScale: 3, canyon: true})
+}
+
+// tunnel holds the parameters of a single tunnel carved by a carver.
+type tunnel struct {
+	seed              int64
+	x, y, z           float64
+	width, yaw, pitch float64
+	start, end        int
+	heightScale       float64
+	canyon            bool
+}
+
+// tunnel carves a tunnel into the chunk. A tunnel is a sequence of ellipsoids that follows a randomly curving path.
+// A tunnel with a start of -1 is a room: A single, large ellipsoid.
+func (ch *overworldChunk) tunnel(t tunnel) {
+	centreX, centreZ := float64(ch.baseX+8), float64(ch.baseZ+8)
+	var yawChange, pitchChange float64
+	r := rand.New(&splitMix{s: uint64(t.seed)})
+
+	if t.end <= 0 {
+		maxLength := carverRange*16 - 16
+		t.end = maxLength - r.Intn(maxLength/4)
+	}
+	room := false
+	if t.start == -1 {
+		t.start, room = t.end/2, true
+	}
+	branch := r.Intn(t.end/2) + t.end/4
+	steep := r.Intn(6) == 0
+
+	for ; t.start < t.end; t.start++ {
+		horizontal := 1.5 + math.Sin(float64(t.start)*math.Pi/float64(t.end))*t.width
+		vertical := horizontal * t.heightScale
+
+		cos := math.Cos(t.pitch)
+		t.x += math.Cos(t.yaw) * cos
+		t.y += math.Sin(t.pitch)
+		t.z += math.Sin(t.yaw) * cos
+
+		if steep || t.canyon {
+			t.pitch *= 0.92
+		} else {
+			t.pitch *= 0.7
+		}
+		t.pitch += pitchChange * 0.1
+		t.yaw += yawChange * 0.1
+		pitchChange *= 0.9
+		yawChange *= 0.75
+		pitchChange += (r.Float64() - r.Float64()) * r.Float64() * 2
+		yawChange += (r.Float64() - r.Float64()) * r.Float64() * 4
+
+		if !room && !t.canyon && t.start == branch && t.width > 1 {
+			left, right := t, t
+			left.seed, left.width, left.yaw, left.pitch, left.heightScale = r.Int63(), r.Float64()*0.5+0.5, t.yaw-math.Pi/2, t.pitch/3, 1
+			right.seed, right.width, right.yaw, right.pitch, right.heightScale = r.Int63(), r.Float64()*0.5+0.5, t.yaw+math.Pi/2, t.pitch/3, 1
+			ch.tunnel(left)
+			ch.tunnel(right)
+			return
+		}
+		if !room && r.Intn(4) == 0 {
+			continue
+		}
+		dx, dz := t.x-centreX, t.z-centreZ
+		remaining, maxWidth := float64(t.end-t.start), t.width+2+16
+		if dx*dx+dz*dz-remaining*remaining > maxWidth*maxWidth {
+			// The tunnel can no longer reach this chunk.
+			return
+		}
+		if t.x >= centreX-16-horizontal*2 && t.z >= centreZ-16-horizontal*2 && t.x <= centreX+16+horizontal*2 && t.z <= centreZ+16+horizontal*2 {
+			ch.carveEllipsoid(t.x, t.y, t.z, horizontal, vertical, t.canyon)
+			if room {
+				return
+			}
+		}
+	}
+}
+
+// carveEllipsoid carves an ellipsoid centred at x, y and z with a horizontal and vertical radius. Only the part of
+// the ellipsoid within the chunk is carved.
+func (ch *overworldChunk) carveEllipsoid(x, y, z, horizontal, vertical float64, canyon bool) {
+	minX, maxX := max(int(math.Floor(x-horizontal))-1, ch.baseX), min(int(math.Floor(x+horizontal))+1, ch.baseX+15)
+	minZ, maxZ := max(int(math.Floor(z-horizontal))-1, ch.baseZ), min(int(math.Floor(z+horizontal))+1, ch.baseZ+15)
+	minY, maxY := max(int(math.Floor(y-vertical))-1, ch.r.Min()+1), min(int(math.Floor(y+vertical))+1, ch.r.Max()-1)
+
+	for bx := minX; bx <= maxX; bx++ {
+		dx := (float64(bx) + 0.5 - x) / horizontal
+		for bz := minZ; bz <= maxZ; bz++ {
+			dz := (float64(bz) + 0.5 - z) / horizontal
+			if dx*dx+dz*dz >= 1 {
+				continue
+			}
+			for by := maxY; by >= minY; by-- {
+				dy := (float64(by) + 0.5 - y) / vertical
+				if canyon && dx*dx+dz*dz >= 1-dy*dy/6 {
+					continue
+				} else if !canyon && (dy <= -0.7 || dx*dx+dy*dy+dz*dz >= 1) {
+					continue
+				}
+				ch.carveBlock(bx, by, bz)
+			}
+		}
+	}
+}
+
+// carveBlock carves a single block at a world position in the chunk. Fluids are never carved, nor are blocks
+// directly below fluids, so that oceans and aquifers are not drained into caves.
+func (ch *overworldChunk) carveBlock(x, y, z int) {
+	rid := ch.block(x, y, z)
+	if rid == air || rid == water || rid == lava || rid == bedrock || ch.block(x, y+1, z) == water {
+		return
+	}
+	fill := ch.aq.fluid(x, y, z, ch.surface[x-ch.baseX][z-ch.baseZ])
+	if fill != air && fill != water && fill != lava {
+		// A barrier between two aquifers: Leave the block in place.
+		return
+	}
+	ch.setBlock(x, y, z, fill)
+}
@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
+)
+
+// Decorator decorates a chunk while it is being populated, for example by placing trees, flowers or lakes in it.
+// Generators that implement world.Populator run a list of Decorators for the biome of every chunk they populate.
+type Decorator interface {
+	// Decorate decorates the chunk at the position passed. Decorate may place features that extend into the chunks
+	// surrounding it through the world.Population passed. The rand.Rand passed is seeded for the chunk and the
+	// Decorator, so that the same world seed always produces the same decorations.
+	Decorate(pos world.ChunkPos, p *world.Population, r *rand.Rand)
+}
+
+// decorate runs the Decorators passed for the chunk at pos, giving each Decorator its own rand.Rand.
+func decorate(seed int64, pos world.ChunkPos, p *world.Population, decorators []Decorator) {
+	for i, d := range decorators {
+		d.Decorate(pos, p, chunkRand(seed, pos[0], pos[1], uint64(i)+saltDecorators))
+	}
+}
+
+// surfaceAt returns the position of the highest block at a random x and z within the chunk at pos.
+func surfaceAt(pos world.ChunkPos, p *world.Population, r *rand.Rand) cube.Pos {
+	x, z := int(pos[0])<<4+r.Intn(16), int(pos[1])<<4+r.Intn(16)
+	return cube.Pos{x, p.HighestBlock(x, z), z}
+}
+
+// TreeDecorator places trees on the grass and dirt in a chunk. The trees placed are picked randomly from a list of
+// tree kinds.
+type TreeDecorator struct {
+	// Count is the amount of trees attempted to be placed in a chunk.
+	Count int
+	// Chance is the chance, from 0 to 1, that trees are placed in a chunk at all. A Chance of 0 is treated as 1.
+	Chance float64
+	// Kinds holds the kinds of trees to pick from. Every kind is a wood type with a spruce-like (cone) shape if
+	// Cone is true.
+	Kinds []TreeKind
+}
+
+// TreeKind is a kind of tree placed by a TreeDecorator.
+type TreeKind struct {
+	// Wood is the wood type of the logs and leaves of the tree.
+	Wood block.WoodType
+	// Cone specifies if the tree has the cone-shaped canopy of spruce trees rather than the round canopy of oak
+	// trees.
+	Cone bool
+	// MinHeight and MaxHeight are the bounds of the height of the trunk of the tree.
+	MinHeight, MaxHeight int
+}
+
+// Decorate ...
+func (d TreeDecorator) Decorate(pos world.ChunkPos, p *world.Population, r *rand.Rand) {
+	if d.Chance > 0 && r.Float64() >= d.Chance {
+		return
+	}
+	for i := 0; i < d.Count; i++ {
+		ground := surfaceAt(pos, p, r)
+		kind := d.Kinds[r.Intn(len(d.Kinds))]
+		shape := roundTree
+		if kind.Cone {
+			shape = coneTree
+		}
+		t := newTree(r, kind.Wood, shape, kind.MinHeight, kind.MaxHeight)
+		if !soil(p.Block(ground)) || !t.fits(p, ground.Side(cube.FaceUp)) {
+			continue
+		}
+		p.SetBlock(ground, block.Dirt{})
+		p.BuildStructure(ground.Add(cube.Pos{-t.radius(), 1, -t.radius()}), t)
+	}
+}
+
+// fits checks if the trunk of a tree fits at the position passed.
+func (t tree) fits(p *world.Population, pos cube.Pos) bool {
+	if pos[1]+t.height >= p.Range()[1] {
+		return false
+	}
+	for y := 0; y < t.height; y++ {
+		switch p.Block(pos.Add(cube.Pos{0, y, 0})).(type) {
+		case block.Air, block.Leaves:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// soil checks if trees and plants may grow on the block passed.
+func soil(b world.Block) bool {
+	switch b.(type) {
+	case block.Grass, block.Dirt, block.Podzol:
+		return true
+	}
+	return false
+}
+
+// PlantDecorator scatters plants, such as flowers and tall grass, on the surface of a chunk. A plant is only placed
+// if the block below it can support it.
+type PlantDecorator struct {
+	// Count is the amount of plants attempted to be placed in a chunk.
+	Count int
+	// Plants holds the plants to pick from. If a plant is a block.DoubleFlower or block.DoubleTallGrass, its upper
+	// part is placed as well.
+	Plants []world.Block
+	// On checks if a plant may be placed on a block. If nil, plants are placed on grass, dirt and podzol.
+	On func(b world.Block) bool
+}
+
+// Decorate ...
+func (d PlantDecorator) Decorate(pos world.ChunkPos, p *world.Population, r *rand.Rand) {
+	on := d.On
+	if on == nil {
+		on = soil
+	}
+	for i := 0; i < d.Count; i++ {
+		ground := surfaceAt(pos, p, r)
+		plant := d.Plants[r.Intn(len(d.Plants))]
+		if !on(p.Block(ground)) || !p.Contains(ground.Add(cube.Pos{0, 2, 0})) {
+			continue
+		}
+		above := ground.Side(cube.FaceUp)
+		var upper world.Block
+		switch b := plant.(type) {
+		case block.DoubleFlower:
+			b.UpperPart = true
+			upper = b
+		case block.DoubleTallGrass:
+			b.UpperPart = true
+			upper = b
+		}
+		if _, ok := p.Block(above).(block.Air); !ok {
+			continue
+		}
+		if upper != nil {
+			if _, ok := p.Block(above.Side(cube.FaceUp)).(block.Air); !ok {
+				continue
+			}
+			p.SetBlock(above.Side(cube.FaceUp), upper)
+		}
+		p.SetBlock(above, plant)
+	}
+}
+
+// LakeDecorator places lakes of water or lava at the surface of a chunk.
+type LakeDecorator struct {
+	// Chance is the chance, from 0 to 1, that a lake is attempted to be placed in a chunk.
+	Chance float64
+	// Fluid is the liquid that the lake is filled with, such as block.Water or block.Lava.
+	Fluid world.Liquid
+}
+
+// Decorate ...
+func (d LakeDecorator) Decorate(pos world.ChunkPos, p *world.Population, r *rand.Rand) {
+	if r.Float64() >= d.Chance {
+		return
+	}
+	l := newLake(r, d.Fluid)
+	ground := surfaceAt(pos, p, r)
+	// The lake is placed so that its fluid surface is level with the surface of the terrain.
+	origin := cube.Pos{int(pos[0]) << 4, ground[1] - 4, int(pos[1]) << 4}
+	if origin[1] <= p.Range()[0] || origin[1]+8 > p.Range()[1] || !l.fits(p, origin) {
+		return
+	}
+	p.BuildStructure(origin, l)
+}
+
+// OreDecorator places veins of ore in the stone and deepslate of a chunk. The Overworld generator already places the
+// common ores of every biome while generating chunks, so OreDecorator is typically used to place additional ores in
+// specific biomes only.
+type OreDecorator struct {
+	// Ore is the block placed where a vein replaces stone. DeepslateOre is the block placed where a vein replaces
+	// deepslate. If DeepslateOre is nil, Ore is placed in deepslate too.
+	Ore, DeepslateOre world.Block
+	// Size is the amount of blocks in a single vein.
+	Size int
+	// Count is the amount of veins attempted to be placed in a chunk.
+	Count int
+	// MinY and MaxY are the bounds of the heights at which veins are placed.
+	MinY, MaxY int
+}
+
+// Decorate ...
+func (d OreDecorator) Decorate(pos world.ChunkPos, p *world.Population, r *rand.Rand) {
+	deepslateOre := d.DeepslateOre
+	if deepslateOre == nil {
+		deepslateOre = d.Ore
+	}
+	o := ore{minY: max(d.MinY, p.Range()[0]), maxY: min(d.MaxY, p.Range()[1])}
+	if o.maxY < o.minY {
+		return
+	}
+	for i := 0; i < d.Count; i++ {
+		x, y, z := int(pos[0])<<4+r.Intn(16), o.y(r), int(pos[1])<<4+r.Intn(16)
+		vein(r, d.Size, x, y, z, func(x, y, z int) {
+			at := cube.Pos{x, y, z}
+			if !p.Contains(at) {
+				return
+			}
+			switch world.BlockRuntimeID(p.Block(at)) {
+			case stone:
+				p.SetBlock(at, d.Ore)
+			case deepslate:
+				p.SetBlock(at, deepslateOre)
+			}
+		})
+	}
+}
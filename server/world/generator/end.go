@@ -0,0 +1,270 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math"
+)
+
+const (
+	// endIslandLevel is the Y level around which the islands of the end are centred.
+	endIslandLevel = 56
+	// endVoidRadius is the radius in chunks around the centre of the end in which no outer islands are generated,
+	// forming the void between the main island and the outer islands.
+	endVoidRadius = 64
+	// spikeCount and spikeDistance are the amount of obsidian spikes on the main island and the distance from the
+	// centre of the end at which they are placed.
+	spikeCount, spikeDistance = 10, 42
+)
+
+var (
+	endStone = world.BlockRuntimeID(block.EndStone{})
+	obsidian = world.BlockRuntimeID(block.Obsidian{})
+	portal   = world.BlockRuntimeID(block.EndPortal{})
+	ironBars = world.BlockRuntimeID(block.IronBars{})
+)
+
+// End is a generator that produces terrain similar to that of the vanilla end: A large main island of end stone in
+// the centre, surrounded by a ring of obsidian spikes, and many smaller outer islands beyond a large void around the
+// main island.
+// End may be constructed by calling NewEnd. The same seed always produces the same terrain.
+type End struct {
+	seed int64
+
+	islands *perlin
+	detail  octaves
+	spikes  [spikeCount]spike
+}
+
+// NewEnd creates a new End generator using the seed passed.
+func NewEnd(seed int64) *End {
+	g := &End{
+		seed:    seed,
+		islands: newPerlin(namedRand(seed, "end_islands")),
+		detail:  newOctaves(namedRand(seed, "end_detail"), -5, 1, 1, 0.5),
+	}
+	g.spikes = endSpikes(seed)
+	return g
+}
+
+// spike is a single obsidian spike found on the main island of the end.
+type spike struct {
+	x, z, radius, height int
+	// guarded specifies if the top of the spike is enclosed by a cage of iron bars.
+	guarded bool
+}
+
+// endSpikes returns the obsidian spikes on the main island of the end. The spikes are placed in a circle around the
+// centre, with their sizes shuffled based on the seed.
+func endSpikes(seed int64) (spikes [spikeCount]spike) {
+	sizes := namedRand(seed, "end_spikes").Perm(spikeCount)
+	for i, size := range sizes {
+		angle := 2 * (-math.Pi + math.Pi/spikeCount*float64(i))
+		spikes[i] = spike{
+			x:       int(math.Floor(spikeDistance * math.Cos(angle))),
+			z:       int(math.Floor(spikeDistance * math.Sin(angle))),
+			radius:  2 + size/3,
+			height:  76 + size*3,
+			guarded: size == 1 || size == 2,
+		}
+	}
+	return spikes
+}
+
+// islandHeight returns a value between -100 and 80 that indicates how much terrain an island has at a position.
+// The main island is found around the centre of the end, while outer islands are only generated beyond
+// endVoidRadius chunks away from it.
+func (g *End) islandHeight(x, z int) float64 {
+	// The shape of the islands is decided on a grid of 8x8 cells, two of which make up the width of a chunk.
+	cx, cz := float64(x)/8, float64(z)/8
+	i, j := floorDiv(x, 16), floorDiv(z, 16)
+	k, l := cx-float64(i*2), cz-float64(j*2)
+
+	h := clamp(100-math.Sqrt(cx*cx+cz*cz)*8, -100, 80)
+	if (abs(i)+13)*(abs(i)+13)+(abs(j)+13)*(abs(j)+13) <= endVoidRadius*endVoidRadius {
+		// No chunk within reach could contain an outer island.
+		return h
+	}
+	for dx := -12; dx <= 12; dx++ {
+		for dz := -12; dz <= 12; dz++ {
+			ix, iz := i+dx, j+dz
+			if ix*ix+iz*iz <= endVoidRadius*endVoidRadius || g.islands.noise(float64(ix)*0.9, 0, float64(iz)*0.9) > -0.6 {
+				continue
+			}
+			size := float64((abs(ix)*3439+abs(iz)*147)%13 + 9)
+			fx, fz := k-float64(dx*2), l-float64(dz*2)
+			h = max(h, clamp(100-math.Sqrt(fx*fx+fz*fz)*size, -100, 80))
+		}
+	}
+	return h
+}
+
+// density returns the density of the terrain at a position, given the island height of the column. Positions with
+// a density higher than 0 are solid. Islands extend further down than up from endIslandLevel.
+func (g *End) density(x, y, z int, height float64) float64 {
+	d := height + g.detail.sample(float64(x), float64(y), float64(z))*12
+	if y > endIslandLevel {
+		return d - float64(y-endIslandLevel)*4
+	}
+	return d - float64(endIslandLevel-y)*2
+}
+
+// GenerateChunk ...
+func (g *End) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	r := c.Range()
+
+	g.fill(c, baseX, baseZ, r)
+	g.placeSpikes(c, baseX, baseZ, r)
+	g.placeExitPortal(c, baseX, baseZ, r)
+
+	id := uint32(biome.End{}.EncodeBiome())
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			for y := r.Min(); y <= r.Max(); y++ {
+				c.SetBiome(x, int16(y), z, id)
+			}
+		}
+	}
+}
+
+// fill fills the chunk at baseX and baseZ with end stone based on the density of the terrain. The density is
+// sampled on the corners of cells and interpolated between them.
+func (g *End) fill(c *chunk.Chunk, baseX, baseZ int, r cube.Range) {
+	const n = 16/cellWidth + 1
+
+	var heights [n][n]float64
+	empty := true
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			heights[i][j] = g.islandHeight(baseX+i*cellWidth, baseZ+j*cellWidth)
+			if heights[i][j] > -40 {
+				empty = false
+			}
+		}
+	}
+	if empty {
+		// The detail noise can never raise the density above 0 here, so the chunk is left empty.
+		return
+	}
+
+	minY, cellsY := r.Min(), (r.Height()+1)/cellHeight
+	densities := make([][n][n]float64, cellsY+1)
+	for k := range densities {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				densities[k][i][j] = g.density(baseX+i*cellWidth, minY+k*cellHeight, baseZ+j*cellWidth, heights[i][j])
+			}
+		}
+	}
+	for k := 0; k < cellsY; k++ {
+		lower, upper := densities[k], densities[k+1]
+		for dy := 0; dy < cellHeight; dy++ {
+			y, ty := minY+k*cellHeight+dy, float64(dy)/cellHeight
+			for x := 0; x < 16; x++ {
+				i, tx := x/cellWidth, float64(x%cellWidth)/cellWidth
+				for z := 0; z < 16; z++ {
+					j, tz := z/cellWidth, float64(z%cellWidth)/cellWidth
+					d := lerp(ty,
+						lerp(tz, lerp(tx, lower[i][j], lower[i+1][j]), lerp(tx, lower[i][j+1], lower[i+1][j+1])),
+						lerp(tz, lerp(tx, upper[i][j], upper[i+1][j]), lerp(tx, upper[i][j+1], upper[i+1][j+1])),
+					)
+					if d > 0 {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, endStone)
+					}
+				}
+			}
+		}
+	}
+}
+
+// placeSpikes places the parts of the obsidian spikes on the main island that are within the chunk at baseX and
+// baseZ. The top of every spike is capped with bedrock.
+func (g *End) placeSpikes(c *chunk.Chunk, baseX, baseZ int, r cube.Range) {
+	for _, s := range g.spikes {
+		if s.x+s.radius+2 < baseX || s.x-s.radius-2 >= baseX+16 || s.z+s.radius+2 < baseZ || s.z-s.radius-2 >= baseZ+16 {
+			continue
+		}
+		for x := 0; x < 16; x++ {
+			for z := 0; z < 16; z++ {
+				dx, dz := baseX+x-s.x, baseZ+z-s.z
+				if dx*dx+dz*dz <= s.radius*s.radius+1 {
+					for y := r.Min(); y < s.height; y++ {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, obsidian)
+					}
+				}
+				if dx == 0 && dz == 0 {
+					c.SetBlock(uint8(x), int16(s.height), uint8(z), 0, bedrock)
+				}
+				if s.guarded && abs(dx) <= 2 && abs(dz) <= 2 && (abs(dx) == 2 || abs(dz) == 2) {
+					for y := s.height; y <= s.height+3; y++ {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, ironBars)
+					}
+				}
+				if s.guarded && abs(dx) <= 2 && abs(dz) <= 2 {
+					c.SetBlock(uint8(x), int16(s.height+3), uint8(z), 0, ironBars)
+				}
+			}
+		}
+	}
+}
+
+// surface returns the Y value of the highest end stone block in the column at x and z. x and z must be on the
+// corner of a cell, where the density of the terrain does not have to be interpolated on the horizontal axes. If the
+// column is empty, the minimum of the range passed is returned.
+func (g *End) surface(x, z int, r cube.Range) int {
+	height := g.islandHeight(x, z)
+	top := r.Min() + ((r.Height()+1)/cellHeight)*cellHeight
+	for y := top - 1; y >= r.Min(); y-- {
+		lower := r.Min() + floorDiv(y-r.Min(), cellHeight)*cellHeight
+		ty := float64(y-lower) / cellHeight
+		if lerp(ty, g.density(x, lower, z, height), g.density(x, lower+cellHeight, z, height)) > 0 {
+			return y
+		}
+	}
+	return r.Min()
+}
+
+// placeExitPortal places the parts of the exit portal in the centre of the main island that are within the chunk at
+// baseX and baseZ. The exit portal is a bedrock fountain filled with end portal blocks that lead back to the
+// overworld. As there is no ender dragon to defeat, the portal is active right away.
+func (g *End) placeExitPortal(c *chunk.Chunk, baseX, baseZ int, r cube.Range) {
+	if baseX > 0 || baseX < -16 || baseZ > 0 || baseZ < -16 {
+		return
+	}
+	y := g.surface(0, 0, r)
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			dx, dz := baseX+x, baseZ+z
+			d := dx*dx + dz*dz
+			if d > 12 {
+				continue
+			}
+			c.SetBlock(uint8(x), int16(y), uint8(z), 0, bedrock)
+			for dy := 1; dy <= 4; dy++ {
+				c.SetBlock(uint8(x), int16(y+dy), uint8(z), 0, air)
+			}
+			switch {
+			case d == 0:
+				for dy := 1; dy <= 4; dy++ {
+					c.SetBlock(uint8(x), int16(y+dy), uint8(z), 0, bedrock)
+				}
+			case d <= 6:
+				c.SetBlock(uint8(x), int16(y+1), uint8(z), 0, portal)
+			default:
+				c.SetBlock(uint8(x), int16(y+1), uint8(z), 0, bedrock)
+			}
+		}
+	}
+}
+
+// abs returns the absolute value of an integer.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
+)
+
+// treeShape is the shape of the canopy of a tree.
+type treeShape int
+
+const (
+	// roundTree is the shape of oak, birch and jungle trees: A trunk with two wide layers of leaves followed by two
+	// narrow layers on top.
+	roundTree treeShape = iota
+	// coneTree is the shape of spruce trees: Layers of leaves that become wider towards the bottom of the trunk.
+	coneTree
+)
+
+// tree is a world.Structure that places a tree with a trunk of logs and a canopy of leaves. The origin of the
+// structure is offset from the trunk, so that the trunk is in the centre of the structure horizontally.
+type tree struct {
+	log, leaves world.Block
+	height      int
+	shape       treeShape
+	// seed is used to randomly leave out the corners of the canopy.
+	seed int64
+}
+
+// newTree returns a tree of a wood type with a random height between min and max.
+func newTree(r *rand.Rand, wood block.WoodType, shape treeShape, min, max int) tree {
+	return tree{
+		log:    block.Log{Wood: wood, Axis: cube.Y},
+		leaves: block.Leaves{Wood: wood},
+		height: min + r.Intn(max-min+1),
+		shape:  shape,
+		seed:   r.Int63(),
+	}
+}
+
+// radius returns the largest horizontal distance of the canopy from the trunk.
+func (t tree) radius() int {
+	if t.shape == coneTree {
+		return 3
+	}
+	return 2
+}
+
+// Dimensions ...
+func (t tree) Dimensions() [3]int {
+	d := t.radius()*2 + 1
+	return [3]int{d, t.height + 1, d}
+}
+
+// At ...
+func (t tree) At(x, y, z int, blockAt func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	r := t.radius()
+	dx, dz := abs(x-r), abs(z-r)
+	if dx == 0 && dz == 0 && y < t.height {
+		return t.log, nil
+	}
+	if !t.leavesAt(dx, y, dz, posHash(t.seed, x, y, z)) {
+		return nil, nil
+	}
+	if _, ok := blockAt(x, y, z).(block.Air); !ok {
+		// Leaves never replace existing blocks.
+		return nil, nil
+	}
+	return t.leaves, nil
+}
+
+// leavesAt checks if leaves are placed at a horizontal distance dx and dz from the trunk at a height y.
+func (t tree) leavesAt(dx, y, dz int, hash uint64) bool {
+	top := t.height
+	switch t.shape {
+	case coneTree:
+		if y > top || y < 3 {
+			return false
+		}
+		// The width of the layers alternates, becoming wider further down the tree.
+		width := (top - y + 1) / 2
+		if (top-y)%2 == 1 {
+			width--
+		}
+		width = min(width, 3)
+		return dx+dz <= width+1 && dx <= width && dz <= width && !(dx == width && dz == width && width > 0)
+	default:
+		rel := y - top
+		switch {
+		case rel < -3 || rel > 0:
+			return false
+		case rel >= -1:
+			// The two narrow top layers. The corners of the top layer are never placed.
+			if dx > 1 || dz > 1 {
+				return false
+			}
+			return !(dx == 1 && dz == 1) || (rel == -1 && hash%2 == 0)
+		}
+		if dx == 2 && dz == 2 {
+			return hash%2 == 0 && rel == -3
+		}
+		return true
+	}
+}
+
+// lake is a world.Structure that places a lake of water or lava. The lake is formed by a number of overlapping
+// ellipsoids, the lower half of which is filled with the fluid, while the upper half is air.
+type lake struct {
+	fluid world.Liquid
+	// shape holds the positions within the 16x8x16 area of the lake that are part of it.
+	shape [16][8][16]bool
+}
+
+// newLake returns a lake filled with the fluid passed, with a random shape.
+func newLake(r *rand.Rand, fluid world.Liquid) *lake {
+	l := &lake{fluid: fluid}
+	for i := r.Intn(4) + 4; i > 0; i-- {
+		sx, sy, sz := r.Float64()*6+3, r.Float64()*4+2, r.Float64()*6+3
+		cx := r.Float64()*(16-sx-2) + 1 + sx/2
+		cy := r.Float64()*(8-sy-4) + 2 + sy/2
+		cz := r.Float64()*(16-sz-2) + 1 + sz/2
+		for x := 1; x < 15; x++ {
+			for z := 1; z < 15; z++ {
+				for y := 1; y < 7; y++ {
+					dx, dy, dz := (float64(x)-cx)/(sx/2), (float64(y)-cy)/(sy/2), (float64(z)-cz)/(sz/2)
+					if dx*dx+dy*dy+dz*dz < 1 {
+						l.shape[x][y][z] = true
+					}
+				}
+			}
+		}
+	}
+	return l
+}
+
+// edge checks if the position passed borders the lake without being part of it.
+func (l *lake) edge(x, y, z int) bool {
+	if l.shape[x][y][z] {
+		return false
+	}
+	return (x < 15 && l.shape[x+1][y][z]) || (x > 0 && l.shape[x-1][y][z]) ||
+		(z < 15 && l.shape[x][y][z+1]) || (z > 0 && l.shape[x][y][z-1]) ||
+		(y < 7 && l.shape[x][y+1][z]) || (y > 0 && l.shape[x][y-1][z])
+}
+
+// fits checks if the lake may be placed with its lower corner at pos. A lake cannot be placed where its fluid would
+// be exposed to air or other liquids, or where its air would be exposed to liquids.
+func (l *lake) fits(p *world.Population, pos cube.Pos) bool {
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for y := 0; y < 8; y++ {
+				if !l.edge(x, y, z) {
+					continue
+				}
+				at := pos.Add(cube.Pos{x, y, z})
+				if _, ok := p.Liquid(at); ok {
+					return false
+				}
+				if _, air := p.Block(at).(block.Air); air && y < 4 {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Dimensions ...
+func (l *lake) Dimensions() [3]int {
+	return [3]int{16, 8, 16}
+}
+
+// At ...
+func (l *lake) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	if !l.shape[x][y][z] {
+		return nil, nil
+	}
+	if y < 4 {
+		return l.fluid, nil
+	}
+	return block.Air{}, nil
+}
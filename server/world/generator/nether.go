@@ -0,0 +1,378 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math"
+	"math/rand"
+)
+
+const (
+	// netherLavaLevel is the Y level up to which open spaces in the nether are filled with lava.
+	netherLavaLevel = 31
+
+	saltGlowstone = 0x676c6f77
+	saltNetherOre = 0x6e6f7265
+)
+
+var (
+	netherrack = world.BlockRuntimeID(block.Netherrack{})
+	soulSand   = world.BlockRuntimeID(block.SoulSand{})
+	soulSoil   = world.BlockRuntimeID(block.SoulSoil{})
+	basalt     = world.BlockRuntimeID(block.Basalt{Axis: cube.Y})
+	blackstone = world.BlockRuntimeID(block.Blackstone{Type: block.NormalBlackstone()})
+	glowstone  = world.BlockRuntimeID(block.Glowstone{})
+)
+
+// netherOres holds all ores placed by the Nether generator, in the order that they are placed. Nether ores replace
+// netherrack, which is the only block used for the stone field of the ore.
+var netherOres = []ore{
+	newNetherOre(block.NetherQuartzOre{}, 14, 16, 10, 117, false),
+	newNetherOre(block.NetherGoldOre{}, 10, 10, 10, 117, false),
+	newNetherOre(block.AncientDebris{}, 3, 1, 8, 22, true),
+	newNetherOre(block.AncientDebris{}, 2, 1, 8, 119, false),
+	newNetherOre(block.Gravel{}, 33, 2, 5, 41, false),
+	newNetherOre(block.Blackstone{Type: block.NormalBlackstone()}, 33, 2, 5, 31, false),
+}
+
+// newNetherOre returns an ore that places the block passed in netherrack.
+func newNetherOre(b world.Block, size, count, minY, maxY int, triangle bool) ore {
+	o := newBlob(b, size, count, minY, maxY)
+	o.triangle = triangle
+	return o
+}
+
+// netherBiome is a biome of the nether together with the climate at which it is found. A position in the nether
+// takes the biome whose climate is closest to the climate at that position.
+type netherBiome struct {
+	b                             world.Biome
+	temperature, humidity, offset float64
+}
+
+// netherBiomes holds all biomes that are placed by the Nether generator.
+var netherBiomes = []netherBiome{
+	{b: biome.NetherWastes{}},
+	{b: biome.SoulSandValley{}, humidity: -0.5},
+	{b: biome.CrimsonForest{}, temperature: 0.4},
+	{b: biome.WarpedForest{}, humidity: 0.5, offset: 0.375},
+	{b: biome.BasaltDeltas{}, temperature: -0.5, offset: 0.175},
+}
+
+// Nether is a generator that produces terrain similar to that of the vanilla nether: Large caverns shaped by
+// three-dimensional noise, enclosed by a floor and ceiling of bedrock, with oceans of lava up to Y=31. The nether
+// biomes are placed based on a temperature and humidity noise, each with their own surface. Glowstone hangs from
+// the ceilings of caverns and nether quartz, nether gold and ancient debris are found throughout the netherrack.
+// Nether may be constructed by calling NewNether. The same seed always produces the same terrain.
+type Nether struct {
+	seed int64
+
+	caverns               normalNoise
+	detail                octaves
+	temperature, humidity normalNoise
+	surface, patches      normalNoise
+}
+
+// NewNether creates a new Nether generator using the seed passed.
+func NewNether(seed int64) *Nether {
+	return &Nether{
+		seed:        seed,
+		caverns:     newNormalNoise(namedRand(seed, "nether_caverns"), -6, 1, 1, 0.5),
+		detail:      newOctaves(namedRand(seed, "nether_detail"), -3, 1, 0.5),
+		temperature: newNormalNoise(namedRand(seed, "nether_temperature"), -7, 1, 1),
+		humidity:    newNormalNoise(namedRand(seed, "nether_humidity"), -7, 1, 1),
+		surface:     newNormalNoise(namedRand(seed, "nether_surface"), -4, 1, 1),
+		patches:     newNormalNoise(namedRand(seed, "nether_patches"), -3, 1, 1),
+	}
+}
+
+// density returns the density of the nether terrain at a position. Positions with a density higher than 0 are
+// solid. The terrain becomes solid towards the top and bottom of the nether to form a floor and a ceiling.
+func (g *Nether) density(x, y, z int) float64 {
+	fx, fy, fz := float64(x), float64(y), float64(z)
+	d := g.caverns.sample(fx, fy*2, fz)*1.5 + g.detail.sample(fx, fy*2, fz)*0.2 - 0.1
+	if y > 104 {
+		d += float64(y-104) / 8
+	} else if y < 12 {
+		d += float64(12-y) / 6
+	}
+	return d
+}
+
+// densityAt returns the density of the terrain at a position, interpolated between the corners of the cell that it
+// is in, exactly as it is when the terrain is filled.
+func (g *Nether) densityAt(x, y, z int, cache map[[3]int]float64) float64 {
+	cx, cy, cz := floorDiv(x, cellWidth)*cellWidth, floorDiv(y, cellHeight)*cellHeight, floorDiv(z, cellWidth)*cellWidth
+	corner := func(dx, dy, dz int) float64 {
+		k := [3]int{cx + dx, cy + dy, cz + dz}
+		if d, ok := cache[k]; ok {
+			return d
+		}
+		d := g.density(k[0], k[1], k[2])
+		cache[k] = d
+		return d
+	}
+	tx, ty, tz := float64(x-cx)/cellWidth, float64(y-cy)/cellHeight, float64(z-cz)/cellWidth
+	return lerp(ty,
+		lerp(tz, lerp(tx, corner(0, 0, 0), corner(cellWidth, 0, 0)), lerp(tx, corner(0, 0, cellWidth), corner(cellWidth, 0, cellWidth))),
+		lerp(tz, lerp(tx, corner(0, cellHeight, 0), corner(cellWidth, cellHeight, 0)), lerp(tx, corner(0, cellHeight, cellWidth), corner(cellWidth, cellHeight, cellWidth))),
+	)
+}
+
+// biomeAt returns the nether biome at a position.
+func (g *Nether) biomeAt(x, y, z int) world.Biome {
+	fx, fy, fz := float64(x), float64(y), float64(z)
+	t, h := g.temperature.sample(fx, fy, fz), g.humidity.sample(fx, fy, fz)
+
+	closest, dist := netherBiomes[0].b, math.MaxFloat64
+	for _, b := range netherBiomes {
+		dt, dh := t-b.temperature, h-b.humidity
+		if d := dt*dt + dh*dh + b.offset*b.offset; d < dist {
+			closest, dist = b.b, d
+		}
+	}
+	return closest
+}
+
+// netherChunk holds the state of a chunk while it is being generated by a Nether generator.
+type netherChunk struct {
+	g   *Nether
+	c   *chunk.Chunk
+	pos world.ChunkPos
+	r   cube.Range
+
+	baseX, baseZ int
+	// densities caches the densities of the corners of cells, used to predict the terrain outside the chunk.
+	densities map[[3]int]float64
+}
+
+// GenerateChunk ...
+func (g *Nether) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	ch := &netherChunk{g: g, c: c, pos: pos, r: c.Range(), baseX: int(pos[0]) << 4, baseZ: int(pos[1]) << 4}
+	ch.densities = make(map[[3]int]float64)
+
+	ch.fill()
+	ch.placeBiomes()
+	ch.placeSurface()
+	ch.placeOres()
+	ch.placeGlowstone()
+	ch.placeBedrock()
+}
+
+// fill fills the chunk with netherrack and lava based on the density of the terrain.
+func (ch *netherChunk) fill() {
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for y := ch.r.Min(); y <= ch.r.Max(); y++ {
+				if ch.g.densityAt(ch.baseX+x, y, ch.baseZ+z, ch.densities) > 0 {
+					ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, netherrack)
+				} else if y <= netherLavaLevel {
+					ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, lava)
+				}
+			}
+		}
+	}
+}
+
+// placeBiomes sets the biomes of the chunk per 4x4x4 cell.
+func (ch *netherChunk) placeBiomes() {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			for y := ch.r.Min(); y <= ch.r.Max(); y += 4 {
+				id := uint32(ch.g.biomeAt(ch.baseX+i*4+2, y+2, ch.baseZ+j*4+2).EncodeBiome())
+				for dx := 0; dx < 4; dx++ {
+					for dz := 0; dz < 4; dz++ {
+						for dy := 0; dy < 4; dy++ {
+							ch.c.SetBiome(uint8(i*4+dx), int16(y+dy), uint8(j*4+dz), id)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// placeSurface replaces the netherrack at the floors (and, in basalt deltas, ceilings) of caverns with the surface
+// blocks of the biome found there. Crimson and warped forests keep a floor of netherrack.
+func (ch *netherChunk) placeSurface() {
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			wx, wz := ch.baseX+x, ch.baseZ+z
+			for y := ch.r.Max() - 1; y > ch.r.Min(); y-- {
+				if ch.c.Block(uint8(x), int16(y), uint8(z), 0) != netherrack {
+					continue
+				}
+				above, below := ch.c.Block(uint8(x), int16(y+1), uint8(z), 0), ch.c.Block(uint8(x), int16(y-1), uint8(z), 0)
+				floor, ceiling := above == air, below == air || below == lava
+				if !floor && !ceiling {
+					continue
+				}
+				depth := 2 + int(ch.g.surface.sample(float64(wx), float64(y), float64(wz))*2+0.5)
+				patch := ch.g.patches.sample(float64(wx), float64(y), float64(wz))
+
+				switch ch.g.biomeAt(wx, y, wz).(type) {
+				case biome.SoulSandValley:
+					if floor {
+						top := soulSand
+						if patch > 0 {
+							top = soulSoil
+						}
+						ch.replaceDown(x, y, z, depth, top, soulSoil)
+					}
+				case biome.BasaltDeltas:
+					top := basalt
+					if patch > 0.3 {
+						top = blackstone
+					}
+					if floor {
+						ch.replaceDown(x, y, z, depth, top, basalt)
+					} else {
+						ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, top)
+					}
+				case biome.NetherWastes:
+					if floor && y >= netherLavaLevel-1 && y <= netherLavaLevel+4 {
+						// Beaches of soul sand and gravel form around the lava oceans.
+						if patch > 0.5 {
+							ch.replaceDown(x, y, z, depth, soulSand, soulSand)
+						} else if patch < -0.5 {
+							ch.replaceDown(x, y, z, depth, gravel, gravel)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// replaceDown replaces the block at x, y and z with top and the netherrack up to depth blocks below it with filler.
+func (ch *netherChunk) replaceDown(x, y, z, depth int, top, filler uint32) {
+	ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, top)
+	for dy := 1; dy < depth && y-dy > ch.r.Min(); dy++ {
+		if ch.c.Block(uint8(x), int16(y-dy), uint8(z), 0) != netherrack {
+			return
+		}
+		ch.c.SetBlock(uint8(x), int16(y-dy), uint8(z), 0, filler)
+	}
+}
+
+// placeOres places the ore veins of the chunk and its neighbours, in so far as they reach into the chunk.
+func (ch *netherChunk) placeOres() {
+	for x := ch.pos[0] - 1; x <= ch.pos[0]+1; x++ {
+		for z := ch.pos[1] - 1; z <= ch.pos[1]+1; z++ {
+			for i, o := range netherOres {
+				r := chunkRand(ch.g.seed, x, z, uint64(i)+saltNetherOre)
+				for n := 0; n < o.count; n++ {
+					vx, vy, vz := int(x)*16+r.Intn(16), o.y(r), int(z)*16+r.Intn(16)
+					seed := r.Int63()
+
+					reach := o.size/8 + o.size/16 + 2
+					if vx+reach < ch.baseX || vx-reach >= ch.baseX+16 || vz+reach < ch.baseZ || vz-reach >= ch.baseZ+16 {
+						continue
+					}
+					vein(rand.New(&splitMix{s: uint64(seed)}), o.size, vx, vy, vz, func(bx, by, bz int) {
+						if ch.contains(bx, by, bz) && ch.block(bx, by, bz) == netherrack {
+							ch.setBlock(bx, by, bz, o.stone)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// placeGlowstone places the clusters of glowstone that hang from the ceilings of caverns. A cluster grows from a
+// block below the ceiling by attaching glowstone to exactly one other glowstone block of the cluster. Clusters are
+// grown using the predicted terrain, so that clusters that start in neighbouring chunks continue into this chunk.
+func (ch *netherChunk) placeGlowstone() {
+	for x := ch.pos[0] - 1; x <= ch.pos[0]+1; x++ {
+		for z := ch.pos[1] - 1; z <= ch.pos[1]+1; z++ {
+			r := chunkRand(ch.g.seed, x, z, saltGlowstone)
+			n := r.Intn(r.Intn(10)+1) + 10
+			for i := 0; i < n; i++ {
+				start := cube.Pos{int(x)*16 + r.Intn(16), 4 + r.Intn(120), int(z)*16 + r.Intn(16)}
+				seed := r.Int63()
+				if start[0]+8 < ch.baseX || start[0]-8 >= ch.baseX+16 || start[2]+8 < ch.baseZ || start[2]-8 >= ch.baseZ+16 {
+					continue
+				}
+				if !ch.open(start) {
+					continue
+				}
+				// Move the start of the cluster up to the ceiling of the cavern.
+				for ch.open(start.Side(cube.FaceUp)) {
+					start = start.Side(cube.FaceUp)
+				}
+				if start[1] >= ch.r.Max()-1 {
+					continue
+				}
+				ch.glowstoneCluster(rand.New(&splitMix{s: uint64(seed)}), start)
+			}
+		}
+	}
+}
+
+// glowstoneCluster grows a single cluster of glowstone from start and places the parts of it within the chunk.
+func (ch *netherChunk) glowstoneCluster(r *rand.Rand, start cube.Pos) {
+	cluster := map[cube.Pos]struct{}{start: {}}
+	for i := 0; i < 1500; i++ {
+		pos := start.Add(cube.Pos{r.Intn(8) - r.Intn(8), -r.Intn(12), r.Intn(8) - r.Intn(8)})
+		if _, ok := cluster[pos]; ok || !ch.open(pos) {
+			continue
+		}
+		neighbours := 0
+		pos.Neighbours(func(neighbour cube.Pos) {
+			if _, ok := cluster[neighbour]; ok {
+				neighbours++
+			}
+		}, ch.r)
+		if neighbours == 1 {
+			cluster[pos] = struct{}{}
+		}
+	}
+	for pos := range cluster {
+		if ch.contains(pos[0], pos[1], pos[2]) && ch.block(pos[0], pos[1], pos[2]) == air {
+			ch.setBlock(pos[0], pos[1], pos[2], glowstone)
+		}
+	}
+}
+
+// open checks if the terrain at a position is predicted to be air.
+func (ch *netherChunk) open(pos cube.Pos) bool {
+	return pos[1] > netherLavaLevel && pos[1] < ch.r.Max() && ch.g.densityAt(pos[0], pos[1], pos[2], ch.densities) <= 0
+}
+
+// placeBedrock places the bedrock floor and ceiling of the chunk, with a gradient of bedrock in the four layers
+// next to them.
+func (ch *netherChunk) placeBedrock() {
+	minY, maxY := ch.r.Min(), ch.r.Max()
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			ch.c.SetBlock(uint8(x), int16(minY), uint8(z), 0, bedrock)
+			ch.c.SetBlock(uint8(x), int16(maxY), uint8(z), 0, bedrock)
+			for d := 1; d < 5; d++ {
+				if int(posHash(ch.g.seed, ch.baseX+x, minY+d, ch.baseZ+z)%5) < 5-d {
+					ch.c.SetBlock(uint8(x), int16(minY+d), uint8(z), 0, bedrock)
+				}
+				if int(posHash(ch.g.seed, ch.baseX+x, maxY-d, ch.baseZ+z)%5) < 5-d {
+					ch.c.SetBlock(uint8(x), int16(maxY-d), uint8(z), 0, bedrock)
+				}
+			}
+		}
+	}
+}
+
+// block returns the runtime ID of the block at a world position within the chunk.
+func (ch *netherChunk) block(x, y, z int) uint32 {
+	return ch.c.Block(uint8(x-ch.baseX), int16(y), uint8(z-ch.baseZ), 0)
+}
+
+// setBlock sets the runtime ID of the block at a world position within the chunk.
+func (ch *netherChunk) setBlock(x, y, z int, rid uint32) {
+	ch.c.SetBlock(uint8(x-ch.baseX), int16(y), uint8(z-ch.baseZ), 0, rid)
+}
+
+// contains checks if the world position passed is within the chunk.
+func (ch *netherChunk) contains(x, y, z int) bool {
+	return x >= ch.baseX && x < ch.baseX+16 && z >= ch.baseZ && z < ch.baseZ+16 && y >= ch.r.Min() && y <= ch.r.Max()
+}
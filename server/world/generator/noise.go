@@ -0,0 +1,237 @@
+package generator
+
+import (
+	"github.com/segmentio/fasthash/fnv1"
+	"math"
+	"math/rand"
+)
+
+// perlin implements improved Perlin noise in three dimensions. A perlin value
+// is randomised on construction, so that two perlin values created from
+// different sources produce different noise. The values produced by noise are
+// roughly in the range [-1, 1].
+type perlin struct {
+	p          [512]uint8
+	xo, yo, zo float64
+}
+
+// newPerlin creates a new perlin noise generator using the rand.Rand passed to
+// shuffle its permutation table and pick random offsets.
+func newPerlin(r *rand.Rand) *perlin {
+	p := &perlin{xo: r.Float64() * 256, yo: r.Float64() * 256, zo: r.Float64() * 256}
+	for i := 0; i < 256; i++ {
+		p.p[i] = uint8(i)
+	}
+	for i := 0; i < 256; i++ {
+		j := r.Intn(256-i) + i
+		p.p[i], p.p[j] = p.p[j], p.p[i]
+	}
+	copy(p.p[256:], p.p[:256])
+	return p
+}
+
+// gradients holds the 16 gradient vectors used by improved Perlin noise. The
+// last four entries repeat earlier ones so that the gradient may be selected
+// using the lowest four bits of a hash.
+var gradients = [16][3]float64{
+	{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+	{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+	{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+	{1, 1, 0}, {0, -1, 1}, {-1, 1, 0}, {0, -1, -1},
+}
+
+// noise returns the noise value at x, y and z.
+func (p *perlin) noise(x, y, z float64) float64 {
+	x, y, z = x+p.xo, y+p.yo, z+p.zo
+	xf, yf, zf := math.Floor(x), math.Floor(y), math.Floor(z)
+	xi, yi, zi := int(xf)&255, int(yf)&255, int(zf)&255
+	x, y, z = x-xf, y-yf, z-zf
+
+	u, v, w := fade(x), fade(y), fade(z)
+
+	a := int(p.p[xi]) + yi
+	aa, ab := int(p.p[a])+zi, int(p.p[a+1])+zi
+	b := int(p.p[xi+1]) + yi
+	ba, bb := int(p.p[b])+zi, int(p.p[b+1])+zi
+
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad(p.p[aa], x, y, z), grad(p.p[ba], x-1, y, z)),
+			lerp(u, grad(p.p[ab], x, y-1, z), grad(p.p[bb], x-1, y-1, z)),
+		),
+		lerp(v,
+			lerp(u, grad(p.p[aa+1], x, y, z-1), grad(p.p[ba+1], x-1, y, z-1)),
+			lerp(u, grad(p.p[ab+1], x, y-1, z-1), grad(p.p[bb+1], x-1, y-1, z-1)),
+		),
+	)
+}
+
+// grad returns the dot product of the gradient selected by hash and x, y and
+// z.
+func grad(hash uint8, x, y, z float64) float64 {
+	g := gradients[hash&15]
+	return g[0]*x + g[1]*y + g[2]*z
+}
+
+// fade is the quintic fade curve used to smooth the interpolation of noise.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// lerp linearly interpolates between a and b using t.
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// clamp clamps v between min and max.
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	} else if v > max {
+		return max
+	}
+	return v
+}
+
+// octaves combines several layers of perlin noise with doubling frequencies
+// into one fractal noise. Every layer may have its own amplitude. A layer with
+// an amplitude of 0 is skipped entirely.
+type octaves struct {
+	layers     []*perlin
+	amplitudes []float64
+	// inputFactor is the frequency of the lowest octave and valueFactor the
+	// factor by which the lowest octave's value is multiplied.
+	inputFactor, valueFactor float64
+}
+
+// newOctaves creates octaves using the rand.Rand passed. firstOctave specifies
+// the frequency of the first layer as a power of two, so that -8 results in a
+// layer with a frequency of 1/256. Every amplitude passed results in one layer.
+func newOctaves(r *rand.Rand, firstOctave int, amplitudes ...float64) octaves {
+	n := len(amplitudes)
+	o := octaves{
+		layers:      make([]*perlin, n),
+		amplitudes:  amplitudes,
+		inputFactor: math.Pow(2, float64(firstOctave)),
+		valueFactor: math.Pow(2, float64(n-1)) / (math.Pow(2, float64(n)) - 1),
+	}
+	for i := range o.layers {
+		o.layers[i] = newPerlin(r)
+	}
+	return o
+}
+
+// sample returns the fractal noise value at x, y and z.
+func (o octaves) sample(x, y, z float64) float64 {
+	var v float64
+	in, val := o.inputFactor, o.valueFactor
+	for i, layer := range o.layers {
+		if amp := o.amplitudes[i]; amp != 0 {
+			v += amp * val * layer.noise(x*in, y*in, z*in)
+		}
+		in *= 2
+		val /= 2
+	}
+	return v
+}
+
+// normalNoise combines two octaves sampled at slightly different coordinates
+// to produce a noise that is more normally distributed than octaves alone. The
+// values it produces are roughly in the range [-1, 1], with most values
+// closer to 0.
+type normalNoise struct {
+	first, second octaves
+	valueFactor   float64
+}
+
+// newNormalNoise creates a new normalNoise. The firstOctave and amplitudes are
+// passed to newOctaves for both octaves used.
+func newNormalNoise(r *rand.Rand, firstOctave int, amplitudes ...float64) normalNoise {
+	first, last := len(amplitudes), 0
+	for i, amp := range amplitudes {
+		if amp != 0 {
+			first, last = min(first, i), max(last, i)
+		}
+	}
+	span := float64(last - first)
+	return normalNoise{
+		first:       newOctaves(r, firstOctave, amplitudes...),
+		second:      newOctaves(r, firstOctave, amplitudes...),
+		valueFactor: (1.0 / 6.0) / (0.1 * (1 + 1/(span+1))),
+	}
+}
+
+// sample returns the noise value at x, y and z.
+func (n normalNoise) sample(x, y, z float64) float64 {
+	const shift = 1.0181268882175227
+	return (n.first.sample(x, y, z) + n.second.sample(x*shift, y*shift, z*shift)) * n.valueFactor
+}
+
+// point is a single point of a spline.
+type point struct{ x, y float64 }
+
+// spline is a piecewise linear function defined by a set of points sorted by
+// their x value. Values outside the range of the spline take the value of the
+// nearest point.
+type spline []point
+
+// at returns the value of the spline at x.
+func (s spline) at(x float64) float64 {
+	if x <= s[0].x {
+		return s[0].y
+	}
+	for i := 1; i < len(s); i++ {
+		if x <= s[i].x {
+			a, b := s[i-1], s[i]
+			return lerp((x-a.x)/(b.x-a.x), a.y, b.y)
+		}
+	}
+	return s[len(s)-1].y
+}
+
+// splitMix is a small and fast rand.Source64 implementation. It is used in
+// favour of rand.NewSource where many short-lived sources are created, such as
+// for every chunk a feature is generated in.
+type splitMix struct{ s uint64 }
+
+// Uint64 ...
+func (s *splitMix) Uint64() uint64 {
+	s.s += 0x9e3779b97f4a7c15
+	z := s.s
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// Int63 ...
+func (s *splitMix) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed ...
+func (s *splitMix) Seed(seed int64) {
+	s.s = uint64(seed)
+}
+
+// namedRand returns a rand.Rand seeded with the seed passed combined with the
+// hash of name, so that different noises created from the same world seed
+// differ from each other.
+func namedRand(seed int64, name string) *rand.Rand {
+	return rand.New(&splitMix{s: uint64(seed) ^ fnv1.HashString64(name)})
+}
+
+// chunkRand returns a rand.Rand seeded for the chunk at x and z with a salt.
+// The same seed, chunk and salt always produce the same rand.Rand.
+func chunkRand(seed int64, x, z int32, salt uint64) *rand.Rand {
+	s := &splitMix{s: uint64(seed) ^ salt}
+	a, b := s.Uint64()|1, s.Uint64()|1
+	return rand.New(&splitMix{s: uint64(int64(x))*a ^ uint64(int64(z))*b ^ uint64(seed) ^ salt})
+}
+
+// posHash returns a pseudo-random hash for a block position, used for cheap
+// per-block decisions such as the transition between stone and deepslate.
+func posHash(seed int64, x, y, z int) uint64 {
+	h := uint64(seed) ^ uint64(int64(x))*0x2fc6a5bb ^ uint64(int64(y))*0x6ebfa9b1 ^ uint64(int64(z))*0x8cb92ba7
+	s := splitMix{s: h}
+	return s.Uint64()
+}
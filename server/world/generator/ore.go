@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"math"
+	"math/rand"
+)
+
+// ore describes the distribution of a single kind of ore vein or blob of stone in the terrain.
+type ore struct {
+	// stone and deepslate are the blocks placed where the vein replaces stone and deepslate respectively.
+	stone, deepslate uint32
+	// size is the amount of blocks in a vein and count the amount of veins attempted per chunk.
+	size, count int
+	// minY and maxY are the bounds of the heights at which veins are placed.
+	minY, maxY int
+	// triangle specifies if veins are most common halfway between minY and maxY, rather than evenly spread.
+	triangle bool
+	// mountains specifies if the vein may only be placed in mountainous biomes.
+	mountains bool
+}
+
+// y returns a random height for a vein of the ore.
+func (o ore) y(r *rand.Rand) int {
+	if o.triangle {
+		half := (o.maxY - o.minY) / 2
+		return o.minY + r.Intn(half+1) + r.Intn(half+1)
+	}
+	return o.minY + r.Intn(o.maxY-o.minY+1)
+}
+
+// newOre returns an ore with the ore variants of a block for stone and deepslate.
+func newOre(f func(t block.OreType) world.Block, size, count, minY, maxY int, triangle bool) ore {
+	return ore{
+		stone:     world.BlockRuntimeID(f(block.StoneOre())),
+		deepslate: world.BlockRuntimeID(f(block.DeepslateOre())),
+		size:      size, count: count, minY: minY, maxY: maxY, triangle: triangle,
+	}
+}
+
+// newBlob returns an ore that places the same block in both stone and deepslate.
+func newBlob(b world.Block, size, count, minY, maxY int) ore {
+	rid := world.BlockRuntimeID(b)
+	return ore{stone: rid, deepslate: rid, size: size, count: count, minY: minY, maxY: maxY}
+}
+
+// overworldOres holds all ores placed by the Overworld generator, in the order that they are placed.
+var overworldOres = func() []ore {
+	coal := func(t block.OreType) world.Block { return block.CoalOre{Type: t} }
+	iron := func(t block.OreType) world.Block { return block.IronOre{Type: t} }
+	copper := func(t block.OreType) world.Block { return block.CopperOre{Type: t} }
+	gold := func(t block.OreType) world.Block { return block.GoldOre{Type: t} }
+	lapis := func(t block.OreType) world.Block { return block.LapisOre{Type: t} }
+	diamond := func(t block.OreType) world.Block { return block.DiamondOre{Type: t} }
+	emerald := func(t block.OreType) world.Block { return block.EmeraldOre{Type: t} }
+
+	emeralds := newOre(emerald, 3, 100, -16, 480, true)
+	emeralds.mountains = true
+	return []ore{
+		newBlob(block.Dirt{}, 33, 7, 0, 160),
+		newBlob(block.Gravel{}, 33, 14, -64, 320),
+		newBlob(block.Granite{}, 64, 2, 0, 60),
+		newBlob(block.Diorite{}, 64, 2, 0, 60),
+		newBlob(block.Andesite{}, 64, 2, 0, 60),
+		newBlob(block.Tuff{}, 64, 2, -64, 0),
+		newOre(coal, 17, 30, 136, 320, false),
+		newOre(coal, 17, 20, 0, 192, true),
+		newOre(iron, 9, 90, 80, 384, true),
+		newOre(iron, 9, 10, -24, 56, true),
+		newOre(iron, 4, 10, -64, 72, false),
+		newOre(copper, 10, 16, -16, 112, true),
+		newOre(gold, 9, 4, -64, 32, true),
+		newOre(lapis, 7, 2, -32, 32, true),
+		newOre(lapis, 7, 4, -64, 64, false),
+		newOre(diamond, 4, 7, -144, 16, true),
+		emeralds,
+	}
+}()
+
+// mountainous checks if emeralds may be placed in a biome.
+func mountainous(b world.Biome) bool {
+	switch b.(type) {
+	case biome.JaggedPeaks, biome.FrozenPeaks, biome.StonyPeaks, biome.SnowySlopes, biome.Grove, biome.Meadow,
+		biome.CherryGrove, biome.WindsweptHills, biome.WindsweptGravellyHills, biome.WindsweptForest:
+		return true
+	}
+	return false
+}
+
+// placeOres places the ore veins of the chunk. Veins of ores may be up to 16 blocks away from the chunk that they are
+// placed for, so the veins of all neighbouring chunks are placed in so far as they reach into the chunk.
+func (ch *overworldChunk) placeOres() {
+	for x := ch.pos[0] - 1; x <= ch.pos[0]+1; x++ {
+		for z := ch.pos[1] - 1; z <= ch.pos[1]+1; z++ {
+			for i, o := range overworldOres {
+				r := chunkRand(ch.g.seed, x, z, uint64(i)+0x6f7265)
+				for n := 0; n < o.count; n++ {
+					vx, vy, vz := int(x)*16+r.Intn(16), o.y(r), int(z)*16+r.Intn(16)
+					seed := r.Int63()
+
+					reach := o.size/8 + o.size/16 + 2
+					if vx+reach < ch.baseX || vx-reach >= ch.baseX+16 || vz+reach < ch.baseZ || vz-reach >= ch.baseZ+16 {
+						continue
+					}
+					if o.mountains && !mountainous(ch.g.climateAt(vx, vz).surfaceBiome(ch.g.shapeAt(vx, vz).height)) {
+						continue
+					}
+					vein(rand.New(&splitMix{s: uint64(seed)}), o.size, vx, vy, vz, func(bx, by, bz int) {
+						if !ch.contains(bx, by, bz) {
+							return
+						}
+						switch ch.block(bx, by, bz) {
+						case stone:
+							ch.setBlock(bx, by, bz, o.stone)
+						case deepslate:
+							ch.setBlock(bx, by, bz, o.deepslate)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// vein calls f for every position in a single vein of size blocks around x, y and z. The vein is shaped as a series
+// of blobs along a line with a random direction.
+func vein(r *rand.Rand, size, x, y, z int, f func(x, y, z int)) {
+	angle := r.Float64() * math.Pi
+	spread := float64(size) / 8
+	x1, x2 := float64(x)+math.Sin(angle)*spread, float64(x)-math.Sin(angle)*spread
+	z1, z2 := float64(z)+math.Cos(angle)*spread, float64(z)-math.Cos(angle)*spread
+	y1, y2 := float64(y+r.Intn(3)-2), float64(y+r.Intn(3)-2)
+
+	for i := 0; i < size; i++ {
+		t := float64(i) / float64(size)
+		cx, cy, cz := lerp(t, x1, x2), lerp(t, y1, y2), lerp(t, z1, z2)
+		radius := ((math.Sin(math.Pi*t)+1)*r.Float64()*float64(size)/16 + 1) / 2
+
+		for bx := int(math.Floor(cx - radius)); bx <= int(math.Floor(cx+radius)); bx++ {
+			dx := (float64(bx) + 0.5 - cx) / radius
+			for by := int(math.Floor(cy - radius)); by <= int(math.Floor(cy+radius)); by++ {
+				dy := (float64(by) + 0.5 - cy) / radius
+				for bz := int(math.Floor(cz - radius)); bz <= int(math.Floor(cz+radius)); bz++ {
+					dz := (float64(bz) + 0.5 - cz) / radius
+					if dx*dx+dy*dy+dz*dz < 1 {
+						f(bx, by, bz)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math"
+)
+
+const (
+	// seaLevel is the Y level up to which oceans and rivers are filled with
+	// water.
+	seaLevel = 63
+	// lavaLevel is the Y level at and below which open spaces underground are
+	// filled with lava.
+	lavaLevel = -55
+	// cellWidth and cellHeight are the horizontal and vertical size of the
+	// cells that density is sampled for. Density is interpolated between the
+	// corners of these cells.
+	cellWidth, cellHeight = 4, 8
+)
+
+var (
+	air       = world.BlockRuntimeID(block.Air{})
+	bedrock   = world.BlockRuntimeID(block.Bedrock{})
+	stone     = world.BlockRuntimeID(block.Stone{})
+	deepslate = world.BlockRuntimeID(block.Deepslate{Type: block.NormalDeepslate(), Axis: cube.Y})
+	water     = world.BlockRuntimeID(block.Water{Still: true, Depth: 8})
+	lava      = world.BlockRuntimeID(block.Lava{Still: true, Depth: 8})
+)
+
+// Overworld is a generator that produces terrain similar to that of the vanilla overworld. The shape of the terrain
+// is decided by a set of noises: continentalness, which decides how far inland a column is, erosion, which decides
+// how flat or mountainous the terrain is, and peaks and valleys (derived from weirdness), which decides where in the
+// terrain mountains and rivers are found. Biomes are placed based on these noises and two additional noises for
+// temperature and humidity. Open spaces underground are filled by aquifers and the terrain is carved by caves and
+// canyons, after which ores are placed. Once all neighbours of a chunk are generated, the chunk is populated by the
+// Decorators of its biome, which place trees, plants and lakes.
+// Overworld may be constructed by calling NewOverworld. The same seed always produces the same terrain.
+type Overworld struct {
+	seed int64
+
+	continentalness, erosion, weirdness normalNoise
+	temperature, humidity               normalNoise
+
+	detail   octaves
+	jagged   normalNoise
+	cheese   normalNoise
+	surface  normalNoise
+	flooding normalNoise
+
+	bands      [64]uint32
+	decorators map[int][]Decorator
+}
+
+// NewOverworld creates a new Overworld generator using the seed passed.
+func NewOverworld(seed int64) *Overworld {
+	g := &Overworld{
+		seed:            seed,
+		continentalness: newNormalNoise(namedRand(seed, "continentalness"), -9, 1, 1, 2, 2, 2, 1, 1, 1, 1),
+		erosion:         newNormalNoise(namedRand(seed, "erosion"), -9, 1, 1, 0, 1, 1),
+		weirdness:       newNormalNoise(namedRand(seed, "weirdness"), -7, 1, 2, 1, 0, 0, 0),
+		temperature:     newNormalNoise(namedRand(seed, "temperature"), -10, 1.5, 0, 1, 0, 0, 0),
+		humidity:        newNormalNoise(namedRand(seed, "humidity"), -8, 1, 1, 0, 0, 0, 0),
+		detail:          newOctaves(namedRand(seed, "detail"), -6, 1, 1, 1, 1),
+		jagged:          newNormalNoise(namedRand(seed, "jagged"), -5, 1, 1),
+		cheese:          newNormalNoise(namedRand(seed, "cheese"), -7, 1, 1, 0.5),
+		surface:         newNormalNoise(namedRand(seed, "surface"), -6, 1, 1, 1),
+		flooding:        newNormalNoise(namedRand(seed, "aquifer_flooding"), -7, 1),
+	}
+	g.bands, g.decorators = terracottaBands(seed), make(map[int][]Decorator)
+	return g
+}
+
+// climate holds the values of the climate noises at a single position.
+type climate struct {
+	temperature, humidity, continentalness, erosion, weirdness float64
+}
+
+// peaksAndValleys folds the weirdness of the climate into a value in the range [-1, 1], where -1 is the bottom of a
+// valley and 1 the top of a peak.
+func (c climate) peaksAndValleys() float64 {
+	return 1 - math.Abs(3*math.Abs(c.weirdness)-2)
+}
+
+// climateAt returns the climate at the x and z passed.
+func (g *Overworld) climateAt(x, z int) climate {
+	fx, fz := float64(x), float64(z)
+	return climate{
+		temperature:     g.temperature.sample(fx, 0, fz),
+		humidity:        g.humidity.sample(fx, 0, fz),
+		continentalness: g.continentalness.sample(fx, 0, fz),
+		erosion:         g.erosion.sample(fx, 0, fz),
+		weirdness:       g.weirdness.sample(fx, 0, fz),
+	}
+}
+
+var (
+	// continentalHeight maps the continentalness of a column to the base height of the terrain in that column.
+	continentalHeight = spline{{-1.1, 22}, {-0.455, 38}, {-0.3, 46}, {-0.19, 56}, {-0.16, 61}, {-0.11, 64}, {0.03, 67}, {0.3, 74}, {1, 90}}
+	// erosionAmplitude maps the erosion of a column to the height by which peaks and valleys raise or lower the
+	// terrain. Low erosion leads to mountains, while high erosion leads to flat terrain.
+	erosionAmplitude = spline{{-1, 130}, {-0.78, 110}, {-0.375, 60}, {-0.2225, 25}, {0.05, 12}, {0.45, 6}, {0.55, 3}, {1, 3}}
+)
+
+// shape holds the parameters that shape the terrain of a column.
+type shape struct {
+	// height is the approximate height of the surface in the column.
+	height float64
+	// factor is the distance in blocks over which density changes by one. Higher factors allow the detail noise to
+	// produce overhangs and cliffs.
+	factor float64
+}
+
+// shapeAt returns the shape of the terrain at the x and z passed.
+func (g *Overworld) shapeAt(x, z int) shape {
+	c := g.climateAt(x, z)
+	inland := clamp((c.continentalness+0.11)/0.4, 0, 1)
+	amplitude := inland * erosionAmplitude.at(c.erosion)
+	pv := c.peaksAndValleys()
+
+	height := continentalHeight.at(c.continentalness)
+	if pv > 0 {
+		height += amplitude * pv
+		if pv > 0.5 && amplitude > 60 {
+			// The highest peaks are made jagged by an additional noise.
+			height += (pv - 0.5) * 2 * (amplitude - 60) / 70 * g.jagged.sample(float64(x), 0, float64(z)) * 40
+		}
+	} else {
+		height += amplitude * pv * 0.25
+	}
+	if pv < -0.75 {
+		// Rivers are carved into the lowest valleys, mostly outside of mountainous areas.
+		t := clamp((-0.75-pv)/0.2, 0, 1) * inland * (1 - clamp((amplitude-20)/80, 0, 1))
+		height = lerp(t, height, seaLevel-5)
+	}
+	return shape{height: height, factor: 2 + amplitude/6}
+}
+
+// density returns the density of the terrain at a position with a specific shape. Positions with a density higher
+// than 0 are solid.
+func (g *Overworld) density(x, y, z int, s shape) float64 {
+	fx, fy, fz := float64(x), float64(y), float64(z)
+	d := (s.height-fy)/s.factor + g.detail.sample(fx, fy, fz)
+	if depth := s.height - fy; depth > 10 && d > 0 {
+		// Large open caves are formed where the cheese noise is high enough. These caves fade out near the surface,
+		// so that they do not form large holes in the terrain.
+		const threshold = 0.45
+		if cave := g.cheese.sample(fx, fy*1.5, fz); cave > threshold {
+			d = min(d, (threshold-cave)*8+clamp((20-depth)/10, 0, 1)*4)
+		}
+	}
+	return d
+}
+
+// overworldChunk holds the state of a chunk while it is being generated by an Overworld generator.
+type overworldChunk struct {
+	g   *Overworld
+	c   *chunk.Chunk
+	pos world.ChunkPos
+	r   cube.Range
+
+	baseX, baseZ int
+	// surface holds the height of the surface in every column as predicted by the shape of the terrain.
+	surface [16][16]float64
+	// heights holds the Y of the highest solid block in every column, after the terrain is filled.
+	heights [16][16]int
+	// biomes holds the surface biomes of the 4x4 columns in the chunk.
+	biomes [4][4]world.Biome
+
+	aq aquifer
+}
+
+// GenerateChunk ...
+func (g *Overworld) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	ch := &overworldChunk{g: g, c: c, pos: pos, r: c.Range(), baseX: int(pos[0]) << 4, baseZ: int(pos[1]) << 4}
+	ch.aq = aquifer{g: g, levels: make(map[[3]int]int)}
+
+	ch.fill()
+	ch.placeBiomes()
+	ch.placeSurface()
+	ch.carve()
+	ch.placeOres()
+	ch.placeBedrock()
+}
+
+// fill fills the chunk with stone and fluids based on the density of the terrain.
+func (ch *overworldChunk) fill() {
+	const n = 16/cellWidth + 1
+
+	var shapes [n][n]shape
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			shapes[i][j] = ch.g.shapeAt(ch.baseX+i*cellWidth, ch.baseZ+j*cellWidth)
+		}
+	}
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			i, j := x/cellWidth, z/cellWidth
+			tx, tz := float64(x%cellWidth)/cellWidth, float64(z%cellWidth)/cellWidth
+			ch.surface[x][z] = lerp(tz,
+				lerp(tx, shapes[i][j].height, shapes[i+1][j].height),
+				lerp(tx, shapes[i][j+1].height, shapes[i+1][j+1].height),
+			)
+			ch.heights[x][z] = ch.r.Min() - 1
+		}
+	}
+
+	minY, cellsY := ch.r.Min(), (ch.r.Height()+1)/cellHeight
+	densities := make([][n][n]float64, cellsY+1)
+	for k := range densities {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				densities[k][i][j] = ch.g.density(ch.baseX+i*cellWidth, minY+k*cellHeight, ch.baseZ+j*cellWidth, shapes[i][j])
+			}
+		}
+	}
+
+	for k := cellsY - 1; k >= 0; k-- {
+		lower, upper := densities[k], densities[k+1]
+		for dy := cellHeight - 1; dy >= 0; dy-- {
+			y := minY + k*cellHeight + dy
+			ty := float64(dy) / cellHeight
+			for x := 0; x < 16; x++ {
+				i, tx := x/cellWidth, float64(x%cellWidth)/cellWidth
+				for z := 0; z < 16; z++ {
+					j, tz := z/cellWidth, float64(z%cellWidth)/cellWidth
+					d := lerp(ty,
+						lerp(tz, lerp(tx, lower[i][j], lower[i+1][j]), lerp(tx, lower[i][j+1], lower[i+1][j+1])),
+						lerp(tz, lerp(tx, upper[i][j], upper[i+1][j]), lerp(tx, upper[i][j+1], upper[i+1][j+1])),
+					)
+					if d > 0 {
+						ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, ch.g.stoneAt(ch.baseX+x, y, ch.baseZ+z))
+						if ch.heights[x][z] < minY {
+							ch.heights[x][z] = y
+						}
+						continue
+					}
+					if rid := ch.aq.fluid(ch.baseX+x, y, ch.baseZ+z, ch.surface[x][z]); rid != air {
+						ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, rid)
+					}
+				}
+			}
+		}
+	}
+}
+
+// stoneAt returns the stone block that forms the base of the terrain at a position. Deepslate is found below Y=0,
+// with a gradual transition between Y=0 and Y=-8.
+func (g *Overworld) stoneAt(x, y, z int) uint32 {
+	if y >= 0 {
+		return stone
+	} else if y < -8 || int(posHash(g.seed, x, y, z)%8) < -y {
+		return deepslate
+	}
+	return stone
+}
+
+// placeBedrock places a layer of bedrock at the bottom of the chunk, with a gradient of bedrock in the four layers
+// above it.
+func (ch *overworldChunk) placeBedrock() {
+	minY := ch.r.Min()
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			ch.c.SetBlock(uint8(x), int16(minY), uint8(z), 0, bedrock)
+			for dy := 1; dy < 5; dy++ {
+				if int(posHash(ch.g.seed, ch.baseX+x, minY+dy, ch.baseZ+z)%5) < 5-dy {
+					ch.c.SetBlock(uint8(x), int16(minY+dy), uint8(z), 0, bedrock)
+				}
+			}
+		}
+	}
+}
+
+// block returns the runtime ID of the block at a world position within the chunk.
+func (ch *overworldChunk) block(x, y, z int) uint32 {
+	return ch.c.Block(uint8(x-ch.baseX), int16(y), uint8(z-ch.baseZ), 0)
+}
+
+// setBlock sets the runtime ID of the block at a world position within the chunk.
+func (ch *overworldChunk) setBlock(x, y, z int, rid uint32) {
+	ch.c.SetBlock(uint8(x-ch.baseX), int16(y), uint8(z-ch.baseZ), 0, rid)
+}
+
+// contains checks if the world position passed is within the chunk.
+func (ch *overworldChunk) contains(x, y, z int) bool {
+	return x >= ch.baseX && x < ch.baseX+16 && z >= ch.baseZ && z < ch.baseZ+16 && y >= ch.r.Min() && y <= ch.r.Max()
+}
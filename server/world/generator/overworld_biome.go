@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+)
+
+var (
+	// temperatureLevels and humidityLevels are the thresholds that divide the temperature and humidity noise into
+	// five levels each.
+	temperatureLevels = [4]float64{-0.45, -0.15, 0.2, 0.55}
+	humidityLevels    = [4]float64{-0.35, -0.1, 0.1, 0.3}
+
+	oceans     = [5]world.Biome{biome.FrozenOcean{}, biome.ColdOcean{}, biome.Ocean{}, biome.LukewarmOcean{}, biome.WarmOcean{}}
+	deepOceans = [5]world.Biome{biome.DeepFrozenOcean{}, biome.DeepColdOcean{}, biome.DeepOcean{}, biome.DeepLukewarmOcean{}, biome.DeepWarmOcean{}}
+
+	// middleBiomes holds the biomes found in most inland areas, indexed by temperature and humidity level.
+	middleBiomes = [5][5]world.Biome{
+		{biome.SnowyPlains{}, biome.SnowyPlains{}, biome.SnowyPlains{}, biome.SnowyTaiga{}, biome.Taiga{}},
+		{biome.Plains{}, biome.Plains{}, biome.Forest{}, biome.Taiga{}, biome.OldGrowthSpruceTaiga{}},
+		{biome.FlowerForest{}, biome.Plains{}, biome.Forest{}, biome.BirchForest{}, biome.DarkForest{}},
+		{biome.Savanna{}, biome.Savanna{}, biome.Forest{}, biome.Jungle{}, biome.Jungle{}},
+		{biome.Desert{}, biome.Desert{}, biome.Desert{}, biome.Desert{}, biome.Desert{}},
+	}
+	// middleBiomeVariants holds variants of the middleBiomes that are used instead where the weirdness is positive.
+	// A nil entry means the middle biome is used as is.
+	middleBiomeVariants = [5][5]world.Biome{
+		{biome.IceSpikes{}, nil, biome.SnowyTaiga{}, nil, nil},
+		{nil, nil, nil, nil, biome.OldGrowthPineTaiga{}},
+		{biome.SunflowerPlains{}, nil, nil, biome.OldGrowthBirchForest{}, nil},
+		{nil, nil, biome.Plains{}, biome.JungleEdge{}, biome.BambooJungle{}},
+		{nil, nil, nil, nil, nil},
+	}
+)
+
+// level returns the level of v, where levels are the thresholds between the levels.
+func level(v float64, levels [4]float64) int {
+	for i, l := range levels {
+		if v < l {
+			return i
+		}
+	}
+	return len(levels)
+}
+
+// surfaceBiome returns the biome found at the surface of a column with a specific climate and surface height.
+func (c climate) surfaceBiome(height float64) world.Biome {
+	t, h := level(c.temperature, temperatureLevels), level(c.humidity, humidityLevels)
+	pv := c.peaksAndValleys()
+
+	switch {
+	case c.continentalness < -0.455:
+		return deepOceans[t]
+	case c.continentalness < -0.19:
+		return oceans[t]
+	case pv < -0.75 && height < seaLevel+1:
+		if t == 0 {
+			return biome.FrozenRiver{}
+		}
+		return biome.River{}
+	case c.continentalness < -0.11:
+		switch {
+		case height > seaLevel+8 || c.erosion < -0.375:
+			return biome.StonyShore{}
+		case t == 0:
+			return biome.SnowyBeach{}
+		case t == 4:
+			return biome.Desert{}
+		}
+		return biome.Beach{}
+	case height > 140:
+		return peakBiome(t, c.weirdness)
+	case height > 110:
+		return slopeBiome(t, h, c)
+	case c.erosion > 0.45 && c.erosion < 0.55 && pv > 0:
+		return windsweptBiome(t, h, c)
+	case c.erosion > 0.55 && c.continentalness < 0.3 && height < seaLevel+8 && t > 0:
+		if t >= 3 {
+			return biome.MangroveSwamp{}
+		}
+		return biome.Swamp{}
+	case height > 90:
+		return plateauBiome(t, h, c)
+	}
+	return middleBiome(t, h, c.weirdness)
+}
+
+// middleBiome returns the middle biome for a temperature and humidity level.
+func middleBiome(t, h int, weirdness float64) world.Biome {
+	if weirdness > 0 {
+		if b := middleBiomeVariants[t][h]; b != nil {
+			return b
+		}
+	}
+	return middleBiomes[t][h]
+}
+
+// peakBiome returns the biome found at the peaks of mountains.
+func peakBiome(t int, weirdness float64) world.Biome {
+	switch {
+	case t <= 2 && weirdness < 0:
+		return biome.JaggedPeaks{}
+	case t <= 2:
+		return biome.FrozenPeaks{}
+	case t == 3:
+		return biome.StonyPeaks{}
+	}
+	return biome.ErodedBadlands{}
+}
+
+// slopeBiome returns the biome found on the slopes of mountains.
+func slopeBiome(t, h int, c climate) world.Biome {
+	switch {
+	case t <= 2 && h <= 1:
+		return biome.SnowySlopes{}
+	case t <= 2:
+		return biome.Grove{}
+	}
+	return plateauBiome(t, h, c)
+}
+
+// windsweptBiome returns the biome found in windswept, hilly areas.
+func windsweptBiome(t, h int, c climate) world.Biome {
+	switch {
+	case t <= 1 && h <= 1:
+		return biome.WindsweptGravellyHills{}
+	case t <= 1 && h >= 3:
+		return biome.WindsweptForest{}
+	case t <= 1:
+		return biome.WindsweptHills{}
+	case t == 3:
+		return biome.WindsweptSavanna{}
+	}
+	return middleBiome(t, h, c.weirdness)
+}
+
+// plateauBiome returns the biome found on high, relatively flat terrain.
+func plateauBiome(t, h int, c climate) world.Biome {
+	switch {
+	case t == 4 && h >= 2:
+		return biome.WoodedBadlandsPlateau{}
+	case t == 4 && c.peaksAndValleys() < 0:
+		return biome.ErodedBadlands{}
+	case t == 4:
+		return biome.Badlands{}
+	case t == 3:
+		return biome.SavannaPlateau{}
+	case t == 2 && h == 0 && c.weirdness > 0:
+		return biome.CherryGrove{}
+	case (t == 1 || t == 2) && h <= 1:
+		return biome.Meadow{}
+	}
+	return middleBiome(t, h, c.weirdness)
+}
+
+// caveBiome returns the biome found at a position deep below the surface, or nil if the surface biome should be
+// used.
+func (c climate) caveBiome(depth float64) world.Biome {
+	switch {
+	case depth < 16:
+		return nil
+	case c.erosion < -0.375 && depth > 64:
+		return biome.DeepDark{}
+	case c.continentalness > 0.8:
+		return biome.DripstoneCaves{}
+	case c.humidity > 0.7:
+		return biome.LushCaves{}
+	}
+	return nil
+}
+
+// placeBiomes sets the biomes of the chunk. Surface biomes are decided per 4x4 column, while cave biomes are decided
+// per 4x4x4 cell below the surface.
+func (ch *overworldChunk) placeBiomes() {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			x, z := ch.baseX+i*4+2, ch.baseZ+j*4+2
+			c := ch.g.climateAt(x, z)
+			height := ch.surface[i*4+2][j*4+2]
+			surface := c.surfaceBiome(height)
+			ch.biomes[i][j] = surface
+
+			for y := ch.r.Min(); y <= ch.r.Max(); y += 4 {
+				b := surface
+				if depth := height - float64(y); depth >= 16 {
+					// The humidity of caves varies with their depth.
+					cc := c
+					cc.humidity = ch.g.humidity.sample(float64(x), float64(y), float64(z))
+					if cave := cc.caveBiome(depth); cave != nil {
+						b = cave
+					}
+				}
+				id := uint32(b.EncodeBiome())
+				for dx := 0; dx < 4; dx++ {
+					for dz := 0; dz < 4; dz++ {
+						for dy := 0; dy < 4; dy++ {
+							ch.c.SetBiome(uint8(i*4+dx), int16(y+dy), uint8(j*4+dz), id)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// biomeAt returns the surface biome of the column at a world position within the chunk.
+func (ch *overworldChunk) biomeAt(x, z int) world.Biome {
+	return ch.biomes[(x-ch.baseX)/4][(z-ch.baseZ)/4]
+}
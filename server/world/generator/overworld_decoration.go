@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+)
+
+const saltDecorators = 0x64656361
+
+var (
+	oakTree    = TreeKind{Wood: block.OakWood(), MinHeight: 4, MaxHeight: 6}
+	birchTree  = TreeKind{Wood: block.BirchWood(), MinHeight: 5, MaxHeight: 7}
+	spruceTree = TreeKind{Wood: block.SpruceWood(), Cone: true, MinHeight: 6, MaxHeight: 9}
+	jungleTree = TreeKind{Wood: block.JungleWood(), MinHeight: 5, MaxHeight: 11}
+	acaciaTree = TreeKind{Wood: block.AcaciaWood(), MinHeight: 5, MaxHeight: 7}
+	darkOak    = TreeKind{Wood: block.DarkOakWood(), MinHeight: 5, MaxHeight: 7}
+	cherryTree = TreeKind{Wood: block.Cherry(), MinHeight: 5, MaxHeight: 7}
+	mangrove   = TreeKind{Wood: block.Mangrove(), MinHeight: 5, MaxHeight: 8}
+
+	grassPlants  = []world.Block{block.TallGrass{Type: block.NormalTallGrass()}}
+	fernPlants   = []world.Block{block.TallGrass{Type: block.FernTallGrass()}, block.TallGrass{Type: block.NormalTallGrass()}}
+	plainFlowers = []world.Block{block.Flower{Type: block.Dandelion()}, block.Flower{Type: block.Poppy()}, block.Flower{Type: block.AzureBluet()}, block.Flower{Type: block.OxeyeDaisy()}, block.Flower{Type: block.Cornflower()}}
+	allFlowers   = []world.Block{
+		block.Flower{Type: block.Dandelion()}, block.Flower{Type: block.Poppy()}, block.Flower{Type: block.Allium()},
+		block.Flower{Type: block.AzureBluet()}, block.Flower{Type: block.RedTulip()}, block.Flower{Type: block.OrangeTulip()},
+		block.Flower{Type: block.WhiteTulip()}, block.Flower{Type: block.PinkTulip()}, block.Flower{Type: block.OxeyeDaisy()},
+		block.Flower{Type: block.Cornflower()}, block.Flower{Type: block.LilyOfTheValley()},
+		block.DoubleFlower{Type: block.Lilac()}, block.DoubleFlower{Type: block.RoseBush()}, block.DoubleFlower{Type: block.Peony()},
+	}
+	waterLakes = LakeDecorator{Chance: 1.0 / 8, Fluid: block.Water{Still: true, Depth: 8}}
+	// badlandsGold is the additional gold ore found in badlands biomes.
+	badlandsGold = OreDecorator{Ore: block.GoldOre{Type: block.StoneOre()}, DeepslateOre: block.GoldOre{Type: block.DeepslateOre()}, Size: 9, Count: 50, MinY: 32, MaxY: 256}
+)
+
+// sandy checks if cacti and dead bushes may be placed on a block.
+func sandy(b world.Block) bool {
+	switch b.(type) {
+	case block.Sand, block.Terracotta, block.StainedTerracotta:
+		return true
+	}
+	return false
+}
+
+// defaultDecorators returns the Decorators that the Overworld generator runs by default for the biome passed.
+func defaultDecorators(b world.Biome) []Decorator {
+	switch b.(type) {
+	case biome.Forest:
+		return []Decorator{waterLakes, TreeDecorator{Count: 10, Kinds: []TreeKind{oakTree, oakTree, oakTree, birchTree}}, PlantDecorator{Count: 2, Plants: plainFlowers}, PlantDecorator{Count: 2, Plants: grassPlants}}
+	case biome.FlowerForest:
+		return []Decorator{TreeDecorator{Count: 6, Kinds: []TreeKind{oakTree, birchTree}}, PlantDecorator{Count: 40, Plants: allFlowers}}
+	case biome.BirchForest, biome.OldGrowthBirchForest:
+		return []Decorator{waterLakes, TreeDecorator{Count: 10, Kinds: []TreeKind{birchTree}}, PlantDecorator{Count: 2, Plants: plainFlowers}, PlantDecorator{Count: 2, Plants: grassPlants}}
+	case biome.DarkForest:
+		return []Decorator{TreeDecorator{Count: 16, Kinds: []TreeKind{darkOak, darkOak, darkOak, oakTree}}, PlantDecorator{Count: 2, Plants: grassPlants}}
+	case biome.Taiga, biome.SnowyTaiga, biome.OldGrowthPineTaiga, biome.OldGrowthSpruceTaiga, biome.Grove, biome.WindsweptForest:
+		return []Decorator{waterLakes, TreeDecorator{Count: 10, Kinds: []TreeKind{spruceTree}}, PlantDecorator{Count: 4, Plants: fernPlants}}
+	case biome.Plains, biome.SunflowerPlains, biome.Meadow:
+		return []Decorator{waterLakes, TreeDecorator{Count: 1, Chance: 0.1, Kinds: []TreeKind{oakTree}}, PlantDecorator{Count: 4, Plants: plainFlowers}, PlantDecorator{Count: 20, Plants: grassPlants}}
+	case biome.CherryGrove:
+		return []Decorator{TreeDecorator{Count: 4, Kinds: []TreeKind{cherryTree}}, PlantDecorator{Count: 10, Plants: grassPlants}}
+	case biome.SnowyPlains:
+		return []Decorator{TreeDecorator{Count: 1, Chance: 0.1, Kinds: []TreeKind{spruceTree}}}
+	case biome.WindsweptHills, biome.WindsweptGravellyHills:
+		return []Decorator{TreeDecorator{Count: 1, Chance: 0.3, Kinds: []TreeKind{spruceTree, oakTree}}, PlantDecorator{Count: 4, Plants: grassPlants}}
+	case biome.Jungle, biome.BambooJungle, biome.JungleEdge:
+		return []Decorator{TreeDecorator{Count: 20, Kinds: []TreeKind{jungleTree, jungleTree, oakTree}}, PlantDecorator{Count: 20, Plants: fernPlants}}
+	case biome.Savanna, biome.SavannaPlateau, biome.WindsweptSavanna:
+		return []Decorator{TreeDecorator{Count: 1, Kinds: []TreeKind{acaciaTree, oakTree}}, PlantDecorator{Count: 20, Plants: grassPlants}}
+	case biome.Swamp:
+		return []Decorator{TreeDecorator{Count: 2, Kinds: []TreeKind{oakTree}}, PlantDecorator{Count: 5, Plants: []world.Block{block.Flower{Type: block.BlueOrchid()}}}, PlantDecorator{Count: 5, Plants: grassPlants}}
+	case biome.MangroveSwamp:
+		return []Decorator{TreeDecorator{Count: 4, Kinds: []TreeKind{mangrove}}}
+	case biome.Desert:
+		return []Decorator{PlantDecorator{Count: 10, Plants: []world.Block{block.Cactus{}}, On: sandy}, PlantDecorator{Count: 2, Plants: []world.Block{block.DeadBush{}}, On: sandy}}
+	case biome.Badlands, biome.ErodedBadlands, biome.WoodedBadlandsPlateau:
+		return []Decorator{badlandsGold, PlantDecorator{Count: 5, Plants: []world.Block{block.Cactus{}}, On: sandy}, PlantDecorator{Count: 20, Plants: []world.Block{block.DeadBush{}}, On: sandy}}
+	}
+	return nil
+}
+
+// Decorate sets the Decorators run when populating chunks of the biome passed, replacing the default Decorators of
+// that biome. Decorate must be called before the Overworld is used to generate a World.
+func (g *Overworld) Decorate(b world.Biome, decorators ...Decorator) {
+	g.decorators[b.EncodeBiome()] = decorators
+}
+
+// Populate runs the Decorators of the biome at the centre of the chunk at the position passed.
+func (g *Overworld) Populate(pos world.ChunkPos, p *world.Population) {
+	x, z := int(pos[0])<<4+8, int(pos[1])<<4+8
+	b := p.Biome(cube.Pos{x, p.HighestBlock(x, z), z})
+	decorators, ok := g.decorators[b.EncodeBiome()]
+	if !ok {
+		decorators = defaultDecorators(b)
+	}
+	decorate(g.seed, pos, p, decorators)
+}
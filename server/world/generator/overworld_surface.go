@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+)
+
+var (
+	grass      = world.BlockRuntimeID(block.Grass{})
+	dirt       = world.BlockRuntimeID(block.Dirt{})
+	coarseDirt = world.BlockRuntimeID(block.Dirt{Coarse: true})
+	podzol     = world.BlockRuntimeID(block.Podzol{})
+	sand       = world.BlockRuntimeID(block.Sand{})
+	redSand    = world.BlockRuntimeID(block.Sand{Red: true})
+	sandstone  = world.BlockRuntimeID(block.Sandstone{})
+	gravel     = world.BlockRuntimeID(block.Gravel{})
+	clay       = world.BlockRuntimeID(block.Clay{})
+	mud        = world.BlockRuntimeID(block.Mud{})
+	snow       = world.BlockRuntimeID(block.Snow{})
+	terracotta = world.BlockRuntimeID(block.Terracotta{})
+)
+
+// surfaceRule describes the blocks that make up the surface of the terrain in a biome.
+type surfaceRule struct {
+	// top is the block placed at the very top of the surface if it is not covered by a fluid. underwater is placed
+	// there instead if it is.
+	top, underwater uint32
+	// filler is placed below the top block, up to the depth of the surface.
+	filler uint32
+	// under, if not 0, is placed for another few blocks below the filler, such as sandstone below sand.
+	under uint32
+}
+
+var (
+	defaultSurface  = surfaceRule{top: grass, underwater: dirt, filler: dirt}
+	sandySurface    = surfaceRule{top: sand, underwater: sand, filler: sand, under: sandstone}
+	gravellySurface = surfaceRule{top: gravel, underwater: gravel, filler: gravel}
+	stonySurface    = surfaceRule{top: stone, underwater: gravel, filler: stone}
+	snowySurface    = surfaceRule{top: snow, underwater: gravel, filler: snow}
+	podzolSurface   = surfaceRule{top: podzol, underwater: dirt, filler: dirt}
+	coarseSurface   = surfaceRule{top: coarseDirt, underwater: dirt, filler: dirt}
+	swampSurface    = surfaceRule{top: grass, underwater: clay, filler: dirt}
+	mudSurface      = surfaceRule{top: mud, underwater: mud, filler: mud}
+	riverSurface    = surfaceRule{top: grass, underwater: sand, filler: dirt}
+	oceanSurface    = surfaceRule{top: sand, underwater: sand, filler: sand}
+	deepSurface     = surfaceRule{top: gravel, underwater: gravel, filler: gravel}
+)
+
+// surfaceRuleFor returns the surfaceRule for a biome.
+func surfaceRuleFor(b world.Biome) surfaceRule {
+	switch b.(type) {
+	case biome.Desert, biome.Beach, biome.SnowyBeach:
+		return sandySurface
+	case biome.WindsweptGravellyHills:
+		return gravellySurface
+	case biome.StonyShore, biome.StonyPeaks:
+		return stonySurface
+	case biome.JaggedPeaks, biome.FrozenPeaks, biome.SnowySlopes:
+		return snowySurface
+	case biome.OldGrowthSpruceTaiga, biome.OldGrowthPineTaiga:
+		return podzolSurface
+	case biome.WindsweptSavanna:
+		return coarseSurface
+	case biome.Swamp:
+		return swampSurface
+	case biome.MangroveSwamp:
+		return mudSurface
+	case biome.River, biome.FrozenRiver:
+		return riverSurface
+	case biome.Ocean, biome.LukewarmOcean, biome.WarmOcean, biome.DeepWarmOcean, biome.DeepLukewarmOcean:
+		return oceanSurface
+	case biome.DeepOcean, biome.DeepColdOcean, biome.DeepFrozenOcean, biome.ColdOcean, biome.FrozenOcean:
+		return deepSurface
+	}
+	return defaultSurface
+}
+
+// badlands checks if a biome is one of the badlands biomes, which have a surface of red sand and terracotta.
+func badlands(b world.Biome) bool {
+	switch b.(type) {
+	case biome.Badlands, biome.ErodedBadlands, biome.WoodedBadlandsPlateau:
+		return true
+	}
+	return false
+}
+
+// placeSurface replaces the top layers of stone in every column of the chunk with the surface blocks of the biome of
+// that column.
+func (ch *overworldChunk) placeSurface() {
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			top := ch.heights[x][z]
+			if top < ch.r.Min() {
+				continue
+			}
+			wx, wz := ch.baseX+x, ch.baseZ+z
+			b := ch.biomeAt(wx, wz)
+			depth := 3 + int(ch.g.surface.sample(float64(wx), 0, float64(wz))*2.5+0.5)
+
+			underwater := top < ch.r.Max() && ch.c.Block(uint8(x), int16(top+1), uint8(z), 0) == water
+			if badlands(b) {
+				ch.placeBadlandsSurface(x, z, top, depth, underwater)
+				continue
+			}
+			rule := surfaceRuleFor(b)
+			for y := top; y > top-depth && y > ch.r.Min(); y-- {
+				if ch.c.Block(uint8(x), int16(y), uint8(z), 0) == air {
+					break
+				}
+				rid := rule.filler
+				if y == top {
+					rid = rule.top
+					if underwater {
+						rid = rule.underwater
+					}
+				}
+				if rid != stone {
+					ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, rid)
+				}
+			}
+			if rule.under == 0 {
+				continue
+			}
+			for y := top - depth; y > top-depth-3 && y > ch.r.Min(); y-- {
+				if ch.c.Block(uint8(x), int16(y), uint8(z), 0) != stone {
+					break
+				}
+				ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, rule.under)
+			}
+		}
+	}
+}
+
+// placeBadlandsSurface places the surface of a column in a badlands biome: A layer of red sand at lower heights
+// followed by bands of coloured terracotta.
+func (ch *overworldChunk) placeBadlandsSurface(x, z, top, depth int, underwater bool) {
+	for y := top; y > top-depth*5 && y > ch.r.Min(); y-- {
+		if ch.c.Block(uint8(x), int16(y), uint8(z), 0) != stone {
+			if y == top {
+				continue
+			}
+			break
+		}
+		rid := ch.g.bands[(y%len(ch.g.bands)+len(ch.g.bands))%len(ch.g.bands)]
+		if y == top && top < seaLevel+10 && !underwater {
+			rid = redSand
+		}
+		ch.c.SetBlock(uint8(x), int16(y), uint8(z), 0, rid)
+	}
+}
+
+// terracottaBands returns the colours of the bands of terracotta found in badlands biomes. The bands repeat every
+// 64 blocks and depend on the seed.
+func terracottaBands(seed int64) (bands [64]uint32) {
+	r := namedRand(seed, "terracotta_bands")
+	for i := range bands {
+		bands[i] = terracotta
+	}
+	colours := []item.Colour{item.ColourOrange(), item.ColourYellow(), item.ColourBrown(), item.ColourRed(), item.ColourWhite(), item.ColourLightGrey()}
+	for i := 0; i < 24; i++ {
+		c := colours[r.Intn(len(colours))]
+		start, size := r.Intn(len(bands)), 1+r.Intn(3)
+		for j := 0; j < size; j++ {
+			bands[(start+j)%len(bands)] = world.BlockRuntimeID(block.StainedTerracotta{Colour: c})
+		}
+	}
+	return bands
+}
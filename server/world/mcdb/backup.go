@@ -0,0 +1,236 @@
+package mcdb
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcdb/leveldat"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/opt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotBatchSize is the amount of bytes of keys and values written to a
+// leveldb.Batch when copying a snapshot before the batch is written.
+const snapshotBatchSize = 4 << 20
+
+// Snapshot is a point-in-time copy of the data of a DB, consisting of a
+// snapshot of its LevelDB database and a copy of its level.dat. A Snapshot may
+// be written to an archive in the .mcworld format using WriteArchive.
+type Snapshot struct {
+	conf      Config
+	snap      *leveldb.Snapshot
+	levelDat  []byte
+	levelName string
+}
+
+// Snapshot takes a Snapshot of the data currently stored in the DB. Taking a
+// Snapshot is cheap and does not block writes to the DB. Data stored after the
+// Snapshot was taken is not part of it. The Snapshot must be released using
+// Snapshot.Release once it is no longer used.
+func (db *DB) Snapshot() (world.Snapshot, error) {
+	return db.snapshot()
+}
+
+// snapshot takes a Snapshot of the data currently stored in the DB.
+func (db *DB) snapshot() (*Snapshot, error) {
+	db.ldatMu.Lock()
+	levelName := db.ldat.LevelName
+	var ldat leveldat.LevelDat
+	err := ldat.Marshal(*db.ldat)
+	db.ldatMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := ldat.Write(buf); err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	snap, err := db.ldb.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	return &Snapshot{conf: db.conf, snap: snap, levelDat: buf.Bytes(), levelName: levelName}, nil
+}
+
+// Backup takes a Snapshot of the DB and writes it to w as a zip archive in the
+// .mcworld format. The archive may be restored using Restore.
+func (db *DB) Backup(w io.Writer) error {
+	snap, err := db.snapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	return snap.WriteArchive(w)
+}
+
+// WriteArchive writes the Snapshot to w as a zip archive in the .mcworld
+// format, holding the level.dat, levelname.txt and the LevelDB database of the
+// world. The database is first copied to a temporary directory, as LevelDB
+// snapshots only exist in memory.
+func (s *Snapshot) WriteArchive(w io.Writer) error {
+	tmp, err := os.MkdirTemp("", "mcdb-snapshot-")
+	if err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := s.copyDB(tmp); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+	if err := writeZipFile(zw, "level.dat", bytes.NewReader(s.levelDat)); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	if err := writeZipFile(zw, "levelname.txt", strings.NewReader(s.levelName)); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	err = filepath.WalkDir(tmp, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(tmp, path)
+		if rel == "LOCK" {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return writeZipFile(zw, "db/"+filepath.ToSlash(rel), f)
+	})
+	if err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	return nil
+}
+
+// copyDB copies all keys and values of the Snapshot to a new LevelDB database
+// in the directory passed.
+func (s *Snapshot) copyDB(dir string) error {
+	ldb, err := leveldb.OpenFile(dir, &opt.Options{Compression: s.conf.Compression, BlockSize: s.conf.BlockSize})
+	if err != nil {
+		return fmt.Errorf("create database: %w", err)
+	}
+	iter := s.snap.NewIterator(nil, nil)
+	batch, size := new(leveldb.Batch), 0
+	for iter.Next() {
+		batch.Put(iter.Key(), iter.Value())
+		if size += len(iter.Key()) + len(iter.Value()); size >= snapshotBatchSize {
+			if err = ldb.Write(batch, nil); err != nil {
+				break
+			}
+			batch.Reset()
+			size = 0
+		}
+	}
+	iter.Release()
+	if err == nil {
+		err = iter.Error()
+	}
+	if err == nil && batch.Len() > 0 {
+		err = ldb.Write(batch, nil)
+	}
+	if err != nil {
+		_ = ldb.Close()
+		return fmt.Errorf("copy database: %w", err)
+	}
+	if err := ldb.Close(); err != nil {
+		return fmt.Errorf("copy database: %w", err)
+	}
+	return nil
+}
+
+// Release releases the Snapshot. The Snapshot can no longer be written after
+// calling Release.
+func (s *Snapshot) Release() {
+	s.snap.Release()
+}
+
+// writeZipFile writes a file with the name passed and the contents read from r
+// to the zip.Writer passed.
+func writeZipFile(zw *zip.Writer, name string, r io.Reader) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// RestoreFile restores the world archived in the zip or .mcworld file at the
+// path passed to the directory dir. See Restore for details.
+func RestoreFile(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	return Restore(f, stat.Size(), dir)
+}
+
+// Restore restores a world from a zip archive in the .mcworld format, such as
+// one written by DB.Backup, to the directory dir. The archive has a size in
+// bytes of size. Restore returns an error if dir already holds a world, so the
+// world must first be closed and its directory removed to restore it from a
+// backup. The restored world may be opened using Open afterwards.
+func Restore(r io.ReaderAt, size int64, dir string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "level.dat")); err == nil {
+		return fmt.Errorf("restore: a world already exists in %v", dir)
+	}
+	if _, err := zr.Open("level.dat"); err != nil {
+		return fmt.Errorf("restore: archive does not hold a level.dat")
+	}
+	for _, f := range zr.File {
+		if err := restoreFile(f, dir); err != nil {
+			return fmt.Errorf("restore: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreFile extracts a single file of a zip archive to the directory dir.
+func restoreFile(f *zip.File, dir string) error {
+	if !fs.ValidPath(f.Name) {
+		return fmt.Errorf("invalid file path %v in archive", f.Name)
+	}
+	path := filepath.Join(dir, filepath.FromSlash(f.Name))
+	if f.FileInfo().IsDir() {
+		return os.MkdirAll(path, 0777)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
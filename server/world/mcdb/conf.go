@@ -37,6 +37,10 @@ type Config struct {
 	// ReadOnly opens the DB in read-only mode. This will leave the data in the
 	// database unedited.
 	ReadOnly bool
+	// Seed is the seed of the world created if no world is present at the
+	// directory passed to Open. If left as 0, a random seed will be used. The
+	// seed of an existing world is never changed.
+	Seed int64
 
 	// Entities is an EntityRegistry with all entity types registered that may
 	// be read from the DB. Entities will default to entity.DefaultRegistry.
@@ -63,6 +67,9 @@ func (conf Config) Open(dir string) (*DB, error) {
 	if _, err := os.Stat(filepath.Join(dir, "level.dat")); os.IsNotExist(err) {
 		// A level.dat was not currently present for the world.
 		db.ldat.FillDefault()
+		if conf.Seed != 0 {
+			db.ldat.RandomSeed = conf.Seed
+		}
 	} else {
 		ldat, err := leveldat.ReadFile(filepath.Join(dir, "level.dat"))
 		if err != nil {
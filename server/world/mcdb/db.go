@@ -15,6 +15,7 @@ import (
 	"golang.org/x/exp/maps"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
@@ -24,8 +25,12 @@ type DB struct {
 	conf Config
 	ldb  *leveldb.DB
 	dir  string
-	ldat *leveldat.Data
 	set  *world.Settings
+
+	// ldatMu guards ldat, which is updated by SaveSettings while snapshots
+	// of the DB may be taken concurrently.
+	ldatMu sync.Mutex
+	ldat   *leveldat.Data
 }
 
 // Open creates a new provider reading and writing from/to files under the path
@@ -44,6 +49,8 @@ func (db *DB) Settings() *world.Settings {
 
 // SaveSettings saves the world.Settings passed to the level.dat.
 func (db *DB) SaveSettings(s *world.Settings) {
+	db.ldatMu.Lock()
+	defer db.ldatMu.Unlock()
 	db.ldat.PutSettings(s)
 }
 
@@ -179,9 +186,28 @@ func (db *DB) column(k dbKey) (*world.Column, error) {
 		// Same as with entities, an ErrNotFound is fine here.
 		return nil, fmt.Errorf("read block entities: %w", err)
 	}
+	col.Populated, err = db.populated(k)
+	if err != nil {
+		return nil, fmt.Errorf("read finalisation: %w", err)
+	}
 	return col, nil
 }
 
+// populated checks if the column at the key passed was populated. Columns
+// without a finalisation state are assumed to be populated.
+func (db *DB) populated(k dbKey) (bool, error) {
+	p, err := db.ldb.Get(k.Sum(keyFinalisation), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	if n := len(p); n != 4 {
+		return false, fmt.Errorf("expected 4 finalisation bytes, found %v", n)
+	}
+	return binary.LittleEndian.Uint32(p) != finalisationGenerated, nil
+}
+
 func (db *DB) version(k dbKey) (byte, error) {
 	p, err := db.ldb.Get(k.Sum(keyVersion), nil)
 	switch err {
@@ -323,7 +349,11 @@ func (db *DB) storeColumn(k dbKey, col *world.Column) error {
 	db.storeVersion(batch, k, chunkVersion)
 	db.storeBiomes(batch, k, data.Biomes)
 	db.storeSubChunks(batch, k, data.SubChunks, col.Chunk.Range())
-	db.storeFinalisation(batch, k, finalisationPopulated)
+	if col.Populated {
+		db.storeFinalisation(batch, k, finalisationPopulated)
+	} else {
+		db.storeFinalisation(batch, k, finalisationGenerated)
+	}
 	db.storeEntities(batch, k, col.Entities)
 	db.storeBlockEntities(batch, k, col.BlockEntities)
 
@@ -409,16 +439,20 @@ func (db *DB) NewColumnIterator(r *IteratorRange) *ColumnIterator {
 
 // Close closes the provider, saving any file that might need to be saved, such as the level.dat.
 func (db *DB) Close() error {
+	db.ldatMu.Lock()
 	db.ldat.LastPlayed = time.Now().Unix()
+	levelName := db.ldat.LevelName
 
 	var ldat leveldat.LevelDat
-	if err := ldat.Marshal(*db.ldat); err != nil {
+	err := ldat.Marshal(*db.ldat)
+	db.ldatMu.Unlock()
+	if err != nil {
 		return fmt.Errorf("close: %w", err)
 	}
 	if err := ldat.WriteFile(filepath.Join(db.dir, "level.dat")); err != nil {
 		return fmt.Errorf("close: %w", err)
 	}
-	if err := os.WriteFile(filepath.Join(db.dir, "levelname.txt"), []byte(db.ldat.LevelName), 0644); err != nil {
+	if err := os.WriteFile(filepath.Join(db.dir, "levelname.txt"), []byte(levelName), 0644); err != nil {
 		return fmt.Errorf("close: write levelname.txt: %w", err)
 	}
 	return db.ldb.Close()
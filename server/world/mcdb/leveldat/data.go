@@ -195,6 +195,7 @@ func (d *Data) FillDefault() {
 	d.NetherScale = 8
 	d.NetworkVersion = protocol.CurrentProtocol
 	d.PVP = true
+	d.PlayersSleepingPercentage = 100
 	d.Platform = 2
 	d.PlatformBroadcastIntent = 3
 	d.RainLevel = 1.0
@@ -223,6 +224,7 @@ func (d *Data) Settings() *world.Settings {
 	mode, _ := world.GameModeByID(int(d.GameType))
 	return &world.Settings{
 		Name:            d.LevelName,
+		Seed:            d.RandomSeed,
 		Spawn:           cube.Pos{int(d.SpawnX), int(d.SpawnY), int(d.SpawnZ)},
 		Time:            d.Time,
 		TimeCycle:       d.DoDayLightCycle,
@@ -235,12 +237,55 @@ func (d *Data) Settings() *world.Settings {
 		DefaultGameMode: mode,
 		Difficulty:      difficulty,
 		TickRange:       d.ServerChunkTickRange,
+		GameRules:       d.gameRules(),
 	}
 }
 
+// gameRuleFields returns pointers to the fields of d that hold the values of game rules, indexed by the names of the
+// game rules. The fields are either of the type *bool or *int32. The dodaylightcycle and doweathercycle game rules
+// are stored in the world.Settings separately and are therefore not included.
+func (d *Data) gameRuleFields() map[string]any {
+	return map[string]any{
+		"dofiretick":                &d.DoFireTick,
+		"keepinventory":             &d.KeepInventory,
+		"mobgriefing":               &d.MobGriefing,
+		"naturalregeneration":       &d.NaturalRegeneration,
+		"showcoordinates":           &d.ShowCoordinates,
+		"doimmediaterespawn":        &d.DoImmediateRespawn,
+		"falldamage":                &d.FallDamage,
+		"firedamage":                &d.FireDamage,
+		"drowningdamage":            &d.DrowningDamage,
+		"dotiledrops":               &d.DoTileDrops,
+		"doentitydrops":             &d.DoEntityDrops,
+		"domobloot":                 &d.DoMobLoot,
+		"domobspawning":             &d.DoMobSpawning,
+		"pvp":                       &d.PVP,
+		"showdeathmessages":         &d.ShowDeathMessages,
+		"tntexplodes":               &d.TNTExplodes,
+		"spawnradius":               &d.SpawnRadius,
+		"playerssleepingpercentage": &d.PlayersSleepingPercentage,
+	}
+}
+
+// gameRules returns the values of the game rules stored in d.
+func (d *Data) gameRules() map[string]any {
+	fields := d.gameRuleFields()
+	rules := make(map[string]any, len(fields))
+	for name, field := range fields {
+		switch field := field.(type) {
+		case *bool:
+			rules[name] = *field
+		case *int32:
+			rules[name] = *field
+		}
+	}
+	return rules
+}
+
 // PutSettings updates d with the Settings stored in s.
 func (d *Data) PutSettings(s *world.Settings) {
 	d.LevelName = s.Name
+	d.RandomSeed = s.Seed
 	d.SpawnX, d.SpawnY, d.SpawnZ = int32(s.Spawn.X()), int32(s.Spawn.Y()), int32(s.Spawn.Z())
 	d.LimitedWorldOriginX, d.LimitedWorldOriginY, d.LimitedWorldOriginZ = d.SpawnX, d.SpawnY, d.SpawnZ
 	d.Time = s.Time
@@ -260,4 +305,14 @@ func (d *Data) PutSettings(s *world.Settings) {
 	d.GameType = int32(mode)
 	difficulty, _ := world.DifficultyID(s.Difficulty)
 	d.Difficulty = int32(difficulty)
+
+	fields := d.gameRuleFields()
+	for name, v := range s.GameRules {
+		switch field := fields[name].(type) {
+		case *bool:
+			*field, _ = v.(bool)
+		case *int32:
+			*field, _ = v.(int32)
+		}
+	}
 }
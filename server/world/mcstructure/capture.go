@@ -0,0 +1,46 @@
+package mcstructure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+)
+
+// Capture captures the blocks, liquids and entities within the cube.BBox passed from a World into a new
+// Structure. The Structure covers all blocks of which the lower corner is within the box, so that a box
+// created using cube.Box(x0, y0, z0, x1+1, y1+1, z1+1) captures the blocks from x0, y0, z0 to x1, y1, z1.
+// Parts of the box outside the height range of the World are captured as air. Entities of which the type does
+// not implement world.SaveableEntityType are not captured.
+func Capture(w *world.World, box cube.BBox) *Structure {
+	lower, upper := box.Min(), box.Max()
+	origin := cube.Pos{int(math.Floor(lower[0])), int(math.Floor(lower[1])), int(math.Floor(lower[2]))}
+	end := cube.Pos{int(math.Ceil(upper[0])), int(math.Ceil(upper[1])), int(math.Ceil(upper[2]))}
+
+	s := New([3]int{end[0] - origin[0], end[1] - origin[1], end[2] - origin[2]})
+	s.origin = origin
+	for x := 0; x < s.size[0]; x++ {
+		for y := 0; y < s.size[1]; y++ {
+			for z := 0; z < s.size[2]; z++ {
+				pos := origin.Add(cube.Pos{x, y, z})
+				b := w.Block(pos)
+				var liq world.Liquid
+				if _, ok := b.(world.Liquid); !ok {
+					liq, _ = w.Liquid(pos)
+				}
+				s.Set(x, y, z, b, liq)
+			}
+		}
+	}
+	for _, e := range w.EntitiesWithin(box, nil) {
+		t, ok := e.Type().(world.SaveableEntityType)
+		if !ok {
+			continue
+		}
+		data := t.EncodeNBT(e)
+		data["identifier"] = t.EncodeEntity()
+		data["Pos"] = nbtconv.Vec3ToFloat32Slice(e.Position())
+		s.entities = append(s.entities, data)
+	}
+	return s
+}
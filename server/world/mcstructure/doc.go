@@ -0,0 +1,4 @@
+// Package mcstructure implements reading and writing of .mcstructure files, the format used by structure blocks in
+// Minecraft: Bedrock Edition. Structures read are world.Structures that may be built using World.BuildStructure, and
+// areas of a World may be captured into a Structure using Capture.
+package mcstructure
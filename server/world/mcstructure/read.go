@@ -0,0 +1,135 @@
+package mcstructure
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// formatVersion is the only version of the .mcstructure format that currently exists.
+const formatVersion = 1
+
+// ReadFile reads a Structure from the .mcstructure file at the path passed.
+func ReadFile(path string) (*Structure, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read structure: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read reads a Structure from the .mcstructure data in the io.Reader passed. Blocks in the palette of the
+// structure that are not known to the server are read as structure voids, and entities are kept as NBT until
+// Structure.Entities is called.
+func Read(r io.Reader) (*Structure, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read structure: %w", err)
+	}
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(bytes.NewBuffer(data), nbt.LittleEndian).Decode(&m); err != nil {
+		return nil, fmt.Errorf("read structure: decode nbt: %w", err)
+	}
+	if v, _ := m["format_version"].(int32); v != formatVersion {
+		return nil, fmt.Errorf("read structure: unsupported format version %v", m["format_version"])
+	}
+	size, origin := int32s(m["size"]), int32s(m["structure_world_origin"])
+	if len(size) != 3 || size[0] < 0 || size[1] < 0 || size[2] < 0 {
+		return nil, fmt.Errorf("read structure: invalid size %v", m["size"])
+	}
+	s := New([3]int{int(size[0]), int(size[1]), int(size[2])})
+	if len(origin) == 3 {
+		s.origin = cube.Pos{int(origin[0]), int(origin[1]), int(origin[2])}
+	}
+
+	structure, _ := m["structure"].(map[string]any)
+	layers, _ := structure["block_indices"].([]any)
+	if len(layers) == 0 {
+		return nil, errors.New("read structure: missing block indices")
+	}
+	palettes, _ := structure["palette"].(map[string]any)
+	palette, _ := palettes["default"].(map[string]any)
+	blocks := readPalette(palette)
+	positionData, _ := palette["block_position_data"].(map[string]any)
+
+	n := len(s.blocks)
+	for layer, v := range layers {
+		if layer > 1 {
+			break
+		}
+		indices := int32s(v)
+		if len(indices) != n {
+			return nil, fmt.Errorf("read structure: expected %v block indices in layer %v, got %v", n, layer, len(indices))
+		}
+		for i, index := range indices {
+			if index < 0 || int(index) >= len(blocks) || blocks[index] == nil {
+				continue
+			}
+			b := blocks[index]
+			if layer == 1 {
+				if liq, ok := b.(world.Liquid); ok && s.blocks[i] != nil {
+					s.liquids[i] = liq
+				}
+				continue
+			}
+			if nbter, ok := b.(world.NBTer); ok {
+				pos, _ := positionData[strconv.Itoa(i)].(map[string]any)
+				if blockEntity, ok := pos["block_entity_data"].(map[string]any); ok {
+					b = nbter.DecodeNBT(blockEntity).(world.Block)
+				}
+			}
+			s.blocks[i] = b
+		}
+	}
+	if entities, ok := structure["entities"].([]any); ok {
+		for _, e := range entities {
+			if data, ok := e.(map[string]any); ok {
+				s.entities = append(s.entities, data)
+			}
+		}
+	}
+	return s, nil
+}
+
+// readPalette reads the blocks from the block palette passed. Blocks that are unknown are nil in the slice
+// returned.
+func readPalette(palette map[string]any) []world.Block {
+	entries, _ := palette["block_palette"].([]any)
+	blocks := make([]world.Block, len(entries))
+	for i, v := range entries {
+		entry, _ := v.(map[string]any)
+		name, _ := entry["name"].(string)
+		properties, _ := entry["states"].(map[string]any)
+		if properties == nil {
+			properties = map[string]any{}
+		}
+		if b, ok := world.BlockByName(name, properties); ok {
+			blocks[i] = b
+		}
+	}
+	return blocks
+}
+
+// int32s converts an NBT list of integers to a []int32. Empty lists may be decoded with a different type, in
+// which case nil is returned.
+func int32s(v any) []int32 {
+	switch l := v.(type) {
+	case []int32:
+		return l
+	case []any:
+		s := make([]int32, 0, len(l))
+		for _, x := range l {
+			i, _ := x.(int32)
+			s = append(s, i)
+		}
+		return s
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package mcstructure
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"golang.org/x/exp/maps"
+)
+
+// Structure is a world.Structure read from or written to a .mcstructure file. It holds the blocks and liquids
+// of a cuboid area, including the NBT of any block entities, and the NBT of the entities in the area.
+// A Structure may be built in a World using World.BuildStructure.
+type Structure struct {
+	size   [3]int
+	origin cube.Pos
+
+	// blocks and liquids hold the blocks in the first and second layer of the Structure. A nil block is a
+	// structure void, which leaves the block already in the World untouched when building the Structure.
+	blocks  []world.Block
+	liquids []world.Liquid
+	// entities holds the NBT of the entities in the Structure. Like in .mcstructure files, the positions of the
+	// entities are those in the World at the time the Structure was captured, so they are offset by the origin.
+	entities []map[string]any
+}
+
+// New returns a new Structure with the dimensions passed. All blocks of the Structure are initially structure
+// voids.
+func New(dimensions [3]int) *Structure {
+	n := dimensions[0] * dimensions[1] * dimensions[2]
+	return &Structure{
+		size:    dimensions,
+		blocks:  make([]world.Block, n),
+		liquids: make([]world.Liquid, n),
+	}
+}
+
+// Dimensions returns the width, height and length of the Structure.
+func (s *Structure) Dimensions() [3]int {
+	return s.size
+}
+
+// Origin returns the position in the World that the Structure was captured from. The Origin is used to find
+// the relative position of the entities of the Structure.
+func (s *Structure) Origin() cube.Pos {
+	return s.origin
+}
+
+// At returns the block and liquid at a position in the Structure. If the block at the position is a
+// structure void, nil is returned for both, so that the block present in the World remains.
+func (s *Structure) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	i := s.index(x, y, z)
+	return s.blocks[i], s.liquids[i]
+}
+
+// Set sets the block and liquid at a position in the Structure. Passing a nil block turns the position into
+// a structure void. A liquid may be passed to waterlog the block at the position.
+func (s *Structure) Set(x, y, z int, b world.Block, liq world.Liquid) {
+	i := s.index(x, y, z)
+	s.blocks[i], s.liquids[i] = b, liq
+	if b == nil {
+		s.liquids[i] = nil
+	}
+}
+
+// Entities decodes the entities in the Structure using the world.EntityRegistry passed. The entities are
+// positioned as if the Structure was built at the position passed. Entities that are not registered in the
+// world.EntityRegistry or that cannot be saved are left out. The entities returned may be added to a World
+// using World.AddEntity.
+func (s *Structure) Entities(reg world.EntityRegistry, pos cube.Pos) []world.Entity {
+	offset := pos.Vec3().Sub(s.origin.Vec3())
+	entities := make([]world.Entity, 0, len(s.entities))
+	for _, data := range s.entities {
+		name, _ := data["identifier"].(string)
+		t, ok := reg.Lookup(name)
+		if !ok {
+			continue
+		}
+		st, ok := t.(world.SaveableEntityType)
+		if !ok {
+			continue
+		}
+		m := maps.Clone(data)
+		m["Pos"] = nbtconv.Vec3ToFloat32Slice(nbtconv.Vec3(data, "Pos").Add(offset))
+		if e := st.DecodeNBT(m); e != nil {
+			entities = append(entities, e)
+		}
+	}
+	return entities
+}
+
+// index returns the index in the block slices of a position in the Structure. Blocks are ordered by x first,
+// then y, then z, matching the order of the block indices in .mcstructure files.
+func (s *Structure) index(x, y, z int) int {
+	return (x*s.size[1]+y)*s.size[2] + z
+}
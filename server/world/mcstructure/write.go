@@ -0,0 +1,96 @@
+package mcstructure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// WriteFile writes the Structure to a .mcstructure file at the path passed. If the file already exists, it
+// is overwritten.
+func (s *Structure) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("write structure: %w", err)
+	}
+	if err := s.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write structure: %w", err)
+	}
+	return nil
+}
+
+// Write writes the Structure in the .mcstructure format to the io.Writer passed. The NBT of blocks that
+// implement world.NBTer is written as block entity data.
+func (s *Structure) Write(w io.Writer) error {
+	var (
+		palette      []map[string]any
+		indices      = make(map[uint32]int32)
+		positionData = make(map[string]any)
+		layers       = [2][]int32{make([]int32, len(s.blocks)), make([]int32, len(s.blocks))}
+	)
+	paletteIndex := func(b world.Block) int32 {
+		rid := world.BlockRuntimeID(b)
+		index, ok := indices[rid]
+		if !ok {
+			index = int32(len(palette))
+			indices[rid] = index
+			palette = append(palette, nbtconv.WriteBlock(b))
+		}
+		return index
+	}
+	for x := 0; x < s.size[0]; x++ {
+		for y := 0; y < s.size[1]; y++ {
+			for z := 0; z < s.size[2]; z++ {
+				i := s.index(x, y, z)
+				layers[0][i], layers[1][i] = -1, -1
+				b := s.blocks[i]
+				if b == nil {
+					continue
+				}
+				layers[0][i] = paletteIndex(b)
+				if liq := s.liquids[i]; liq != nil {
+					layers[1][i] = paletteIndex(liq)
+				}
+				if nbter, ok := b.(world.NBTer); ok {
+					data := nbter.EncodeNBT()
+					data["x"], data["y"], data["z"] = int32(s.origin[0]+x), int32(s.origin[1]+y), int32(s.origin[2]+z)
+					positionData[strconv.Itoa(i)] = map[string]any{"block_entity_data": data}
+				}
+			}
+		}
+	}
+	entities := s.entities
+	if entities == nil {
+		entities = []map[string]any{}
+	}
+	if palette == nil {
+		palette = []map[string]any{}
+	}
+	m := map[string]any{
+		"format_version":         int32(formatVersion),
+		"size":                   []int32{int32(s.size[0]), int32(s.size[1]), int32(s.size[2])},
+		"structure_world_origin": nbtconv.PosToInt32Slice(s.origin),
+		"structure": map[string]any{
+			"block_indices": [][]int32{layers[0], layers[1]},
+			"entities":      entities,
+			"palette": map[string]any{
+				"default": map[string]any{
+					"block_palette":       palette,
+					"block_position_data": positionData,
+				},
+			},
+		},
+	}
+	if err := nbt.NewEncoderWithEncoding(w, nbt.LittleEndian).Encode(m); err != nil {
+		return fmt.Errorf("write structure: encode nbt: %w", err)
+	}
+	return nil
+}
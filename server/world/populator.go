@@ -0,0 +1,274 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// Populator populates chunks once they and all chunks directly surrounding them have been generated. Unlike a
+// Generator, a Populator may place features, such as trees and structures, that cross the borders of the chunk that
+// is populated. A Generator may implement Populator to have the chunks of a World populated after they are generated.
+type Populator interface {
+	// Populate populates the chunk at the ChunkPos passed. The Population passed provides access to the chunk and
+	// the eight chunks surrounding it, into which Populate may also place blocks. Populate must not call any methods
+	// on the World, as the chunks of the Population are locked while Populate is called.
+	Populate(pos ChunkPos, p *Population)
+}
+
+// Population provides access to the blocks in a 3x3 area of chunks, centred around a chunk that is being populated by
+// a Populator. Positions outside of this area are ignored: Reading a block outside of it returns air and setting one
+// has no effect.
+type Population struct {
+	centre ChunkPos
+	r      cube.Range
+
+	cols    [9]*Column
+	changed [9]bool
+}
+
+// Range returns the range in blocks of the chunks in the Population.
+func (p *Population) Range() cube.Range {
+	return p.r
+}
+
+// Contains checks if the position passed lies within the chunks of the Population.
+func (p *Population) Contains(pos cube.Pos) bool {
+	_, ok := p.column(pos)
+	return ok && !pos.OutOfBounds(p.r)
+}
+
+// Block returns the block at a position in the Population. If the position is outside of the Population, air is
+// returned.
+func (p *Population) Block(pos cube.Pos) Block {
+	i, ok := p.column(pos)
+	if !ok || pos.OutOfBounds(p.r) {
+		return air()
+	}
+	c := p.cols[i]
+	rid := c.Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0)
+	if nbtBlocks[rid] {
+		if b, ok := c.BlockEntities[pos]; ok {
+			return b
+		}
+	}
+	b, _ := BlockByRuntimeID(rid)
+	return b
+}
+
+// Liquid returns the liquid at a position in the Population, either in the first or second layer. If no liquid is
+// present at the position, false is returned.
+func (p *Population) Liquid(pos cube.Pos) (Liquid, bool) {
+	i, ok := p.column(pos)
+	if !ok || pos.OutOfBounds(p.r) {
+		return nil, false
+	}
+	c := p.cols[i]
+	x, y, z := uint8(pos[0]), int16(pos[1]), uint8(pos[2])
+	for layer := uint8(0); layer < 2; layer++ {
+		b, _ := BlockByRuntimeID(c.Block(x, y, z, layer))
+		if liq, ok := b.(Liquid); ok {
+			return liq, true
+		}
+	}
+	return nil, false
+}
+
+// SetBlock sets the block at a position in the Population. Any liquid in the second layer at the position is removed.
+// Unlike World.SetBlock, no block updates are sent to neighbouring blocks.
+func (p *Population) SetBlock(pos cube.Pos, b Block) {
+	i, ok := p.column(pos)
+	if !ok || pos.OutOfBounds(p.r) {
+		return
+	}
+	p.setBlock(i, pos, b, nil)
+}
+
+// setBlock sets a block and liquid in the column with index i at a position.
+func (p *Population) setBlock(i int, pos cube.Pos, b Block, liq Liquid) {
+	c := p.cols[i]
+	x, y, z := uint8(pos[0]), int16(pos[1]), uint8(pos[2])
+	rid := BlockRuntimeID(b)
+	c.SetBlock(x, y, z, 0, rid)
+	if nbtBlocks[rid] {
+		c.BlockEntities[pos] = b
+	} else {
+		delete(c.BlockEntities, pos)
+	}
+	if liq != nil {
+		c.SetBlock(x, y, z, 1, BlockRuntimeID(liq))
+	} else if len(c.SubChunk(y).Layers()) > 1 {
+		c.SetBlock(x, y, z, 1, airRID)
+	}
+	p.changed[i] = true
+}
+
+// Biome returns the biome at a position in the Population. If the position is outside of the Population, the ocean
+// biome is returned.
+func (p *Population) Biome(pos cube.Pos) Biome {
+	i, ok := p.column(pos)
+	if !ok || pos.OutOfBounds(p.r) {
+		return ocean()
+	}
+	b, _ := BiomeByID(int(p.cols[i].Biome(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))))
+	return b
+}
+
+// HighestBlock returns the Y of the highest non-air block at an x and z in the Population. If the position is outside
+// of the Population, the minimum Y of the Range is returned.
+func (p *Population) HighestBlock(x, z int) int {
+	i, ok := p.column(cube.Pos{x, 0, z})
+	if !ok {
+		return p.r[0]
+	}
+	return int(p.cols[i].HighestBlock(uint8(x), uint8(z)))
+}
+
+// BuildStructure builds a Structure at a specific position in the Population, similarly to World.BuildStructure.
+// The parts of the Structure that lie outside of the Population are not built.
+func (p *Population) BuildStructure(pos cube.Pos, s Structure) {
+	dim := s.Dimensions()
+	for x := 0; x < dim[0]; x++ {
+		for z := 0; z < dim[2]; z++ {
+			i, ok := p.column(cube.Pos{pos[0] + x, 0, pos[2] + z})
+			if !ok {
+				continue
+			}
+			for y := 0; y < dim[1]; y++ {
+				actual := cube.Pos{pos[0] + x, pos[1] + y, pos[2] + z}
+				if actual.OutOfBounds(p.r) {
+					continue
+				}
+				b, liq := s.At(x, y, z, func(x, y, z int) Block {
+					return p.Block(cube.Pos{pos[0] + x, pos[1] + y, pos[2] + z})
+				})
+				if b == nil {
+					if liq == nil {
+						continue
+					}
+					b = p.Block(actual)
+				}
+				p.setBlock(i, actual, b, liq)
+			}
+		}
+	}
+}
+
+// column returns the index of the Column in the Population that holds the position passed. If the position is
+// outside of the Population, false is returned.
+func (p *Population) column(pos cube.Pos) (int, bool) {
+	x, z := int32(pos[0]>>4)-p.centre[0]+1, int32(pos[2]>>4)-p.centre[1]+1
+	if x < 0 || x > 2 || z < 0 || z > 2 {
+		return 0, false
+	}
+	return int(x*3 + z), true
+}
+
+// chunkPos returns the position of the Column in the Population with the index passed.
+func (p *Population) chunkPos(i int) ChunkPos {
+	return ChunkPos{p.centre[0] + int32(i/3) - 1, p.centre[1] + int32(i%3) - 1}
+}
+
+// populate populates all chunks around the chunk at the position passed, including the chunk itself, that have not
+// yet been populated and of which all neighbours are now loaded. populate does nothing if the Generator of the World
+// does not implement Populator.
+// populate must be called without the chunkMu of the World locked: It is only locked while looking up the chunks to
+// populate, so that populating a chunk only blocks access to the chunks directly around it.
+func (w *World) populate(centre ChunkPos) {
+	pop, ok := w.conf.Generator.(Populator)
+	if !ok {
+		return
+	}
+	pending := make([]*Population, 0, 9)
+	w.chunkMu.Lock()
+	for x := int32(-1); x <= 1; x++ {
+		for z := int32(-1); z <= 1; z++ {
+			if p, ok := w.population(ChunkPos{centre[0] + x, centre[1] + z}); ok {
+				pending = append(pending, p)
+			}
+		}
+	}
+	w.chunkMu.Unlock()
+
+	populated := make([]ChunkPos, 0, len(pending))
+	for _, p := range pending {
+		if w.populateChunk(p, pop) {
+			populated = append(populated, p.centre)
+		}
+	}
+	if len(populated) == 0 {
+		return
+	}
+	w.chunkMu.Lock()
+	for _, pos := range populated {
+		w.calculateLight(pos)
+	}
+	w.chunkMu.Unlock()
+}
+
+// population returns a Population for the chunk at the position passed if the chunk was not yet populated and if
+// all of its neighbours are loaded. The Columns of the Population are marked as populating, so that they are not
+// unloaded before populateChunk is called.
+// population must be called with the chunkMu of the World locked.
+func (w *World) population(pos ChunkPos) (*Population, bool) {
+	if col, ok := w.chunks[pos]; !ok || col.populated() {
+		return nil, false
+	}
+	p := &Population{centre: pos, r: w.Range()}
+	for x := int32(0); x < 3; x++ {
+		for z := int32(0); z < 3; z++ {
+			neighbour, ok := w.chunks[ChunkPos{pos[0] + x - 1, pos[1] + z - 1}]
+			if !ok {
+				// Not all neighbours are loaded yet, so the chunk cannot be populated safely.
+				return nil, false
+			}
+			p.cols[x*3+z] = neighbour
+		}
+	}
+	for _, c := range p.cols {
+		c.Lock()
+		c.populating++
+		c.Unlock()
+	}
+	return p, true
+}
+
+// populationLockOrder is the order in which the Columns of a Population are locked. It matches the order in which
+// spreadLight locks Columns, so that the two never deadlock.
+var populationLockOrder = [9]int{0, 3, 6, 1, 4, 7, 2, 5, 8}
+
+// populateChunk populates the centre chunk of the Population passed using the Populator passed, unless it was
+// populated by another goroutine in the meantime. Only the Columns of the Population are locked while doing so.
+// populateChunk returns true if the chunk was populated.
+func (w *World) populateChunk(p *Population, pop Populator) bool {
+	for _, i := range populationLockOrder {
+		p.cols[i].Lock()
+	}
+	col := p.cols[4]
+	populated := !col.Populated
+	if populated {
+		pop.Populate(p.centre, p)
+		col.Populated, col.modified = true, true
+	}
+	for _, i := range populationLockOrder {
+		c := p.cols[i]
+		c.populating--
+		if p.changed[i] {
+			c.modified = true
+			neighbour := p.chunkPos(i)
+			chunk.LightArea([]*chunk.Chunk{c.Chunk}, int(neighbour[0]), int(neighbour[1])).Fill()
+		}
+		c.Unlock()
+	}
+
+	for i, c := range p.cols {
+		if !p.changed[i] {
+			continue
+		}
+		c.Lock()
+		for _, viewer := range c.viewers {
+			viewer.ViewChunk(p.chunkPos(i), c.Chunk, c.BlockEntities)
+		}
+		c.Unlock()
+	}
+	return populated
+}
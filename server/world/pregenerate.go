@@ -0,0 +1,90 @@
+package world
+
+import (
+	"runtime"
+	"sync"
+)
+
+// PregenerateArea generates all chunks in a square area around the ChunkPos centre, so that they do not need to be
+// generated once players explore the area. The area spans radius chunks in each direction from the centre. Chunks
+// are generated in parallel using the Generator of the World and stored to its Provider once they are no longer
+// needed, without any Loader or Viewer having to be present. Chunks that were already generated are only loaded.
+// If the Generator implements Populator, one additional ring of chunks is generated around the area, so that all
+// chunks within the area can be populated.
+// The progress function passed is called after every row of chunks generated with the amount of chunks generated
+// so far and the total amount of chunks to generate. It may be nil. PregenerateArea blocks until all chunks have
+// been generated.
+func (w *World) PregenerateArea(centre ChunkPos, radius int, progress func(done, total int)) {
+	if w == nil || radius < 0 {
+		return
+	}
+	if _, ok := w.conf.Generator.(Populator); ok {
+		radius++
+	}
+	r := int32(radius)
+	size := 2*radius + 1
+	total := size * size
+
+	var (
+		wg    sync.WaitGroup
+		done  int
+		queue = make(chan ChunkPos)
+	)
+	for i := 0; i < runtime.NumCPU(); i++ {
+		go func() {
+			for pos := range queue {
+				w.chunk(pos).Unlock()
+				wg.Done()
+			}
+		}()
+	}
+	for x := centre[0] - r; x <= centre[0]+r; x++ {
+		wg.Add(size)
+		for z := centre[1] - r; z <= centre[1]+r; z++ {
+			queue <- ChunkPos{x, z}
+		}
+		wg.Wait()
+		done += size
+
+		// All neighbours of the chunks two rows back have now been generated, so those chunks were populated if
+		// possible and are no longer needed.
+		w.unloadRow(x-2, centre[1]-r, centre[1]+r)
+		if progress != nil {
+			progress(done, total)
+		}
+	}
+	close(queue)
+
+	w.unloadRow(centre[0]+r-1, centre[1]-r, centre[1]+r)
+	w.unloadRow(centre[0]+r, centre[1]-r, centre[1]+r)
+}
+
+// unloadRow saves and removes the chunks with an x of x and a z between minZ and maxZ (inclusive) from the cache of
+// the World, if no viewers are viewing them.
+func (w *World) unloadRow(x, minZ, maxZ int32) {
+	toSave := make(map[ChunkPos]*Column)
+
+	w.chunkMu.Lock()
+	for z := minZ; z <= maxZ; z++ {
+		pos := ChunkPos{x, z}
+		c, ok := w.chunks[pos]
+		if !ok {
+			continue
+		}
+		c.Lock()
+		v := len(c.viewers) + c.populating
+		c.Unlock()
+		if v == 0 {
+			toSave[pos] = c
+			delete(w.chunks, pos)
+			if w.lastPos == pos {
+				w.lastChunk = nil
+			}
+		}
+	}
+	w.chunkMu.Unlock()
+
+	for pos, c := range toSave {
+		w.saveChunk(pos, c)
+	}
+}
@@ -30,6 +30,23 @@ type Provider interface {
 	StoreColumn(pos ChunkPos, dim Dimension, col *Column) error
 }
 
+// Snapshotter is implemented by Providers that are able to take snapshots of their data while a World using them is
+// running, so that backups of the World can be made without closing it.
+type Snapshotter interface {
+	// Snapshot takes a point-in-time snapshot of the data held by the Provider. Data stored to the Provider after the
+	// call to Snapshot is not part of the Snapshot returned.
+	Snapshot() (Snapshot, error)
+}
+
+// Snapshot is a read-only copy of the data of a Provider at a specific point in time, as returned by
+// Snapshotter.Snapshot. A Snapshot must be released by calling Release once it is no longer used.
+type Snapshot interface {
+	// WriteArchive writes the data held by the Snapshot to w in the form of a zip archive.
+	WriteArchive(w io.Writer) error
+	// Release releases the resources held by the Snapshot.
+	Release()
+}
+
 // Compile time check to make sure NopProvider implements Provider.
 var _ Provider = (*NopProvider)(nil)
 
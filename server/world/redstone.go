@@ -0,0 +1,96 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// Conductor represents a block that is a source of redstone power, such as a redstone torch, a lever or redstone
+// dust. Blocks that are not a Conductor may still conduct power if they are solid and are strongly powered by a
+// Conductor next to them.
+type Conductor interface {
+	Block
+	// WeakPower returns the power, between 0 and 15, that the Conductor at the position passed provides to the block
+	// on the face passed. Weak power is received by redstone components directly next to the Conductor, but does not
+	// power solid blocks. If accountForDust is false, redstone dust should not provide any power, which is used by
+	// redstone dust so that it does not power itself through the blocks around it.
+	WeakPower(pos cube.Pos, face cube.Face, w *World, accountForDust bool) int
+	// StrongPower returns the power, between 0 and 15, that the Conductor at the position passed provides to the
+	// block on the face passed. Unlike weak power, strong power also powers solid blocks, which in turn power the
+	// redstone components around them.
+	StrongPower(pos cube.Pos, face cube.Face, w *World, accountForDust bool) int
+}
+
+// RedstonePower returns the redstone power that the block at the position passed provides to the block on the face
+// passed. If the block is a Conductor, its weak power is returned. If the block is a solid block, the highest strong
+// power that any Conductor around it provides to it is returned.
+func (w *World) RedstonePower(pos cube.Pos, face cube.Face, accountForDust bool) int {
+	b := w.Block(pos)
+	if c, ok := b.(Conductor); ok {
+		return c.WeakPower(pos, face, w, accountForDust)
+	}
+	if !conducts(pos, b, w) {
+		return 0
+	}
+	power := 0
+	for _, f := range cube.Faces() {
+		power = max(power, w.StrongRedstonePower(pos.Side(f), f.Opposite(), accountForDust))
+		if power >= 15 {
+			break
+		}
+	}
+	return power
+}
+
+// StrongRedstonePower returns the strong redstone power that the block at the position passed provides to the block
+// on the face passed. If the block is not a Conductor, 0 is returned.
+func (w *World) StrongRedstonePower(pos cube.Pos, face cube.Face, accountForDust bool) int {
+	if c, ok := w.Block(pos).(Conductor); ok {
+		return c.StrongPower(pos, face, w, accountForDust)
+	}
+	return 0
+}
+
+// ReceivedRedstonePower returns the highest redstone power that the block at the position passed receives from any of
+// the blocks around it.
+func (w *World) ReceivedRedstonePower(pos cube.Pos, accountForDust bool) int {
+	power := 0
+	for _, f := range cube.Faces() {
+		power = max(power, w.RedstonePower(pos.Side(f), f.Opposite(), accountForDust))
+		if power >= 15 {
+			break
+		}
+	}
+	return power
+}
+
+// doRedstoneUpdatesAround updates the blocks around each of the neighbours of the position passed. It is called when
+// a Conductor is placed, broken or changed, so that the blocks powered through solid blocks around it are updated
+// too. The position of the Conductor is passed to these blocks as the neighbour that changed.
+func (w *World) doRedstoneUpdatesAround(pos cube.Pos) {
+	if w == nil || pos.OutOfBounds(w.Range()) {
+		return
+	}
+	w.updateMu.Lock()
+	defer w.updateMu.Unlock()
+	changed := pos
+	pos.Neighbours(func(neighbour cube.Pos) {
+		neighbour.Neighbours(func(pos cube.Pos) {
+			w.updateNeighbour(pos, changed)
+		}, w.Range())
+	}, w.Range())
+}
+
+// conducts checks if the block passed at the position passed is able to conduct redstone power. Only solid blocks
+// that fully block light can conduct power.
+func conducts(pos cube.Pos, b Block, w *World) bool {
+	if d, ok := b.(lightDiffuser); ok && d.LightDiffusionLevel() < 15 {
+		return false
+	}
+	m := b.Model()
+	for _, f := range cube.Faces() {
+		if !m.FaceSolid(pos, f, w) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,5 @@
+// Package schematic implements reading of Java Edition schematics, such as Sponge .schem files and legacy MCEdit
+// .schematic files, into a world.Structure that may be built using World.BuildStructure. Java Edition block states
+// are converted to Bedrock Edition blocks using a Mapping, which may be replaced to support blocks that are not
+// handled by the DefaultMapping.
+package schematic
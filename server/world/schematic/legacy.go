@@ -0,0 +1,166 @@
+package schematic
+
+import (
+	"strconv"
+)
+
+var (
+	// colours holds the colours of wool, terracotta, glass and concrete in the order of their legacy metadata.
+	colours = []string{"white", "orange", "magenta", "light_blue", "yellow", "lime", "pink", "gray", "light_gray", "cyan", "purple", "blue", "brown", "green", "red", "black"}
+	// woods holds the wood types of planks, saplings and wooden slabs in the order of their legacy metadata.
+	woods = []string{"oak", "spruce", "birch", "jungle", "acacia", "dark_oak"}
+	// directions holds the directions used by the legacy metadata of chests, furnaces and ladders, starting at
+	// metadata 2.
+	directions = []string{"north", "south", "west", "east"}
+
+	// legacyNames holds the names of blocks with legacy IDs of which the metadata is not used.
+	legacyNames = map[uint16]string{
+		0: "air", 2: "grass_block", 4: "cobblestone", 7: "bedrock", 13: "gravel", 14: "gold_ore", 15: "iron_ore",
+		16: "coal_ore", 20: "glass", 21: "lapis_ore", 22: "lapis_block", 25: "note_block", 30: "cobweb",
+		37: "dandelion", 39: "brown_mushroom", 40: "red_mushroom", 41: "gold_block", 42: "iron_block", 45: "bricks",
+		46: "tnt", 47: "bookshelf", 48: "mossy_cobblestone", 49: "obsidian", 51: "fire", 52: "spawner",
+		56: "diamond_ore", 57: "diamond_block", 58: "crafting_table", 60: "farmland", 73: "redstone_ore",
+		79: "ice", 80: "snow_block", 81: "cactus", 82: "clay", 83: "sugar_cane", 84: "jukebox", 85: "oak_fence",
+		87: "netherrack", 88: "soul_sand", 89: "glowstone", 101: "iron_bars", 102: "glass_pane", 103: "melon",
+		106: "vine", 110: "mycelium", 111: "lily_pad", 112: "nether_bricks", 113: "nether_brick_fence",
+		121: "end_stone", 122: "dragon_egg", 123: "redstone_lamp", 129: "emerald_ore", 133: "emerald_block",
+		152: "redstone_block", 153: "nether_quartz_ore", 165: "slime_block", 169: "sea_lantern", 172: "terracotta",
+		173: "coal_block", 174: "packed_ice", 188: "spruce_fence", 189: "birch_fence", 190: "jungle_fence",
+		191: "dark_oak_fence", 192: "acacia_fence", 201: "purpur_block", 206: "end_stone_bricks",
+		213: "magma_block", 214: "nether_wart_block", 215: "red_nether_bricks",
+	}
+	// legacyVariants holds the names of blocks with legacy IDs of which the metadata selects the variant.
+	legacyVariants = map[uint16][]string{
+		1:   {"stone", "granite", "polished_granite", "diorite", "polished_diorite", "andesite", "polished_andesite"},
+		3:   {"dirt", "coarse_dirt", "podzol"},
+		12:  {"sand", "red_sand"},
+		19:  {"sponge", "wet_sponge"},
+		24:  {"sandstone", "chiseled_sandstone", "cut_sandstone"},
+		31:  {"dead_bush", "grass", "fern"},
+		38:  {"poppy", "blue_orchid", "allium", "azure_bluet", "red_tulip", "orange_tulip", "white_tulip", "pink_tulip", "oxeye_daisy"},
+		97:  {"infested_stone", "infested_cobblestone", "infested_stone_bricks", "infested_mossy_stone_bricks", "infested_cracked_stone_bricks", "infested_chiseled_stone_bricks"},
+		98:  {"stone_bricks", "mossy_stone_bricks", "cracked_stone_bricks", "chiseled_stone_bricks"},
+		139: {"cobblestone_wall", "mossy_cobblestone_wall"},
+		168: {"prismarine", "prismarine_bricks", "dark_prismarine"},
+		179: {"red_sandstone", "chiseled_red_sandstone", "cut_red_sandstone"},
+	}
+	// legacyColoured holds the suffixes of the names of coloured blocks with legacy IDs.
+	legacyColoured = map[uint16]string{
+		35: "_wool", 95: "_stained_glass", 159: "_terracotta", 160: "_stained_glass_pane", 171: "_carpet",
+		251: "_concrete", 252: "_concrete_powder",
+	}
+	// legacyStairs holds the names of the stairs with legacy IDs.
+	legacyStairs = map[uint16]string{
+		53: "oak_stairs", 67: "cobblestone_stairs", 108: "brick_stairs", 109: "stone_brick_stairs",
+		114: "nether_brick_stairs", 128: "sandstone_stairs", 134: "spruce_stairs", 135: "birch_stairs",
+		136: "jungle_stairs", 156: "quartz_stairs", 163: "acacia_stairs", 164: "dark_oak_stairs",
+		180: "red_sandstone_stairs", 203: "purpur_stairs",
+	}
+	// stoneSlabs holds the types of stone slabs with the legacy IDs 43 and 44.
+	stoneSlabs = []string{"smooth_stone", "sandstone", "petrified_oak", "cobblestone", "brick", "stone_brick", "nether_brick", "quartz"}
+)
+
+// LegacyState converts a legacy block ID and metadata value, as found in MCEdit .schematic files and worlds from
+// before Java Edition 1.13, to a Java Edition block State. If the block is not known, false is returned.
+func LegacyState(id uint16, meta uint8) (State, bool) {
+	s := func(name string, properties ...string) (State, bool) {
+		state := State{Name: "minecraft:" + name, Properties: map[string]string{}}
+		for i := 0; i+1 < len(properties); i += 2 {
+			state.Properties[properties[i]] = properties[i+1]
+		}
+		return state, true
+	}
+	if name, ok := legacyNames[id]; ok {
+		return s(name)
+	}
+	if variants, ok := legacyVariants[id]; ok {
+		if int(meta) < len(variants) {
+			return s(variants[meta])
+		}
+		return s(variants[0])
+	}
+	if suffix, ok := legacyColoured[id]; ok {
+		return s(colours[meta&15] + suffix)
+	}
+	if name, ok := legacyStairs[id]; ok {
+		half := "bottom"
+		if meta&4 != 0 {
+			half = "top"
+		}
+		return s(name, "facing", []string{"east", "west", "south", "north"}[meta&3], "half", half)
+	}
+	switch id {
+	case 5:
+		return s(woods[int(meta)%len(woods)] + "_planks")
+	case 6:
+		return s(woods[int(meta&7)%len(woods)] + "_sapling")
+	case 8, 9:
+		return s("water", "level", strconv.Itoa(int(meta&15)))
+	case 10, 11:
+		return s("lava", "level", strconv.Itoa(int(meta&15)))
+	case 17, 162:
+		wood := []string{"oak", "spruce", "birch", "jungle"}[meta&3]
+		if id == 162 {
+			wood = []string{"acacia", "dark_oak", "acacia", "dark_oak"}[meta&3]
+		}
+		if meta>>2 == 3 {
+			return s(wood+"_wood", "axis", "y")
+		}
+		return s(wood+"_log", "axis", []string{"y", "x", "z"}[meta>>2])
+	case 18, 161:
+		wood := []string{"oak", "spruce", "birch", "jungle"}[meta&3]
+		if id == 161 {
+			wood = []string{"acacia", "dark_oak", "acacia", "dark_oak"}[meta&3]
+		}
+		return s(wood+"_leaves", "persistent", strconv.FormatBool(meta&4 != 0))
+	case 43:
+		return s(stoneSlabs[meta&7]+"_slab", "type", "double")
+	case 44:
+		return s(stoneSlabs[meta&7]+"_slab", "type", map[bool]string{false: "bottom", true: "top"}[meta&8 != 0])
+	case 125:
+		return s(woods[int(meta&7)%len(woods)]+"_slab", "type", "double")
+	case 126:
+		return s(woods[int(meta&7)%len(woods)]+"_slab", "type", map[bool]string{false: "bottom", true: "top"}[meta&8 != 0])
+	case 50:
+		if meta >= 1 && meta <= 4 {
+			return s("wall_torch", "facing", []string{"east", "west", "south", "north"}[meta-1])
+		}
+		return s("torch")
+	case 54, 61, 62, 65:
+		facing := "north"
+		if meta >= 2 && meta <= 5 {
+			facing = directions[meta-2]
+		}
+		name := map[uint16]string{54: "chest", 61: "furnace", 62: "furnace", 65: "ladder"}[id]
+		return s(name, "facing", facing, "lit", strconv.FormatBool(id == 62))
+	case 74:
+		return s("redstone_ore", "lit", "true")
+	case 78:
+		return s("snow", "layers", strconv.Itoa(int(meta&7)+1))
+	case 86, 91:
+		name := map[uint16]string{86: "carved_pumpkin", 91: "jack_o_lantern"}[id]
+		return s(name, "facing", []string{"south", "west", "north", "east"}[meta&3])
+	case 124:
+		return s("redstone_lamp", "lit", "true")
+	case 155:
+		switch meta {
+		case 1:
+			return s("chiseled_quartz_block")
+		case 2, 3, 4:
+			return s("quartz_pillar", "axis", []string{"y", "x", "z"}[meta-2])
+		}
+		return s("quartz_block")
+	case 170, 216:
+		name := map[uint16]string{170: "hay_block", 216: "bone_block"}[id]
+		return s(name, "axis", []string{"y", "x", "z", "y"}[meta>>2&3])
+	case 175:
+		if meta&8 != 0 {
+			// The upper half of double plants does not store its type.
+			return s("tall_grass", "half", "upper")
+		}
+		if int(meta) < 6 {
+			return s([]string{"sunflower", "lilac", "tall_grass", "large_fern", "rose_bush", "peony"}[meta], "half", "lower")
+		}
+	}
+	return State{}, false
+}
@@ -0,0 +1,381 @@
+package schematic
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Mapping maps Java Edition block states to Bedrock Edition blocks. A Mapping is passed to Read to convert the
+// palette of a schematic. Mappings may be combined using Chain, so that a custom Mapping only has to handle the
+// states that the DefaultMapping does not handle correctly.
+type Mapping interface {
+	// Map maps a Java Edition block State to the name and properties of a Bedrock Edition block. The properties
+	// returned may be incomplete: Missing properties are set to their default values and properties that the
+	// block does not have are ignored. Map returns false if the State cannot be mapped.
+	Map(s State) (name string, properties map[string]any, ok bool)
+}
+
+// MappingFunc is a function that implements Mapping.
+type MappingFunc func(s State) (name string, properties map[string]any, ok bool)
+
+// Map ...
+func (f MappingFunc) Map(s State) (string, map[string]any, bool) {
+	return f(s)
+}
+
+// Chain returns a Mapping that tries the Mappings passed in order and returns the result of the first Mapping
+// that is able to map a State. Chain may be used to override parts of the DefaultMapping by passing it as the
+// last Mapping.
+func Chain(m ...Mapping) Mapping {
+	return MappingFunc(func(s State) (string, map[string]any, bool) {
+		for _, mapping := range m {
+			if name, properties, ok := mapping.Map(s); ok {
+				return name, properties, true
+			}
+		}
+		return "", nil, false
+	})
+}
+
+// DefaultMapping is the Mapping used if no Mapping is passed to Read. It maps Java Edition blocks to Bedrock
+// Edition blocks with the same name, unless the block is known to have a different name in Bedrock Edition,
+// and converts the properties used by common blocks, such as stairs, slabs, logs, doors and torches.
+// Properties that are stored in block entities in Bedrock Edition, such as the colour of beds, are not mapped.
+var DefaultMapping Mapping = defaultMapping{}
+
+// defaultMapping implements the DefaultMapping.
+type defaultMapping struct{}
+
+// Map ...
+func (defaultMapping) Map(s State) (string, map[string]any, bool) {
+	if !strings.HasPrefix(s.Name, "minecraft:") {
+		return "", nil, false
+	}
+	name, properties := s.Name, map[string]any{}
+	if r, ok := renamed[name]; ok {
+		name = r.name
+		for k, v := range r.properties {
+			properties[k] = v
+		}
+	}
+	name = renameByState(name, s.Properties)
+	for k, v := range s.Properties {
+		convertProperty(name, k, v, properties)
+	}
+	switch s.Properties["face"] {
+	case "floor":
+		properties["facing_direction"] = int32(1)
+	case "ceiling":
+		properties["facing_direction"] = int32(0)
+	}
+	if _, ok := blockStates()[name]; !ok {
+		return "", nil, false
+	}
+	return name, properties, true
+}
+
+// rename holds the Bedrock Edition name and fixed properties of a Java Edition block that has a different
+// name in Bedrock Edition.
+type rename struct {
+	name       string
+	properties map[string]any
+}
+
+// renamed holds the Java Edition blocks that have a different name or additional properties in Bedrock Edition.
+var renamed = map[string]rename{}
+
+func init() {
+	simple := map[string]string{
+		"dandelion": "yellow_flower", "dead_bush": "deadbush", "cobweb": "web", "lily_pad": "waterlily",
+		"slime_block": "slime", "note_block": "noteblock", "spawner": "mob_spawner", "powered_rail": "golden_rail",
+		"nether_quartz_ore": "quartz_ore", "terracotta": "hardened_clay", "sugar_cane": "reeds",
+		"dirt_path": "grass_path", "bricks": "brick_block", "nether_bricks": "nether_brick",
+		"red_nether_bricks": "red_nether_brick", "end_stone_bricks": "end_bricks", "magma_block": "magma",
+		"melon": "melon_block", "jack_o_lantern": "lit_pumpkin", "snow": "snow_layer", "snow_block": "snow",
+		"cobblestone_stairs": "stone_stairs", "stone_stairs": "normal_stone_stairs", "oak_door": "wooden_door",
+		"oak_button": "wooden_button", "oak_pressure_plate": "wooden_pressure_plate", "oak_trapdoor": "trapdoor",
+		"oak_fence_gate": "fence_gate", "shulker_box": "undyed_shulker_box", "wall_torch": "torch",
+		"soul_wall_torch": "soul_torch", "redstone_wall_torch": "redstone_torch", "cave_air": "air",
+		"void_air": "air", "bubble_column": "water", "kelp_plant": "kelp", "tall_seagrass": "seagrass",
+		"repeater": "unpowered_repeater", "comparator": "unpowered_comparator", "nether_portal": "portal",
+		"light_gray_glazed_terracotta": "silver_glazed_terracotta", "oak_sign": "standing_sign",
+		"oak_wall_sign": "wall_sign", "rooted_dirt": "dirt_with_roots",
+		"attached_melon_stem": "melon_stem", "attached_pumpkin_stem": "pumpkin_stem", "beetroots": "beetroot",
+	}
+	for java, bedrock := range simple {
+		renamed["minecraft:"+java] = rename{name: "minecraft:" + bedrock}
+	}
+	typed := []struct {
+		bedrock, property string
+		values            map[string]string
+	}{
+		{"tallgrass", "tall_grass_type", map[string]string{"grass": "default", "short_grass": "default", "fern": "fern"}},
+		{"double_plant", "double_plant_type", map[string]string{"tall_grass": "grass", "large_fern": "fern", "sunflower": "sunflower", "lilac": "syringa", "rose_bush": "rose", "peony": "paeonia"}},
+		{"dirt", "dirt_type", map[string]string{"coarse_dirt": "coarse"}},
+		{"sand", "sand_type", map[string]string{"red_sand": "red"}},
+		{"sandstone", "sand_stone_type", map[string]string{"chiseled_sandstone": "heiroglyphs", "cut_sandstone": "cut", "smooth_sandstone": "smooth"}},
+		{"red_sandstone", "sand_stone_type", map[string]string{"chiseled_red_sandstone": "heiroglyphs", "cut_red_sandstone": "cut", "smooth_red_sandstone": "smooth"}},
+		{"stonebrick", "stone_brick_type", map[string]string{"stone_bricks": "default", "mossy_stone_bricks": "mossy", "cracked_stone_bricks": "cracked", "chiseled_stone_bricks": "chiseled"}},
+		{"prismarine", "prismarine_block_type", map[string]string{"prismarine_bricks": "bricks", "dark_prismarine": "dark"}},
+		{"quartz_block", "chisel_type", map[string]string{"chiseled_quartz_block": "chiseled", "quartz_pillar": "lines", "smooth_quartz": "smooth"}},
+		{"purpur_block", "chisel_type", map[string]string{"purpur_pillar": "lines"}},
+		{"sponge", "sponge_type", map[string]string{"wet_sponge": "wet"}},
+		{"monster_egg", "monster_egg_stone_type", map[string]string{"infested_stone": "stone", "infested_cobblestone": "cobblestone", "infested_stone_bricks": "stone_brick", "infested_mossy_stone_bricks": "mossy_stone_brick", "infested_cracked_stone_bricks": "cracked_stone_brick", "infested_chiseled_stone_bricks": "chiseled_stone_brick"}},
+		{"cobblestone_wall", "wall_block_type", map[string]string{"cobblestone_wall": "cobblestone", "mossy_cobblestone_wall": "mossy_cobblestone", "stone_brick_wall": "stone_brick", "mossy_stone_brick_wall": "mossy_stone_brick", "andesite_wall": "andesite", "diorite_wall": "diorite", "granite_wall": "granite", "sandstone_wall": "sandstone", "red_sandstone_wall": "red_sandstone", "brick_wall": "brick", "prismarine_wall": "prismarine", "nether_brick_wall": "nether_brick", "red_nether_brick_wall": "red_nether_brick", "end_stone_brick_wall": "end_brick"}},
+		{"stone_block_slab", "stone_slab_type", map[string]string{"smooth_stone_slab": "smooth_stone", "sandstone_slab": "sandstone", "petrified_oak_slab": "wood", "cobblestone_slab": "cobblestone", "brick_slab": "brick", "stone_brick_slab": "stone_brick", "quartz_slab": "quartz", "nether_brick_slab": "nether_brick"}},
+		{"stone_block_slab2", "stone_slab_type_2", map[string]string{"red_sandstone_slab": "red_sandstone", "purpur_slab": "purpur", "prismarine_slab": "prismarine_rough", "prismarine_brick_slab": "prismarine_brick", "dark_prismarine_slab": "prismarine_dark", "mossy_cobblestone_slab": "mossy_cobblestone", "smooth_sandstone_slab": "smooth_sandstone", "red_nether_brick_slab": "red_nether_brick"}},
+		{"stone_block_slab3", "stone_slab_type_3", map[string]string{"end_stone_brick_slab": "end_stone_brick", "smooth_red_sandstone_slab": "smooth_red_sandstone", "polished_andesite_slab": "polished_andesite", "andesite_slab": "andesite", "diorite_slab": "diorite", "polished_diorite_slab": "polished_diorite", "granite_slab": "granite", "polished_granite_slab": "polished_granite"}},
+		{"stone_block_slab4", "stone_slab_type_4", map[string]string{"mossy_stone_brick_slab": "mossy_stone_brick", "smooth_quartz_slab": "smooth_quartz", "stone_slab": "stone", "cut_sandstone_slab": "cut_sandstone", "cut_red_sandstone_slab": "cut_red_sandstone"}},
+	}
+	for _, t := range typed {
+		for java, value := range t.values {
+			renamed["minecraft:"+java] = rename{name: "minecraft:" + t.bedrock, properties: map[string]any{t.property: value}}
+		}
+	}
+}
+
+// renameByState returns the Bedrock Edition name of a block of which the name depends on its Java Edition
+// properties, such as lit furnaces and double slabs. The name passed is returned if this is not the case.
+func renameByState(name string, properties map[string]string) string {
+	switch {
+	case properties["type"] == "double" && strings.HasPrefix(name, "minecraft:stone_block_slab"):
+		return "minecraft:double_" + strings.TrimPrefix(name, "minecraft:")
+	case properties["type"] == "double" && strings.HasSuffix(name, "_slab"):
+		return strings.TrimSuffix(name, "_slab") + "_double_slab"
+	case properties["lit"] == "true":
+		switch name {
+		case "minecraft:furnace", "minecraft:blast_furnace", "minecraft:smoker", "minecraft:redstone_lamp",
+			"minecraft:redstone_ore", "minecraft:deepslate_redstone_ore":
+			return "minecraft:lit_" + strings.TrimPrefix(name, "minecraft:")
+		}
+	case properties["lit"] == "false" && name == "minecraft:redstone_torch":
+		return "minecraft:unlit_redstone_torch"
+	case properties["powered"] == "true":
+		switch name {
+		case "minecraft:unpowered_repeater":
+			return "minecraft:powered_repeater"
+		case "minecraft:unpowered_comparator":
+			return "minecraft:powered_comparator"
+		}
+	}
+	switch {
+	case strings.HasSuffix(name, "_wall_banner"):
+		return "minecraft:wall_banner"
+	case strings.HasSuffix(name, "_banner"):
+		return "minecraft:standing_banner"
+	case strings.HasSuffix(name, "_bed"):
+		return "minecraft:bed"
+	case strings.HasSuffix(name, "_wall_sign"):
+		return name
+	case strings.HasSuffix(name, "_sign") && !strings.HasSuffix(name, "_hanging_sign") && name != "minecraft:standing_sign" && name != "minecraft:wall_sign":
+		return strings.TrimSuffix(name, "_sign") + "_standing_sign"
+	}
+	return name
+}
+
+// convertProperty converts a single Java Edition property of a block with the Bedrock Edition name passed and
+// stores the result in properties.
+func convertProperty(name, k, v string, properties map[string]any) {
+	switch k {
+	case "axis":
+		properties["pillar_axis"] = v
+	case "facing":
+		convertFacing(name, v, properties)
+	case "half":
+		switch v {
+		case "top":
+			properties["upside_down_bit"] = true
+		case "upper":
+			properties["upper_block_bit"] = true
+		}
+	case "type":
+		if v == "top" {
+			properties["minecraft:vertical_half"] = "top"
+		}
+	case "open":
+		properties["open_bit"] = v == "true"
+	case "hinge":
+		properties["door_hinge_bit"] = v == "right"
+	case "in_wall":
+		properties["in_wall_bit"] = v == "true"
+	case "persistent":
+		properties["persistent_bit"] = v == "true"
+	case "part":
+		properties["head_piece_bit"] = v == "head"
+	case "occupied":
+		properties["occupied_bit"] = v == "true"
+	case "eye":
+		properties["end_portal_eye_bit"] = v == "true"
+	case "powered":
+		if strings.HasSuffix(name, "_button") {
+			properties["button_pressed_bit"] = v == "true"
+		} else if name == "minecraft:lever" {
+			properties["open_bit"] = v == "true"
+		}
+	case "level":
+		if name == "minecraft:water" || name == "minecraft:lava" {
+			properties["liquid_depth"] = parseInt(v)
+		}
+	case "layers":
+		properties["height"] = parseInt(v) - 1
+	case "delay":
+		properties["repeater_delay"] = parseInt(v) - 1
+	case "rotation":
+		properties["ground_sign_direction"] = parseInt(v)
+	case "power":
+		properties["redstone_signal"] = parseInt(v)
+	case "age":
+		switch name {
+		case "minecraft:wheat", "minecraft:carrots", "minecraft:potatoes", "minecraft:beetroot", "minecraft:melon_stem", "minecraft:pumpkin_stem":
+			properties["growth"] = parseInt(v)
+		default:
+			properties["age"] = parseInt(v)
+		}
+	case "north", "east", "south", "west":
+		switch v {
+		case "none", "low", "tall":
+			if v == "low" {
+				v = "short"
+			}
+			properties["wall_connection_type_"+k] = v
+		}
+	case "up":
+		properties["wall_post_bit"] = v == "true"
+	default:
+		// Properties of which the name is the same in both editions are copied directly. Properties that do
+		// not exist in Bedrock Edition are ignored when the block is looked up.
+		switch v {
+		case "true", "false":
+			properties[k] = v == "true"
+		default:
+			if n, err := strconv.Atoi(v); err == nil {
+				properties[k] = int32(n)
+			} else {
+				properties[k] = v
+			}
+		}
+	}
+}
+
+// convertFacing converts the Java Edition 'facing' property of a block with the Bedrock Edition name passed.
+func convertFacing(name, v string, properties map[string]any) {
+	switch {
+	case strings.HasSuffix(name, "_stairs"), strings.HasSuffix(name, "trapdoor"):
+		properties["weirdo_direction"] = directionIndex(v, "east", "west", "south", "north")
+		properties["direction"] = directionIndex(v, "east", "west", "south", "north")
+	case strings.HasSuffix(name, "_door"):
+		properties["direction"] = directionIndex(v, "east", "south", "west", "north")
+	case strings.HasSuffix(name, "fence_gate"), name == "minecraft:bed":
+		properties["direction"] = directionIndex(v, "south", "west", "north", "east")
+	case strings.HasSuffix(name, "torch"):
+		// Wall torches face away from the block they are attached to in Java Edition, but towards it in Bedrock
+		// Edition.
+		properties["torch_facing_direction"] = map[string]string{"north": "south", "south": "north", "east": "west", "west": "east"}[v]
+	default:
+		properties["facing_direction"] = directionIndex(v, "down", "up", "north", "south", "west", "east")
+		properties["minecraft:cardinal_direction"] = v
+	}
+}
+
+// directionIndex returns the index of v in the directions passed as an int32.
+func directionIndex(v string, directions ...string) int32 {
+	for i, d := range directions {
+		if d == v {
+			return int32(i)
+		}
+	}
+	return 0
+}
+
+// parseInt parses v as an int32, returning 0 if v is not a valid integer.
+func parseInt(v string) int32 {
+	n, _ := strconv.Atoi(v)
+	return int32(n)
+}
+
+var (
+	// states holds all block states registered in the world package, indexed by their name. The first state
+	// of every block holds the default values of its properties.
+	states     map[string][]map[string]any
+	statesOnce sync.Once
+)
+
+// blockStates returns the properties of all block states known to the world package, indexed by the names of the
+// blocks.
+func blockStates() map[string][]map[string]any {
+	statesOnce.Do(func() {
+		states = make(map[string][]map[string]any)
+		for rid := uint32(0); ; rid++ {
+			b, ok := world.BlockByRuntimeID(rid)
+			if !ok {
+				break
+			}
+			name, properties := b.EncodeBlock()
+			states[name] = append(states[name], properties)
+		}
+	})
+	return states
+}
+
+// resolve returns the block with the name passed of which the properties best match the properties passed.
+// Properties that the block does not have are ignored, and properties not passed are preferably set to their
+// default values. If no block with the name exists or no state of it matches, false is returned.
+func resolve(name string, properties map[string]any) (world.Block, bool) {
+	candidates := blockStates()[name]
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	def := candidates[0]
+	best, bestScore := -1, -1
+	for i, candidate := range candidates {
+		matches := true
+		for k, v := range properties {
+			if cv, ok := candidate[k]; ok && !equalValue(cv, v) {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		score := 0
+		for k, v := range candidate {
+			if equalValue(def[k], v) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best == -1 {
+		return nil, false
+	}
+	return world.BlockByName(name, candidates[best])
+}
+
+// equalValue checks if two property values are equal. Booleans and integers of different types are considered
+// equal if they hold the same value, as Bedrock Edition uses both bytes and booleans for boolean properties.
+func equalValue(a, b any) bool {
+	return normalise(a) == normalise(b)
+}
+
+// normalise converts booleans and integers of any type to an int64.
+func normalise(v any) any {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return int64(1)
+		}
+		return int64(0)
+	case uint8:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	}
+	return v
+}
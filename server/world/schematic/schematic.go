@@ -0,0 +1,273 @@
+package schematic
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Schematic is a world.Structure read from a Sponge .schem file or a legacy MCEdit .schematic file. The Java
+// Edition blocks in the schematic are converted to Bedrock Edition blocks using a Mapping. Blocks that could not be
+// mapped are left out of the Schematic and reported by Unmapped.
+type Schematic struct {
+	size   [3]int
+	offset cube.Pos
+
+	blocks   []world.Block
+	liquids  []world.Liquid
+	unmapped map[string]int
+}
+
+// ReadFile reads a Schematic from the .schem or .schematic file at the path passed. The Mapping passed is used to
+// convert the blocks of the schematic. If m is nil, the DefaultMapping is used.
+func ReadFile(path string, m Mapping) (*Schematic, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	defer f.Close()
+	return Read(f, m)
+}
+
+// Read reads a Schematic from the io.Reader passed. Sponge schematics of version 1, 2 and 3, and legacy MCEdit
+// schematics are supported, both compressed with gzip and uncompressed. The Mapping passed is used to convert the
+// blocks of the schematic. If m is nil, the DefaultMapping is used.
+// Block entity data and entities in the schematic are not read, as their Java Edition NBT is not compatible with
+// Bedrock Edition.
+func Read(r io.Reader, m Mapping) (*Schematic, error) {
+	if m == nil {
+		m = DefaultMapping
+	}
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("read schematic: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	} else {
+		r = br
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	var root map[string]any
+	if err := nbt.NewDecoderWithEncoding(bytes.NewBuffer(data), nbt.BigEndian).Decode(&root); err != nil {
+		return nil, fmt.Errorf("read schematic: decode nbt: %w", err)
+	}
+	if nested, ok := root["Schematic"].(map[string]any); ok {
+		// Version 3 of the Sponge format nests all data in a compound named Schematic.
+		root = nested
+	}
+
+	width, height, length := int(uint16(nbtconv.IntFromAny(root["Width"]))), int(uint16(nbtconv.IntFromAny(root["Height"]))), int(uint16(nbtconv.IntFromAny(root["Length"])))
+	s := &Schematic{
+		size:     [3]int{width, height, length},
+		blocks:   make([]world.Block, width*height*length),
+		liquids:  make([]world.Liquid, width*height*length),
+		unmapped: map[string]int{},
+	}
+	if offset := nbtconv.Int32sFromAny(root["Offset"]); len(offset) == 3 {
+		s.offset = cube.Pos{int(offset[0]), int(offset[1]), int(offset[2])}
+	}
+
+	if _, ok := root["Version"]; ok {
+		err = s.readSponge(root, m)
+	} else {
+		err = s.readLegacy(root, m)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	return s, nil
+}
+
+// readSponge reads the blocks of a Sponge schematic.
+func (s *Schematic) readSponge(root map[string]any, m Mapping) error {
+	palette, _ := root["Palette"].(map[string]any)
+	blockData := nbtconv.BytesFromAny(root["BlockData"])
+	if version := nbtconv.IntFromAny(root["Version"]); version >= 3 {
+		blocks, _ := root["Blocks"].(map[string]any)
+		palette, _ = blocks["Palette"].(map[string]any)
+		blockData = nbtconv.BytesFromAny(blocks["Data"])
+	} else if version < 1 {
+		return fmt.Errorf("unsupported sponge schematic version %v", version)
+	}
+	if palette == nil {
+		return errors.New("missing block palette")
+	}
+
+	entries := make(map[int]paletteEntry, len(palette))
+	for str, index := range palette {
+		state, err := ParseState(str)
+		if err != nil {
+			return err
+		}
+		entries[nbtconv.IntFromAny(index)] = s.mapState(state, m)
+	}
+
+	n := len(s.blocks)
+	for i, offset := 0, 0; i < n; i++ {
+		// The palette indices of the blocks are stored as varints, in the order of x, z and then y.
+		index, read := readVarInt(blockData[offset:])
+		if read == 0 {
+			return fmt.Errorf("block data too short: expected %v blocks, got %v", n, i)
+		}
+		offset += read
+		x, z, y := i%s.size[0], (i/s.size[0])%s.size[2], i/(s.size[0]*s.size[2])
+		s.set(x, y, z, entries[index])
+	}
+	return nil
+}
+
+// readLegacy reads the blocks of a legacy MCEdit schematic, which stores blocks by their legacy numeric IDs.
+func (s *Schematic) readLegacy(root map[string]any, m Mapping) error {
+	if materials, ok := root["Materials"].(string); ok && materials != "Alpha" {
+		return fmt.Errorf("unsupported schematic materials %v", materials)
+	}
+	ids, meta, add := nbtconv.BytesFromAny(root["Blocks"]), nbtconv.BytesFromAny(root["Data"]), nbtconv.BytesFromAny(root["AddBlocks"])
+	n := len(s.blocks)
+	if len(ids) != n || len(meta) != n {
+		return fmt.Errorf("expected %v blocks, got %v block IDs and %v data values", n, len(ids), len(meta))
+	}
+
+	entries := map[uint32]paletteEntry{}
+	for i := 0; i < n; i++ {
+		id := uint16(ids[i])
+		if i>>1 < len(add) {
+			// AddBlocks holds the upper four bits of the block IDs, two IDs per byte.
+			if i&1 == 0 {
+				id |= uint16(add[i>>1]>>4) << 8
+			} else {
+				id |= uint16(add[i>>1]&0xf) << 8
+			}
+		}
+		key := uint32(id)<<8 | uint32(meta[i]&0xf)
+		entry, ok := entries[key]
+		if !ok {
+			if state, known := LegacyState(id, meta[i]&0xf); known {
+				entry = s.mapState(state, m)
+			} else {
+				entry = paletteEntry{unmapped: "legacy:" + strconv.Itoa(int(id)) + ":" + strconv.Itoa(int(meta[i]&0xf))}
+			}
+			entries[key] = entry
+		}
+		x, z, y := i%s.size[0], (i/s.size[0])%s.size[2], i/(s.size[0]*s.size[2])
+		s.set(x, y, z, entry)
+	}
+	return nil
+}
+
+// paletteEntry is a Java Edition block state converted to a Bedrock Edition block and liquid. If the state
+// could not be converted, unmapped holds the state.
+type paletteEntry struct {
+	b        world.Block
+	liq      world.Liquid
+	unmapped string
+}
+
+// mapState converts a Java Edition block state to a paletteEntry using the Mapping passed.
+func (s *Schematic) mapState(state State, m Mapping) paletteEntry {
+	b, liq, ok := Convert(state, m)
+	if !ok {
+		return paletteEntry{unmapped: state.String()}
+	}
+	return paletteEntry{b: b, liq: liq}
+}
+
+// Convert converts a Java Edition block State to a Bedrock Edition block using the Mapping passed. If the State
+// is waterlogged, water is returned as the liquid of the block. If m is nil, the DefaultMapping is used. Convert
+// returns false if the Mapping cannot map the State or if the block it maps to does not exist.
+func Convert(state State, m Mapping) (world.Block, world.Liquid, bool) {
+	if m == nil {
+		m = DefaultMapping
+	}
+	name, properties, ok := m.Map(state)
+	if !ok {
+		return nil, nil, false
+	}
+	b, ok := resolve(name, properties)
+	if !ok {
+		return nil, nil, false
+	}
+	var liq world.Liquid
+	if _, ok := b.(world.Liquid); !ok && waterlogged(state) {
+		water, _ := resolve("minecraft:water", map[string]any{"liquid_depth": int32(0)})
+		liq, _ = water.(world.Liquid)
+	}
+	return b, liq, true
+}
+
+// waterlogged checks if a Java Edition block state is waterlogged. Some blocks, such as seagrass, are always
+// waterlogged in Java Edition.
+func waterlogged(state State) bool {
+	switch state.Name {
+	case "minecraft:seagrass", "minecraft:tall_seagrass", "minecraft:kelp", "minecraft:kelp_plant", "minecraft:bubble_column":
+		return true
+	}
+	return state.Properties["waterlogged"] == "true"
+}
+
+// set sets a paletteEntry at a position in the Schematic.
+func (s *Schematic) set(x, y, z int, entry paletteEntry) {
+	if entry.unmapped != "" {
+		s.unmapped[entry.unmapped]++
+		return
+	}
+	i := s.index(x, y, z)
+	s.blocks[i], s.liquids[i] = entry.b, entry.liq
+}
+
+// Dimensions returns the width, height and length of the Schematic.
+func (s *Schematic) Dimensions() [3]int {
+	return s.size
+}
+
+// Offset returns the offset of the Schematic, which is the position of the lower corner of the Schematic relative
+// to the position it was copied from in Java Edition.
+func (s *Schematic) Offset() cube.Pos {
+	return s.offset
+}
+
+// At returns the block and liquid at a position in the Schematic. Positions of which the block could not be mapped
+// return nil, so that the block already present in the World remains.
+func (s *Schematic) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	i := s.index(x, y, z)
+	return s.blocks[i], s.liquids[i]
+}
+
+// Unmapped returns the Java Edition block states in the Schematic that could not be mapped to Bedrock Edition
+// blocks, together with the amount of blocks with that state. Legacy block IDs that are unknown are returned in
+// the form 'legacy:id:metadata'.
+func (s *Schematic) Unmapped() map[string]int {
+	return s.unmapped
+}
+
+// index returns the index of a position in the blocks and liquids of the Schematic.
+func (s *Schematic) index(x, y, z int) int {
+	return (x*s.size[1]+y)*s.size[2] + z
+}
+
+// readVarInt reads an unsigned varint from b, returning the value and the amount of bytes read. If b does not
+// hold a complete varint, 0 bytes are returned as read.
+func readVarInt(b []byte) (int, int) {
+	var v int
+	for i := 0; i < len(b) && i < 5; i++ {
+		v |= int(b[i]&0x7f) << (7 * i)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,73 @@
+package schematic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// State is a Java Edition block state, such as found in the palette of a Sponge schematic. It consists of the
+// namespaced name of the block and its properties.
+type State struct {
+	// Name is the namespaced name of the block, such as 'minecraft:oak_stairs'.
+	Name string
+	// Properties holds the properties of the block state, such as 'facing' and 'half' for stairs. The values of
+	// the properties are always strings.
+	Properties map[string]string
+}
+
+// ParseState parses a Java Edition block state in the form 'minecraft:oak_stairs[facing=east,half=top]'. If no
+// namespace is present in the name, the 'minecraft' namespace is assumed.
+func ParseState(s string) (State, error) {
+	state := State{Properties: map[string]string{}}
+	name, properties, found := strings.Cut(s, "[")
+	if found {
+		var ok bool
+		if properties, ok = strings.CutSuffix(properties, "]"); !ok {
+			return State{}, fmt.Errorf("parse state %q: missing closing bracket", s)
+		}
+		for _, property := range strings.Split(properties, ",") {
+			if property == "" {
+				continue
+			}
+			k, v, ok := strings.Cut(property, "=")
+			if !ok {
+				return State{}, fmt.Errorf("parse state %q: invalid property %q", s, property)
+			}
+			state.Properties[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return State{}, fmt.Errorf("parse state %q: missing name", s)
+	}
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	state.Name = name
+	return state, nil
+}
+
+// String returns the State in the same form as accepted by ParseState, with its properties sorted by name.
+func (s State) String() string {
+	if len(s.Properties) == 0 {
+		return s.Name
+	}
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(s.Name)
+	b.WriteByte('[')
+	for i, k := range keys {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k + "=" + s.Properties[k])
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -3,6 +3,7 @@ package world
 import (
 	"github.com/df-mc/atomic"
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"math/rand"
 	"sync"
 )
 
@@ -14,6 +15,9 @@ type Settings struct {
 
 	// Name is the display name of the World.
 	Name string
+	// Seed is the seed of the World. Generators use the seed to produce the same terrain every time a World is
+	// loaded.
+	Seed int64
 	// Spawn is the spawn position of the World. New players that join the world will be spawned here.
 	Spawn cube.Pos
 	// Time is the current time of the World. It advances every tick if TimeCycle is set to true.
@@ -41,12 +45,18 @@ type Settings struct {
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// GameRules holds the values of the game rules of the World that were changed, indexed by the name of the
+	// GameRule. Values are either of the type bool or int32. Game rules not present in the map have their default
+	// value. The values of GameRuleDoDaylightCycle and GameRuleDoWeatherCycle are stored in TimeCycle and
+	// WeatherCycle instead.
+	GameRules map[string]any
 }
 
 // defaultSettings returns the default Settings for a new World.
 func defaultSettings() *Settings {
 	return &Settings{
 		Name:            "World",
+		Seed:            rand.Int63(),
 		DefaultGameMode: GameModeSurvival,
 		Difficulty:      DifficultyNormal,
 		TimeCycle:       true,
@@ -0,0 +1,140 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"math"
+)
+
+// Sleeper represents an entity that is able to sleep in a bed, such as a player. Enough Sleepers in a World
+// sleeping at the same time skip the night, as specified by GameRulePlayersSleepingPercentage.
+type Sleeper interface {
+	Entity
+	// Sleeping returns the position of the bed that the Sleeper is sleeping in. False is returned if the Sleeper is
+	// not currently sleeping.
+	Sleeping() (cube.Pos, bool)
+	// Wake makes the Sleeper wake up if it is sleeping.
+	Wake()
+}
+
+const (
+	// dayLength is the amount of ticks that a full day and night cycle lasts.
+	dayLength = 24000
+	// sleepStart and sleepEnd are the times of the day between which Sleepers are able to sleep if it is not
+	// thundering.
+	sleepStart, sleepEnd = 12542, 23460
+	// sleepDuration is the amount of ticks that enough Sleepers must be sleeping for to skip the night.
+	sleepDuration = 100
+)
+
+// CanSleepAt checks if a Sleeper is able to sleep in a bed at the position passed. This is the case during the night
+// or if it is thundering at the position.
+func (w *World) CanSleepAt(pos cube.Pos) bool {
+	if w == nil || !w.conf.Dim.TimeCycle() {
+		return false
+	}
+	if t := w.Time() % dayLength; t >= sleepStart && t < sleepEnd {
+		return true
+	}
+	return w.ThunderingAt(pos)
+}
+
+// StartSleeping marks the Sleeper passed as sleeping in the World. A Sleeper should call StartSleeping when it gets
+// into a bed, so that the night is skipped once enough Sleepers in the World are sleeping.
+func (w *World) StartSleeping(s Sleeper) {
+	if w == nil {
+		return
+	}
+	w.sleepMu.Lock()
+	defer w.sleepMu.Unlock()
+	w.sleeping[s] = struct{}{}
+}
+
+// StopSleeping marks the Sleeper passed as no longer sleeping in the World. A Sleeper should call StopSleeping when it
+// wakes up.
+func (w *World) StopSleeping(s Sleeper) {
+	if w == nil {
+		return
+	}
+	w.sleepMu.Lock()
+	defer w.sleepMu.Unlock()
+	delete(w.sleeping, s)
+}
+
+// tickSleeping checks if enough Sleepers in the World have been sleeping for long enough to skip the night. If so,
+// the time is set to the start of the next day, the weather is cleared and all Sleepers are woken up. While any
+// Sleeper is sleeping, the amount of sleeping and required Sleepers is sent to all viewers when it changes. Once the
+// last Sleeper wakes up, viewers are sent that no Sleepers are sleeping anymore.
+func (t ticker) tickSleeping(viewers []Viewer) {
+	if !t.w.conf.Dim.TimeCycle() {
+		return
+	}
+	sleeping := t.sleepers()
+	if len(sleeping) == 0 {
+		if t.w.sleepers != 0 {
+			t.w.sleepers = 0
+			for _, viewer := range viewers {
+				viewer.ViewSleepingPlayers(0, t.w.requiredSleepers)
+			}
+		}
+		t.w.sleepTicks = 0
+		return
+	}
+
+	// The amount of Sleepers required to skip the night depends on all Sleepers in the World, so they are only
+	// counted while any of them are sleeping.
+	var total int
+	for _, e := range t.w.Entities() {
+		if _, ok := e.(Sleeper); !ok {
+			continue
+		}
+		if g, ok := e.(interface{ GameMode() GameMode }); ok && !g.GameMode().Visible() {
+			// Spectators are not taken into account when skipping the night.
+			continue
+		}
+		total++
+	}
+	percentage := t.w.IntGameRule(GameRulePlayersSleepingPercentage)
+	required := max(1, int(math.Ceil(float64(total)*float64(percentage)/100)))
+	if len(sleeping) != t.w.sleepers || required != t.w.requiredSleepers {
+		t.w.sleepers, t.w.requiredSleepers = len(sleeping), required
+		for _, viewer := range viewers {
+			viewer.ViewSleepingPlayers(len(sleeping), required)
+		}
+	}
+
+	if percentage > 100 || len(sleeping) < required {
+		t.w.sleepTicks = 0
+		return
+	}
+	if t.w.sleepTicks++; t.w.sleepTicks < sleepDuration {
+		return
+	}
+	t.w.sleepTicks = 0
+
+	if t.w.BoolGameRule(GameRuleDoDaylightCycle) {
+		tim := t.w.Time()
+		t.w.SetTime(tim + dayLength - tim%dayLength)
+	}
+	if t.w.BoolGameRule(GameRuleDoWeatherCycle) {
+		t.w.StopRaining()
+	}
+	for _, s := range sleeping {
+		s.Wake()
+	}
+}
+
+// sleepers returns the Sleepers that are currently sleeping in the World. Sleepers that woke up or left the World
+// without calling World.StopSleeping are no longer tracked.
+func (t ticker) sleepers() []Sleeper {
+	t.w.sleepMu.Lock()
+	defer t.w.sleepMu.Unlock()
+	sleeping := make([]Sleeper, 0, len(t.w.sleeping))
+	for s := range t.w.sleeping {
+		if _, ok := s.Sleeping(); !ok || s.World() != t.w {
+			delete(t.w.sleeping, s)
+			continue
+		}
+		sleeping = append(sleeping, s)
+	}
+	return sleeping
+}
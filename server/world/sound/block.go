@@ -51,6 +51,12 @@ type EnderChestOpen struct{ sound }
 // EnderChestClose is played when a ender chest is closed.
 type EnderChestClose struct{ sound }
 
+// EnderEyePlaced is played when an eye of ender is placed in an end portal frame.
+type EnderEyePlaced struct{ sound }
+
+// EndPortalCreated is played when an end portal is created by completing an end portal frame.
+type EndPortalCreated struct{ sound }
+
 // BarrelOpen is played when a barrel is opened.
 type BarrelOpen struct{ sound }
 
@@ -120,6 +126,22 @@ type DoorCrash struct{ sound }
 // Click is a clicking sound.
 type Click struct{ sound }
 
+// ClickFail is a clicking sound played when a dispenser or dropper fails to dispense an item.
+type ClickFail struct{ sound }
+
+// PowerOn is a sound played when a redstone component, such as a lever, a button or a pressure plate, is turned on.
+type PowerOn struct{ sound }
+
+// PowerOff is a sound played when a redstone component, such as a lever, a button or a pressure plate, is turned
+// off.
+type PowerOff struct{ sound }
+
+// PistonExtend is a sound played when a piston extends its arm.
+type PistonExtend struct{ sound }
+
+// PistonRetract is a sound played when a piston retracts its arm.
+type PistonRetract struct{ sound }
+
 // Ignite is a sound played when using a flint & steel.
 type Ignite struct{ sound }
 
@@ -68,6 +68,7 @@ func (t ticker) tick() {
 		t.w.tickLightning()
 	}
 
+	t.tickSleeping(viewers)
 	t.tickEntities(tick)
 	t.tickBlocksRandomly(loaders, tick)
 	t.tickScheduledBlocks(tick)
@@ -98,22 +99,44 @@ func (t ticker) tickScheduledBlocks(tick int64) {
 	}
 }
 
+// maxNeighbourUpdates is the maximum amount of neighbour updates performed in a single tick. Updates that remain
+// once this amount is reached are performed in the next tick.
+const maxNeighbourUpdates = 65536
+
 // performNeighbourUpdates performs all block updates that came as a result of a neighbouring block being changed.
+// Updates are performed in the order in which they were queued. Updates queued while performing updates, such as
+// those caused by redstone power spreading, are performed in the same tick, so that chains of updates do not take
+// multiple ticks to complete. Duplicate updates queued within the same batch are only performed once.
 func (t ticker) performNeighbourUpdates() {
-	t.w.updateMu.Lock()
-	positions := slices.Clone(t.w.neighbourUpdates)
-	t.w.neighbourUpdates = t.w.neighbourUpdates[:0]
-	t.w.updateMu.Unlock()
-
-	for _, update := range positions {
-		pos, changedNeighbour := update.pos, update.neighbour
-		if ticker, ok := t.w.Block(pos).(NeighbourUpdateTicker); ok {
-			ticker.NeighbourUpdateTick(pos, changedNeighbour, t.w)
+	performed := 0
+	for performed < maxNeighbourUpdates {
+		t.w.updateMu.Lock()
+		n := min(len(t.w.neighbourUpdates), maxNeighbourUpdates-performed)
+		positions := slices.Clone(t.w.neighbourUpdates[:n])
+		t.w.neighbourUpdates = append(t.w.neighbourUpdates[:0], t.w.neighbourUpdates[n:]...)
+		t.w.updateMu.Unlock()
+
+		if n == 0 {
+			return
 		}
-		if liquid, ok := t.w.additionalLiquid(pos); ok {
-			if ticker, ok := liquid.(NeighbourUpdateTicker); ok {
+		performed += n
+
+		done := make(map[neighbourUpdate]struct{}, n)
+		for _, update := range positions {
+			if _, ok := done[update]; ok {
+				continue
+			}
+			done[update] = struct{}{}
+
+			pos, changedNeighbour := update.pos, update.neighbour
+			if ticker, ok := t.w.Block(pos).(NeighbourUpdateTicker); ok {
 				ticker.NeighbourUpdateTick(pos, changedNeighbour, t.w)
 			}
+			if liquid, ok := t.w.additionalLiquid(pos); ok {
+				if ticker, ok := liquid.(NeighbourUpdateTicker); ok {
+					ticker.NeighbourUpdateTick(pos, changedNeighbour, t.w)
+				}
+			}
 		}
 	}
 }
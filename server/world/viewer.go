@@ -47,6 +47,9 @@ type Viewer interface {
 	// ViewEntityState views the current state of an entity. It is called whenever an entity changes its
 	// physical appearance, for example when sprinting.
 	ViewEntityState(e Entity)
+	// ViewEntityRide views an entity starting or stopping to ride another entity, such as a minecart. Riding is
+	// true if the rider started riding the vehicle, and false if it stopped riding it.
+	ViewEntityRide(rider, vehicle Entity, riding bool)
 	// ViewEntityAnimation starts viewing an animation performed by an entity. The animation has to be from a resource pack.
 	ViewEntityAnimation(e Entity, animationName string)
 	// ViewParticle views a particle spawned at a given position in the world. It is called when a particle,
@@ -68,6 +71,12 @@ type Viewer interface {
 	ViewWorldSpawn(pos cube.Pos)
 	// ViewWeather views the weather of the world, including rain and thunder.
 	ViewWeather(raining, thunder bool)
+	// ViewSleepingPlayers views the amount of players sleeping in the world and the amount of players that must be
+	// sleeping to skip the night.
+	ViewSleepingPlayers(sleeping, required int)
+	// ViewGameRules views the game rules of the world passed. The map holds the values of the game rules, indexed by
+	// their names. It is called when a game rule is changed or when the viewer starts viewing the world.
+	ViewGameRules(rules map[string]any)
 }
 
 // NopViewer is a Viewer implementation that does not implement any behaviour. It may be embedded by other structs to
@@ -89,6 +98,7 @@ func (NopViewer) ViewEntityItems(Entity)                                     {}
 func (NopViewer) ViewEntityArmour(Entity)                                    {}
 func (NopViewer) ViewEntityAction(Entity, EntityAction)                      {}
 func (NopViewer) ViewEntityState(Entity)                                     {}
+func (NopViewer) ViewEntityRide(Entity, Entity, bool)                        {}
 func (NopViewer) ViewEntityAnimation(Entity, string)                         {}
 func (NopViewer) ViewParticle(mgl64.Vec3, Particle)                          {}
 func (NopViewer) ViewSound(mgl64.Vec3, Sound)                                {}
@@ -98,5 +108,7 @@ func (NopViewer) ViewEmote(Entity, uuid.UUID)                                {}
 func (NopViewer) ViewSkin(Entity)                                            {}
 func (NopViewer) ViewWorldSpawn(cube.Pos)                                    {}
 func (NopViewer) ViewWeather(bool, bool)                                     {}
+func (NopViewer) ViewSleepingPlayers(int, int)                               {}
+func (NopViewer) ViewGameRules(map[string]any)                               {}
 func (NopViewer) ViewFurnaceUpdate(time.Duration, time.Duration, time.Duration, time.Duration, time.Duration, time.Duration) {
 }
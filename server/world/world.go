@@ -2,6 +2,7 @@ package world
 
 import (
 	"errors"
+	"fmt"
 	"github.com/df-mc/goleveldb/leveldb"
 	"math/rand"
 	"sync"
@@ -15,6 +16,7 @@ import (
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
 	"golang.org/x/exp/maps"
+	"io"
 	"slices"
 )
 
@@ -34,6 +36,7 @@ type World struct {
 
 	set     *Settings
 	handler atomic.Value[Handler]
+	border  *Border
 
 	weather
 	ticker
@@ -65,6 +68,14 @@ type World struct {
 
 	viewersMu sync.Mutex
 	viewers   map[*Loader]Viewer
+
+	sleepMu sync.Mutex
+	// sleeping holds the Sleepers that are currently sleeping in the World, as marked using StartSleeping.
+	sleeping map[Sleeper]struct{}
+	// sleepTicks is the amount of ticks that enough Sleepers have been sleeping for to skip the night. sleepers and
+	// requiredSleepers hold the amount of sleeping and required Sleepers last sent to viewers. These fields are only
+	// accessed while ticking the World.
+	sleepTicks, sleepers, requiredSleepers int
 }
 
 // New creates a new initialised world. The world may be used right away, but it will not be saved or loaded
@@ -84,6 +95,14 @@ func (w *World) Name() string {
 	return w.set.Name
 }
 
+// Seed returns the seed of the World as stored in its Settings. Generators use the seed to produce the same terrain
+// every time the World is loaded.
+func (w *World) Seed() int64 {
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Seed
+}
+
 // Dimension returns the Dimension assigned to the World in world.New. The sky colour and behaviour of a variety of
 // world features differ based on the Dimension assigned to a World.
 func (w *World) Dimension() Dimension {
@@ -101,6 +120,14 @@ func (w *World) Range() cube.Range {
 	return w.ra
 }
 
+// Border returns the world Border of the World. The Border may be changed using its methods.
+func (w *World) Border() *Border {
+	if w == nil {
+		return newBorder()
+	}
+	return w.border
+}
+
 // EntityRegistry returns the EntityRegistry that was passed to the World's
 // Config upon construction.
 func (w *World) EntityRegistry() EntityRegistry {
@@ -188,6 +215,89 @@ func (w *World) HighestBlock(x, z int) int {
 	return int(c.HighestBlock(uint8(x), uint8(z)))
 }
 
+// FindBlocks returns the positions of all blocks between the cube.Pos min and max, inclusive, for which the function
+// f returns true. Chunks that are not yet loaded are loaded, or generated if they could not be found in the world
+// save. Sub chunks that do not contain any matching block are skipped entirely, so FindBlocks is much faster than
+// calling World.Block for every position in the area. Note that f is not called with block entities that hold data.
+func (w *World) FindBlocks(min, max cube.Pos, f func(b Block) bool) []cube.Pos {
+	return w.findBlocks(min, max, f, false)
+}
+
+// FindLoadedBlocks works like FindBlocks, except that only chunks that are currently loaded are searched. Chunks that
+// are not loaded are skipped rather than loaded or generated, which makes FindLoadedBlocks suitable for searching
+// large areas.
+func (w *World) FindLoadedBlocks(min, max cube.Pos, f func(b Block) bool) []cube.Pos {
+	return w.findBlocks(min, max, f, true)
+}
+
+// findBlocks returns the positions of all blocks between min and max for which f returns true. If loadedOnly is
+// true, chunks that are not loaded are skipped.
+func (w *World) findBlocks(min, max cube.Pos, f func(b Block) bool, loadedOnly bool) []cube.Pos {
+	if w == nil {
+		return nil
+	}
+	var (
+		matches = make(map[uint32]bool)
+		found   []cube.Pos
+	)
+	minChunk, maxChunk := chunkPosFromBlockPos(min), chunkPosFromBlockPos(max)
+	for cx := minChunk[0]; cx <= maxChunk[0]; cx++ {
+		for cz := minChunk[1]; cz <= maxChunk[1]; cz++ {
+			pos := ChunkPos{cx, cz}
+			var c *Column
+			if loadedOnly {
+				w.chunkMu.Lock()
+				c = w.chunks[pos]
+				w.chunkMu.Unlock()
+				if c == nil {
+					continue
+				}
+				c.Lock()
+			} else {
+				c = w.chunk(pos)
+			}
+			for i, sub := range c.Sub() {
+				baseY := int(c.SubY(int16(i)))
+				if baseY+15 < min[1] || baseY > max[1] {
+					continue
+				}
+				palette := sub.Layer(0).Palette()
+				contains := false
+				for j := 0; j < palette.Len(); j++ {
+					rid := palette.Value(uint16(j))
+					match, ok := matches[rid]
+					if !ok {
+						b, _ := BlockByRuntimeID(rid)
+						match = f(b)
+						matches[rid] = match
+					}
+					// Every entry of the palette is checked, so that the blocks of the sub chunk can be looked up in
+					// matches below.
+					contains = contains || match
+				}
+				if !contains {
+					continue
+				}
+				for x := 0; x < 16; x++ {
+					for z := 0; z < 16; z++ {
+						bx, bz := int(cx)<<4+x, int(cz)<<4+z
+						if bx < min[0] || bx > max[0] || bz < min[2] || bz > max[2] {
+							continue
+						}
+						for y := 0; y < 16; y++ {
+							if by := baseY + y; by >= min[1] && by <= max[1] && matches[sub.Block(byte(x), byte(y), byte(z), 0)] {
+								found = append(found, cube.Pos{bx, by, bz})
+							}
+						}
+					}
+				}
+			}
+			c.Unlock()
+		}
+	}
+	return found
+}
+
 // highestObstructingBlock returns the highest block in the world at a given x and z that has at least a solid top or
 // bottom face.
 func (w *World) highestObstructingBlock(x, z int) int {
@@ -241,10 +351,7 @@ func (w *World) SetBlock(pos cube.Pos, b Block, opts *SetOpts) {
 
 	rid := BlockRuntimeID(b)
 
-	var before uint32
-	if rid != airRID && !opts.DisableLiquidDisplacement {
-		before = c.Block(x, y, z, 0)
-	}
+	before := c.Block(x, y, z, 0)
 
 	c.modified = true
 	c.SetBlock(x, y, z, 0, rid)
@@ -290,6 +397,10 @@ func (w *World) SetBlock(pos cube.Pos, b Block, opts *SetOpts) {
 
 	if !opts.DisableBlockUpdates {
 		w.doBlockUpdatesAround(pos)
+		if conductorBlocks[rid] || conductorBlocks[before] {
+			// The block may have powered the blocks around it, so the neighbours of those blocks must be updated too.
+			w.doRedstoneUpdatesAround(pos)
+		}
 	}
 }
 
@@ -1004,6 +1115,86 @@ func (w *World) PortalDestination(dim Dimension) *World {
 	return w
 }
 
+// Save saves all chunks currently loaded in the World and the settings of the World to its Provider. Unlike Close,
+// Save keeps the chunks loaded and the World running. Save does nothing if the World is read-only or closed.
+func (w *World) Save() {
+	if w == nil || w.conf.ReadOnly {
+		return
+	}
+	select {
+	case <-w.closing:
+		return
+	default:
+	}
+	w.chunkMu.Lock()
+	toSave := maps.Clone(w.chunks)
+	w.chunkMu.Unlock()
+
+	for pos, c := range toSave {
+		w.flushChunk(pos, c)
+	}
+	w.provider().SaveSettings(w.set)
+}
+
+// Backup writes a backup of the World to wr in the form of a zip archive, such as a .mcworld file, while the World
+// keeps running. All chunks are first stored to the Provider, after which the chunks modified in the meantime are
+// stored again and a snapshot of the Provider is taken. The snapshot is then written to wr. The World is never
+// blocked for longer than it takes to find the chunks that were modified. An error is returned if the Provider of
+// the World does not implement Snapshotter.
+func (w *World) Backup(wr io.Writer) error {
+	if w == nil {
+		return fmt.Errorf("backup world: world is nil")
+	}
+	p, ok := w.provider().(Snapshotter)
+	if !ok {
+		return fmt.Errorf("backup world: provider %T does not support snapshots", w.provider())
+	}
+	w.Save()
+
+	if !w.conf.ReadOnly {
+		// Store the chunks that were modified while saving, so that the snapshot holds the latest state of the World.
+		w.chunkMu.Lock()
+		modified := make(map[ChunkPos]*Column)
+		for pos, c := range w.chunks {
+			c.Lock()
+			if c.modified {
+				modified[pos] = c
+			}
+			c.Unlock()
+		}
+		w.chunkMu.Unlock()
+
+		for pos, c := range modified {
+			w.flushChunk(pos, c)
+		}
+		w.provider().SaveSettings(w.set)
+	}
+	snap, err := p.Snapshot()
+	if err != nil {
+		return fmt.Errorf("backup world: %w", err)
+	}
+	defer snap.Release()
+
+	if err := snap.WriteArchive(wr); err != nil {
+		return fmt.Errorf("backup world: %w", err)
+	}
+	return nil
+}
+
+// flushChunk stores a Column to the Provider if it was modified or holds (block) entities, without removing it from
+// the cache.
+func (w *World) flushChunk(pos ChunkPos, c *Column) {
+	c.Lock()
+	defer c.Unlock()
+	if len(c.BlockEntities) > 0 || len(c.Entities) > 0 || c.modified {
+		c.Compact()
+		if err := w.provider().StoreColumn(pos, w.conf.Dim, c); err != nil {
+			w.conf.Log.Errorf("save chunk: %v", err)
+		}
+		c.modified = false
+	}
+}
+
 // Close closes the world and saves all chunks currently loaded.
 func (w *World) Close() error {
 	if w == nil {
@@ -1077,6 +1268,7 @@ func (w *World) addWorldViewer(l *Loader) {
 	w.set.Unlock()
 	l.viewer.ViewWeather(raining, thundering)
 	l.viewer.ViewWorldSpawn(w.Spawn())
+	l.viewer.ViewGameRules(w.GameRules())
 }
 
 // removeWorldViewer removes a viewer from the world. Should only be used while the viewer isn't viewing any chunks.
@@ -1186,8 +1378,9 @@ func (w *World) chunk(pos ChunkPos) *Column {
 			return c
 		}
 		c.Unlock()
-		w.chunkMu.Lock()
+		w.populate(pos)
 
+		w.chunkMu.Lock()
 		w.calculateLight(pos)
 	}
 	w.lastChunk, w.lastPos = c, pos
@@ -1242,6 +1435,8 @@ func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
 	case errors.Is(err, leveldb.ErrNotFound):
 		// The provider doesn't have a chunk saved at this position, so we generate a new one.
 		col = newColumn(chunk.New(airRID, w.Range()))
+		_, populator := w.conf.Generator.(Populator)
+		col.Populated = !populator
 		w.chunks[pos] = col
 
 		col.Lock()
@@ -1333,7 +1528,7 @@ func (w *World) chunkCacheJanitor() {
 			w.chunkMu.Lock()
 			for pos, c := range w.chunks {
 				c.Lock()
-				v := len(c.viewers)
+				v := len(c.viewers) + c.populating
 				c.Unlock()
 				if v == 0 {
 					chunksToRemove[pos] = c
@@ -1357,12 +1552,37 @@ func (w *World) chunkCacheJanitor() {
 	}
 }
 
+// autosave runs until the world is closed, saving the World every AutosaveInterval of the Config.
+func (w *World) autosave() {
+	t := time.NewTicker(w.conf.AutosaveInterval)
+	defer t.Stop()
+
+	w.running.Add(1)
+	for {
+		select {
+		case <-t.C:
+			w.conf.Log.Debugf("Autosaving world...")
+			w.Save()
+		case <-w.closing:
+			w.running.Done()
+			return
+		}
+	}
+}
+
 // Column represents the data of a chunk including the block entities and loaders. This data is protected
 // by the mutex present in the chunk.Chunk held.
 type Column struct {
 	sync.Mutex
 	modified bool
 
+	// Populated specifies if the Column was populated by the Populator of the World. A Column that was not yet
+	// populated is populated as soon as all of its neighbours are loaded.
+	Populated bool
+	// populating is the amount of pending populations that the Column is part of. Columns that are about to be
+	// populated are not unloaded.
+	populating int
+
 	*chunk.Chunk
 	Entities      []Entity
 	BlockEntities map[cube.Pos]Block
@@ -1371,6 +1591,13 @@ type Column struct {
 	loaders []*Loader
 }
 
+// populated checks if the Column was populated.
+func (c *Column) populated() bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.Populated
+}
+
 // newColumn returns a new Column wrapper around the chunk.Chunk passed.
 func newColumn(c *chunk.Chunk) *Column {
 	return &Column{Chunk: c, BlockEntities: map[cube.Pos]Block{}}
@@ -0,0 +1,302 @@
+package server
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Names of the default worlds of a Server, as registered in its WorldManager.
+const (
+	OverworldName = "overworld"
+	NetherName    = "nether"
+	EndName       = "end"
+)
+
+// WorldManager manages the worlds of a Server. Besides the default overworld,
+// nether and end, worlds may be created, loaded, saved and unloaded at
+// runtime. Every world is registered under a unique name, by which it may be
+// looked up. A WorldManager is obtained by calling Server.Worlds.
+type WorldManager struct {
+	srv *Server
+	dir string
+
+	mu     sync.RWMutex
+	worlds map[string]*world.World
+	// links holds the names of the destination worlds of portals in each
+	// world, per portal world.Dimension.
+	links map[string]map[world.Dimension]string
+}
+
+// newWorldManager creates a WorldManager for the Server passed that loads
+// worlds by name from the directory passed.
+func newWorldManager(srv *Server, dir string) *WorldManager {
+	return &WorldManager{
+		srv:    srv,
+		dir:    dir,
+		worlds: make(map[string]*world.World),
+		links:  make(map[string]map[world.Dimension]string),
+	}
+}
+
+// Create creates a new world using the world.Config passed and registers it
+// under the name passed. The world is stored by the world.Provider in the
+// Config, so if conf.Provider is nil, none of its data is stored. If
+// conf.Entities is empty, it is set to the entity registry of the Server, and
+// if conf.AutosaveInterval is 0, the world is autosaved at the interval of the
+// Server. The world.Provider in conf is not closed when the world is
+// unloaded, as it was not opened by the WorldManager. An error is returned if
+// a world with the same name is already registered.
+func (m *WorldManager) Create(name string, conf world.Config) (*world.World, error) {
+	return m.register(name, conf, false)
+}
+
+// register creates a world using the world.Config passed and registers it
+// under the name passed, like Create. If owned is true, the world.Provider of
+// conf is closed when the world is closed.
+func (m *WorldManager) register(name string, conf world.Config, owned bool) (*world.World, error) {
+	if name == "" {
+		return nil, fmt.Errorf("create world: name must not be empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.worlds[name]; ok {
+		return nil, fmt.Errorf("create world %v: world already exists", name)
+	}
+	return m.create(name, conf, owned), nil
+}
+
+// Load loads the world with the name passed from the worlds folder of the
+// Server, creating it if it does not yet exist, and registers it under that
+// name. The world is stored in a mcdb.DB in the folder, so conf.Provider is
+// ignored. If conf.Generator is nil, the default generator of conf.Dim is
+// used with the seed of the world. An error is returned if a world with the
+// same name is already registered or if the world could not be opened.
+func (m *WorldManager) Load(name string, conf world.Config) (*world.World, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("load world %v: invalid world name", name)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.worlds[name]; ok {
+		return nil, fmt.Errorf("load world %v: world already loaded", name)
+	}
+	db, err := mcdb.Config{Log: m.srv.conf.Log, Entities: m.srv.conf.Entities, ReadOnly: conf.ReadOnly}.Open(filepath.Join(m.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("load world %v: %w", name, err)
+	}
+	conf.Provider = db
+	if conf.Generator == nil {
+		dim := conf.Dim
+		if dim == nil {
+			dim = world.Overworld
+		}
+		conf.Generator = loadGenerator(dim, db.Settings().Seed)
+	}
+	return m.create(name, conf, true), nil
+}
+
+// create creates a world using the world.Config passed and registers it under
+// a name. If owned is false, the world.Provider of conf is wrapped so that it
+// is not closed when the world is closed. m.mu must be held when calling
+// create.
+func (m *WorldManager) create(name string, conf world.Config, owned bool) *world.World {
+	if !owned && conf.Provider != nil {
+		conf.Provider = unowned(conf.Provider)
+	}
+	if conf.Log == nil {
+		conf.Log = m.srv.conf.Log
+		if v, ok := conf.Log.(interface {
+			WithField(key string, field any) *logrus.Entry
+		}); ok {
+			// Add a world field to be able to distinguish between the
+			// different worlds in the log.
+			conf.Log = v.WithField("world", name)
+		}
+	}
+	if len(conf.Entities.Types()) == 0 {
+		conf.Entities = m.srv.conf.Entities
+	}
+	if conf.AutosaveInterval == 0 {
+		conf.AutosaveInterval = m.srv.conf.AutosaveInterval
+	}
+	conf.PortalDestination = func(dim world.Dimension) *world.World {
+		return m.portalDestination(name, dim)
+	}
+	w := conf.New()
+	m.worlds[name] = w
+	return w
+}
+
+// Unload unloads the world registered under the name passed, saving all of
+// its data and closing it. Players in the world are moved to the spawn of the
+// default overworld first. The default worlds of the Server cannot be
+// unloaded. Portals linked to the world stop functioning until a world with
+// the same name is registered again.
+func (m *WorldManager) Unload(name string) error {
+	if name == OverworldName || name == NetherName || name == EndName {
+		return fmt.Errorf("unload world %v: default worlds cannot be unloaded", name)
+	}
+	m.mu.Lock()
+	w, ok := m.worlds[name]
+	delete(m.worlds, name)
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unload world %v: world not loaded", name)
+	}
+
+	for _, p := range m.srv.Players() {
+		if p.World() != w {
+			continue
+		}
+		// Teleport the player before adding it to the overworld, so that no
+		// chunks are loaded in the overworld at its old position.
+		p.Teleport(m.srv.world.Spawn().Vec3Middle())
+		m.srv.world.AddEntity(p)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("unload world %v: %w", name, err)
+	}
+	return nil
+}
+
+// Save saves the chunks and settings of the world registered under the name
+// passed without unloading it. An error is returned if no such world is
+// loaded.
+func (m *WorldManager) Save(name string) error {
+	w, ok := m.World(name)
+	if !ok {
+		return fmt.Errorf("save world %v: world not loaded", name)
+	}
+	w.Save()
+	return nil
+}
+
+// SaveAll saves the chunks and settings of all worlds of the WorldManager.
+func (m *WorldManager) SaveAll() {
+	for _, w := range m.Worlds() {
+		w.Save()
+	}
+}
+
+// World looks up the world registered under the name passed. If found, the
+// world is returned and the bool returned is true.
+func (m *WorldManager) World(name string) (*world.World, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	w, ok := m.worlds[name]
+	return w, ok
+}
+
+// Name returns the name that the world passed is registered under. If the
+// world is not registered in the WorldManager, false is returned.
+func (m *WorldManager) Name(w *world.World) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for name, v := range m.worlds {
+		if v == w {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Names returns the sorted names of all worlds currently registered.
+func (m *WorldManager) Names() []string {
+	m.mu.RLock()
+	names := maps.Keys(m.worlds)
+	m.mu.RUnlock()
+	slices.Sort(names)
+	return names
+}
+
+// Worlds returns all worlds currently registered.
+func (m *WorldManager) Worlds() []*world.World {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Values(m.worlds)
+}
+
+// Link links the portals of a world.Dimension in the world with the name from
+// to the world with the name to. Players entering such a portal in the world
+// from are transported to the world to. Passing world.Nether as dimension
+// links nether portals, while world.End links end portals. The worlds do not
+// need to be loaded when linking them. Link may be called again to change an
+// existing link.
+func (m *WorldManager) Link(from string, dim world.Dimension, to string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.links[from]; !ok {
+		m.links[from] = make(map[world.Dimension]string)
+	}
+	m.links[from][dim] = to
+}
+
+// Unlink removes the link of portals of a world.Dimension in the world with
+// the name passed, so that those portals no longer function.
+func (m *WorldManager) Unlink(from string, dim world.Dimension) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.links[from], dim)
+}
+
+// portalDestination returns the world that portals of a world.Dimension in
+// the world with the name passed lead to, or nil if the portals are not
+// linked or the destination world is not loaded.
+func (m *WorldManager) portalDestination(name string, dim world.Dimension) *world.World {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	to, ok := m.links[name][dim]
+	if !ok {
+		return nil
+	}
+	return m.worlds[to]
+}
+
+// close closes all worlds that are not default worlds of the Server.
+func (m *WorldManager) close() {
+	m.mu.Lock()
+	worlds := maps.Clone(m.worlds)
+	m.mu.Unlock()
+	for name, w := range worlds {
+		if name == OverworldName || name == NetherName || name == EndName {
+			continue
+		}
+		if err := w.Close(); err != nil {
+			m.srv.conf.Log.Errorf("Error closing world %v: %v", name, err)
+		}
+	}
+}
+
+// unownedProvider wraps a world.Provider that was not opened by the
+// WorldManager. Closing it has no effect, as it may still be used elsewhere.
+type unownedProvider struct {
+	world.Provider
+}
+
+// Close ...
+func (unownedProvider) Close() error {
+	return nil
+}
+
+// unownedSnapshotter is an unownedProvider for a world.Provider that also
+// implements world.Snapshotter, so that worlds using it can still be backed
+// up.
+type unownedSnapshotter struct {
+	unownedProvider
+	world.Snapshotter
+}
+
+// unowned wraps the world.Provider passed so that closing it has no effect.
+func unowned(p world.Provider) world.Provider {
+	if s, ok := p.(world.Snapshotter); ok {
+		return unownedSnapshotter{unownedProvider: unownedProvider{p}, Snapshotter: s}
+	}
+	return unownedProvider{p}
+}